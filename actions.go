@@ -0,0 +1,349 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+
+	"git-ai-tools/internal/models"
+)
+
+// actionMeta holds the palette metadata of an App method
+type actionMeta struct {
+	title     string
+	category  string
+	params    []string
+	dangerous bool
+}
+
+// actionRegistry describes the App methods shown in the command palette
+// Methods missing here are still listed by ListActions under the "other" category
+var actionRegistry = map[string]actionMeta{
+	// Repository
+	"SelectRepository":           {"打开仓库", "repository", []string{"path"}, false},
+	"OpenRepository":             {"打开已保存的仓库", "repository", []string{"id"}, false},
+	"InitRepository":             {"初始化仓库", "repository", []string{"path"}, false},
+	"HandleDroppedPath":          {"添加拖入的文件夹", "repository", []string{"path"}, false},
+	"CloneRepository":            {"克隆仓库", "repository", []string{"url", "path", "branch"}, false},
+	"CloneRepositoryWithOptions": {"按选项克隆仓库", "repository", []string{"opts"}, true},
+	"GetSetupCommands":           {"仓库的初始化命令", "repository", nil, false},
+	"RunSetupCommand":            {"运行确认后的初始化命令", "repository", []string{"command"}, true},
+	"SuggestClonePath":           {"建议克隆目录", "repository", []string{"url"}, false},
+	"GetCloneSettings":           {"克隆设置", "repository", nil, false},
+	"SetCloneSettings":           {"修改克隆设置", "repository", []string{"settings"}, false},
+	"GetCurrentRepository":       {"当前仓库路径", "repository", nil, false},
+	"GetRecentRepositories":      {"最近打开的仓库", "repository", nil, false},
+	"RemoveRecentRepository":     {"移除最近打开的仓库", "repository", []string{"path"}, false},
+	"GetRepositoryInfo":          {"仓库信息", "repository", nil, false},
+	"CheckRepositoryHealth":      {"检查仓库健康状况", "repository", nil, false},
+	"GetTeamConfig":              {"团队配置", "repository", nil, false},
+	"RunMaintenance":             {"执行仓库维护", "repository", []string{"action"}, false},
+	"IsValidGitRepository":       {"检查是否为 Git 仓库", "repository", []string{"path"}, false},
+	"GetAllRepositories":         {"仓库列表", "repository", nil, false},
+	"GetRepository":              {"仓库详情", "repository", []string{"id"}, false},
+	"AddRepository":              {"添加仓库", "repository", []string{"path", "alias", "description"}, false},
+	"UpdateRepository":           {"更新仓库", "repository", []string{"id", "alias", "description"}, false},
+	"UpdateRepositoryAlias":      {"设置仓库别名", "repository", []string{"id", "alias"}, false},
+	"SetRepositoryScope":         {"设置仓库路径范围", "repository", []string{"id", "scope"}, false},
+	"SetRepositoryDefaults":      {"设置仓库默认远程和分支", "repository", []string{"id", "defaults"}, false},
+	"DeleteRepository":           {"删除仓库", "repository", []string{"id"}, true},
+	"SearchRepositories":         {"搜索仓库", "repository", []string{"keyword"}, false},
+	"TakePendingCloneLink":       {"获取待处理的克隆链接", "repository", nil, false},
+	"GetPendingOperations":       {"未完成的操作", "repository", nil, false},
+
+	"ResumeOrCleanupPendingOperations": {"恢复或清理未完成的操作", "repository", nil, false},
+
+	// Remotes
+	"GetRemotes":           {"远程仓库列表", "remote", nil, false},
+	"GetRemoteNames":       {"远程仓库名称", "remote", nil, false},
+	"AddRemote":            {"添加远程仓库", "remote", []string{"name", "url"}, false},
+	"AddRemoteVerified":    {"添加并验证远程仓库", "remote", []string{"opts"}, false},
+	"RemoveRemote":         {"删除远程仓库", "remote", []string{"name"}, true},
+	"Push":                 {"推送", "remote", []string{"opts"}, false},
+	"Fetch":                {"获取", "remote", []string{"remote", "prune", "tags"}, false},
+	"FetchAll":             {"获取全部远程", "remote", []string{"prune", "tags"}, false},
+	"CheckRemoteStaleness": {"检查远程引用是否过期", "remote", nil, false},
+	"GetFetchPolicy":       {"自动获取设置", "remote", nil, false},
+	"SetFetchPolicy":       {"保存自动获取设置", "remote", []string{"policy"}, false},
+	"GetWebURLs":           {"在网页中打开", "remote", []string{"kind", "ref", "path", "line"}, false},
+	"Pull":                 {"拉取", "remote", []string{"remote", "branch"}, false},
+
+	// Status and staging
+	"GetStatus":             {"刷新状态", "changes", nil, false},
+	"GetStatusWithOptions":  {"刷新状态（选项）", "changes", []string{"opts"}, false},
+	"ExpandUntrackedDir":    {"展开未跟踪目录", "changes", []string{"dir", "threshold"}, false},
+	"StageFiles":            {"暂存文件", "changes", []string{"files"}, false},
+	"StageAll":              {"暂存全部", "changes", nil, false},
+	"UnstageFiles":          {"取消暂存文件", "changes", []string{"files"}, false},
+	"UnstageAll":            {"取消暂存全部", "changes", nil, false},
+	"StageChanges":          {"暂存变更", "changes", []string{"changes"}, false},
+	"UnstageChanges":        {"取消暂存变更", "changes", []string{"changes"}, false},
+	"GetChangeDiff":         {"查看变更差异", "changes", []string{"change", "staged"}, false},
+	"GetDiff":               {"查看文件差异", "changes", []string{"filePath", "staged"}, false},
+	"GetStructuredDiff":     {"查看结构化文件差异", "changes", []string{"filePath", "staged"}, false},
+	"ExportSelectionPatch":  {"导出所选文件的补丁", "changes", []string{"files", "staged", "destination"}, false},
+	"GetFileDiffBetween":    {"比较文件的两个版本", "changes", []string{"path", "revA", "revB"}, false},
+	"CompareWithBranch":     {"与分支比较", "changes", []string{"path", "branch"}, false},
+	"GetFileAtRevision":     {"查看文件历史版本", "changes", []string{"path", "rev"}, false},
+	"ExportArchive":         {"导出归档", "changes", []string{"rev", "format", "dest"}, false},
+	"GetMaxOutput":          {"输出大小上限", "changes", nil, false},
+	"SetMaxOutput":          {"设置输出大小上限", "changes", []string{"limit"}, false},
+	"DiscardChanges":        {"丢弃更改", "changes", []string{"filePath"}, true},
+	"StagePaths":            {"暂存所选", "changes", []string{"paths"}, false},
+	"UnstagePaths":          {"取消暂存所选", "changes", []string{"paths"}, false},
+	"DiscardPaths":          {"丢弃所选更改", "changes", []string{"paths"}, true},
+	"CleanPaths":            {"删除未跟踪文件", "changes", []string{"paths"}, true},
+	"ListDiscarded":         {"回收站", "changes", nil, false},
+	"GetDiscardedPatch":     {"查看已丢弃的更改", "changes", []string{"id"}, false},
+	"RestoreDiscarded":      {"恢复已丢弃的更改", "changes", []string{"id"}, false},
+	"GetProtectedPaths":     {"受保护的文件", "changes", nil, false},
+	"SetProtectedPaths":     {"设置受保护的文件", "changes", []string{"protected"}, false},
+	"ConfirmProtectedPaths": {"确认丢弃受保护的文件", "changes", []string{"paths"}, true},
+	"CheckLineEndings":      {"检查换行符和编码", "changes", nil, false},
+	"ApplyAttributesFix":    {"写入 .gitattributes 修复", "changes", []string{"lines"}, false},
+
+	// Email patches
+	"FormatPatchSeries": {"生成补丁系列", "patch", []string{"revRange", "coverLetter"}, false},
+	"SendEmailPatch":    {"通过邮件发送补丁", "patch", []string{"opts"}, false},
+	"GetSMTPConfig":     {"邮件发送设置", "patch", nil, false},
+	"SetSMTPConfig":     {"保存邮件发送设置", "patch", []string{"config"}, false},
+
+	// Backups
+	"GetBackupTargets":   {"备份远程列表", "remote", nil, false},
+	"SaveBackupTarget":   {"保存备份远程", "remote", []string{"target"}, false},
+	"DeleteBackupTarget": {"删除备份远程", "remote", []string{"id"}, true},
+	"RunBackup":          {"立即备份", "remote", []string{"id"}, false},
+
+	// Sharing
+	"ShareRepository": {"局域网共享仓库", "remote", []string{"port", "readOnly"}, false},
+	"StopSharing":     {"停止共享", "remote", nil, false},
+	"GetShareStatus":  {"共享状态", "remote", nil, false},
+
+	// Snapshots
+	"CreateSnapshot":      {"创建快照", "snapshot", []string{"message"}, false},
+	"ListSnapshots":       {"快照列表", "snapshot", nil, false},
+	"GetSnapshotDiff":     {"快照与工作区差异", "snapshot", []string{"id"}, false},
+	"RestoreSnapshot":     {"恢复快照", "snapshot", []string{"id"}, true},
+	"DeleteSnapshot":      {"删除快照", "snapshot", []string{"id"}, true},
+	"TryChanges":          {"试用补丁", "snapshot", []string{"patch"}, false},
+	"RevertTry":           {"撤销试用的补丁", "snapshot", nil, false},
+	"KeepTry":             {"保留试用的补丁", "snapshot", nil, false},
+	"GetTryState":         {"试用补丁状态", "snapshot", nil, false},
+	"ApplySuggestedPatch": {"应用建议的补丁", "snapshot", []string{"filePath", "unifiedDiff"}, false},
+
+	// Commit
+	"Commit":                         {"提交", "commit", []string{"message"}, false},
+	"CommitPaths":                    {"提交所选文件", "commit", []string{"message", "paths"}, false},
+	"GetCommitTrailers":              {"提交尾注", "commit", nil, false},
+	"SetCommitTrailers":              {"设置提交尾注", "commit", []string{"trailers"}, false},
+	"CommitFixup":                    {"创建修正提交", "commit", []string{"targetHash"}, false},
+	"AutosquashRebase":               {"合并修正提交", "commit", []string{"base"}, true},
+	"RewordCommit":                   {"修改提交说明", "commit", []string{"hash", "newMessage"}, true},
+	"GenerateRewordSuggestion":       {"AI 建议提交说明", "ai", []string{"hash"}, false},
+	"ReorderCommits":                 {"调整提交顺序", "commit", []string{"base", "newOrder"}, true},
+	"GetRewrittenCommits":            {"已推送的待改写提交", "commit", []string{"base", "tip"}, false},
+	"AcknowledgeRewrite":             {"确认改写已推送的提交", "commit", []string{"hashes"}, true},
+	"PreviewRebaseOnto":              {"变基到新基点预览", "commit", []string{"newBase", "oldBase", "branch"}, false},
+	"RebaseOnto":                     {"变基到新基点", "commit", []string{"newBase", "oldBase", "branch"}, true},
+	"CommitAllowEmpty":               {"创建空提交", "commit", []string{"message"}, false},
+	"CreateInitialCommit":            {"创建初始提交", "commit", []string{"message"}, false},
+	"PreviewCommit":                  {"预览提交", "commit", []string{"message"}, false},
+	"GenerateCommitMessage":          {"AI 生成提交信息", "commit", nil, false},
+	"RegenerateCommitMessage":        {"AI 按意见修改提交信息", "commit", []string{"previous", "feedback"}, false},
+	"GenerateHeuristicCommitMessage": {"按规则生成提交信息", "commit", nil, false},
+	"CancelGeneration":               {"停止 AI 生成", "commit", nil, false},
+	"GetOwnersForChanges":            {"暂存文件的代码负责人", "commit", nil, false},
+	"SuggestTestsForChanges":         {"暂存更改的测试建议", "commit", nil, false},
+	"ContinueOperation":              {"继续当前操作", "commit", nil, false},
+	"AbortOperation":                 {"中止当前操作", "commit", nil, true},
+	"SkipOperationStep":              {"跳过当前提交", "commit", nil, true},
+
+	// Branches and tags
+	"GetBranches":                {"分支列表", "branch", nil, false},
+	"CheckoutBranch":             {"切换分支", "branch", []string{"branch"}, false},
+	"CheckoutBranchWithStrategy": {"切换分支（处理未提交的更改）", "branch", []string{"branch", "strategy"}, true},
+	"GetDirtyPaths":              {"未提交更改的文件", "branch", nil, false},
+	"CreateBranch":               {"新建分支", "branch", []string{"branch", "checkout"}, false},
+	"MergeBranch":                {"合并分支", "branch", []string{"branch", "noFF"}, false},
+	"PredictMergeConflicts":      {"预测合并冲突", "branch", []string{"source", "target"}, false},
+	"PreviewDeleteBranch":        {"删除分支前检查", "branch", []string{"name"}, false},
+	"DeleteBranch":               {"删除分支", "branch", []string{"name", "force"}, true},
+	"DiffBranches":               {"比较分支", "branch", []string{"branch1", "branch2"}, false},
+	"GetTags":                    {"标签列表", "branch", nil, false},
+	"CreateTag":                  {"创建标签", "branch", []string{"name", "message", "commit"}, false},
+	"GenerateChangelog":          {"生成变更日志", "branch", []string{"fromRef", "toRef", "useAI"}, false},
+	"DeleteTag":                  {"删除标签", "branch", []string{"name"}, true},
+	"CheckoutTag":                {"检出标签", "branch", []string{"name"}, false},
+
+	// History
+	"GetLog":                    {"提交历史", "history", []string{"limit"}, false},
+	"GetCommitGraph":            {"提交关系图", "history", []string{"limit"}, false},
+	"GetLogWithOptions":         {"筛选提交历史", "history", []string{"opts"}, false},
+	"GetLogStream":              {"流式加载提交历史", "history", []string{"opts", "chunkSize"}, false},
+	"CancelLogStream":           {"停止加载提交历史", "history", []string{"id"}, false},
+	"GetCommitDetail":           {"提交详情", "history", []string{"commitHash"}, false},
+	"FormatCommitReference":     {"复制提交引用", "history", []string{"hash", "style"}, false},
+	"GetAuthorAvatars":          {"作者头像", "history", []string{"emails"}, false},
+	"GetBlame":                  {"逐行追溯", "history", []string{"filePath", "rev"}, false},
+	"WhenWasLineChanged":        {"查看行修改历史", "history", []string{"filePath", "lines", "limit"}, false},
+	"FindCommitsTouchingString": {"搜索代码变更", "history", []string{"text", "regex", "filePath"}, false},
+	"AnalyzeCommitQuality":      {"提交信息质量分析", "history", []string{"limit"}, false},
+	"SemanticSearchCommits":     {"语义搜索提交", "history", []string{"query", "limit"}, false},
+	"ResolveRef":                {"解析引用", "history", []string{"ref"}, false},
+	"ResolveLocation":           {"跳转到引用", "history", []string{"token"}, false},
+	"Reset":                     {"回滚", "history", []string{"resetType", "commit"}, true},
+	"Revert":                    {"撤销提交", "history", []string{"commit", "noCommit"}, false},
+
+	// Forge
+	"GetForgeConfigs":                {"代码托管平台配置", "forge", nil, false},
+	"SetForgeConfig":                 {"设置代码托管平台", "forge", []string{"config"}, false},
+	"DeleteForgeConfig":              {"删除代码托管账号", "forge", []string{"id"}, true},
+	"ValidateForgeAccount":           {"验证代码托管令牌", "forge", []string{"id"}, false},
+	"ListRemoteRepositories":         {"浏览远程仓库", "forge", []string{"provider", "query"}, false},
+	"GetChecksStatus":                {"CI 状态", "forge", []string{"ref"}, false},
+	"PreflightPush":                  {"推送前检查分支保护", "forge", []string{"remote", "branch"}, false},
+	"GeneratePullRequestDescription": {"生成拉取请求描述", "forge", []string{"baseBranch"}, false},
+	"GetPullRequestComments":         {"拉取请求评论", "forge", nil, false},
+	"GetMyReviewQueue":               {"待我审查", "forge", nil, false},
+	"CheckoutReviewRequest":          {"检出待审查分支", "forge", []string{"request"}, false},
+	"CheckoutPullRequest":            {"检出拉取请求", "forge", []string{"number"}, false},
+	"ListIssues":                     {"问题列表", "forge", []string{"provider", "filter"}, false},
+	"GetIssueBranchPattern":          {"问题分支命名规则", "forge", nil, false},
+	"SetIssueBranchPattern":          {"设置问题分支命名规则", "forge", []string{"pattern"}, false},
+	"CreateBranchFromIssue":          {"从问题创建分支", "forge", []string{"provider", "issueNumber"}, false},
+
+	// AI
+	"GetAIConfig":              {"AI 配置", "ai", nil, false},
+	"SetAIConfig":              {"保存 AI 配置", "ai", []string{"config"}, false},
+	"TestAIConnection":         {"测试 AI 连接", "ai", []string{"config"}, false},
+	"GetMessageStyle":          {"提交信息格式设置", "ai", nil, false},
+	"SetMessageStyle":          {"保存提交信息格式设置", "ai", []string{"style"}, false},
+	"GetCommitGenerator":       {"提交信息生成方式", "ai", nil, false},
+	"SetCommitGenerator":       {"设置提交信息生成方式", "ai", []string{"generator"}, false},
+	"GetAIContextFilter":       {"AI 忽略文件设置", "ai", nil, false},
+	"SetAIContextFilter":       {"保存 AI 忽略文件设置", "ai", []string{"filter"}, false},
+	"GetSemanticSearchEnabled": {"语义搜索设置", "ai", nil, false},
+	"SetSemanticSearchEnabled": {"开启或关闭语义搜索", "ai", []string{"enabled"}, false},
+	"RefreshSemanticIndex":     {"更新语义索引", "ai", nil, false},
+	"ClearSemanticIndex":       {"清除语义索引", "ai", nil, true},
+
+	// Prompts and commands
+	"GetPrompts":            {"提示词列表", "template", nil, false},
+	"GetPrompt":             {"提示词详情", "template", []string{"id"}, false},
+	"GetDefaultPrompt":      {"默认提示词", "template", nil, false},
+	"CreatePrompt":          {"新建提示词", "template", []string{"name", "description", "template", "isDefault"}, false},
+	"UpdatePrompt":          {"更新提示词", "template", []string{"id", "name", "description", "template", "isDefault"}, false},
+	"DeletePrompt":          {"删除提示词", "template", []string{"id"}, true},
+	"SetDefaultPrompt":      {"设为默认提示词", "template", []string{"id"}, false},
+	"GetPromptHistory":      {"提示词历史版本", "template", []string{"id"}, false},
+	"RollbackPrompt":        {"恢复提示词版本", "template", []string{"id", "versionID"}, false},
+	"GetPromptLibrary":      {"提示词库", "template", nil, false},
+	"InstallLibraryPrompt":  {"安装提示词", "template", []string{"id"}, false},
+	"UpdateLibraryPrompt":   {"更新提示词", "template", []string{"id"}, false},
+	"GetCommands":           {"自定义命令列表", "template", nil, false},
+	"GetCommand":            {"自定义命令详情", "template", []string{"id"}, false},
+	"GetCommandsByCategory": {"按分类查看命令", "template", []string{"category"}, false},
+	"GetCategories":         {"命令分类", "template", nil, false},
+	"CreateCommand":         {"新建自定义命令", "template", []string{"name", "description", "command", "category"}, false},
+	"UpdateCommand":         {"更新自定义命令", "template", []string{"id", "name", "description", "command", "category"}, false},
+	"DeleteCommand":         {"删除自定义命令", "template", []string{"id"}, true},
+	"GetCommandHistory":     {"自定义命令历史版本", "template", []string{"id"}, false},
+	"RollbackCommand":       {"恢复自定义命令版本", "template", []string{"id", "versionID"}, false},
+
+	// Utilities
+	"SelectDirectory":          {"选择目录", "utility", nil, false},
+	"CopyToClipboard":          {"复制到剪贴板", "utility", []string{"text"}, false},
+	"OpenRepositoryInTerminal": {"在终端中打开", "utility", nil, false},
+	"OpenFileInEditor":         {"在编辑器中打开", "utility", []string{"filePath"}, false},
+	"ListActions":              {"命令列表", "utility", nil, false},
+	"GetPerformanceReport":     {"性能报告", "utility", []string{"days"}, false},
+	"SetPerformanceTracing":    {"性能记录开关", "utility", []string{"enabled"}, false},
+	"ClearPerformanceData":     {"清除性能记录", "utility", nil, true},
+	"GetFSMonitorStatus":       {"文件系统监视状态", "utility", nil, false},
+	"SetFSMonitor":             {"文件系统监视开关", "utility", []string{"enabled"}, false},
+	"GetPathOverride":          {"git 的 PATH 设置", "utility", nil, false},
+	"SetPathOverride":          {"设置 git 的 PATH", "utility", []string{"path"}, false},
+	"GetGitExecutable":         {"git 程序设置", "utility", nil, false},
+	"SetGitExecutable":         {"设置 git 程序和全局参数", "utility", []string{"exe"}, false},
+	"GetOfflineMode":           {"离线模式状态", "utility", nil, false},
+	"SetOfflineMode":           {"离线模式开关", "utility", []string{"offline"}, false},
+	"GetAppVersion":            {"应用版本", "utility", nil, false},
+	"GetUpdateChannel":         {"更新通道", "utility", nil, false},
+	"SetUpdateChannel":         {"设置更新通道", "utility", []string{"channel"}, false},
+	"CheckForUpdates":          {"检查更新", "utility", nil, false},
+	"DownloadUpdate":           {"下载更新", "utility", nil, false},
+	"InstallUpdateOnRestart":   {"重启后安装更新", "utility", nil, true},
+	"GetRecentLogs":            {"查看日志", "utility", []string{"level", "limit"}, false},
+	"GetCrashReporting":        {"崩溃报告设置", "utility", nil, false},
+	"SetCrashReporting":        {"崩溃报告开关", "utility", []string{"enabled"}, false},
+	"ListCrashReports":         {"崩溃报告列表", "utility", nil, false},
+	"ExportCrashReport":        {"导出崩溃报告", "utility", []string{"id"}, false},
+	"SubmitCrashReport":        {"提交崩溃报告", "utility", []string{"id"}, false},
+	"DeleteCrashReport":        {"删除崩溃报告", "utility", []string{"id"}, true},
+	"GetLogDirectory":          {"日志目录", "utility", nil, false},
+}
+
+// ListActions returns a catalog of every bound App method
+// Parameter types come from the method signatures so the catalog cannot drift from the code
+func (a *App) ListActions() []models.ActionInfo {
+	appType := reflect.TypeOf(a)
+	actions := make([]models.ActionInfo, 0, appType.NumMethod())
+
+	for i := 0; i < appType.NumMethod(); i++ {
+		method := appType.Method(i)
+		meta, ok := actionRegistry[method.Name]
+		if !ok {
+			meta = actionMeta{title: method.Name, category: "other"}
+		}
+
+		// The first input is the receiver
+		params := []models.ActionParam{}
+		for j := 1; j < method.Type.NumIn(); j++ {
+			name := ""
+			if j-1 < len(meta.params) {
+				name = meta.params[j-1]
+			}
+			if name == "" {
+				name = "arg" + string(rune('0'+j))
+			}
+			params = append(params, models.ActionParam{
+				Name: name,
+				Type: schemaType(method.Type.In(j)),
+			})
+		}
+
+		actions = append(actions, models.ActionInfo{
+			ID:        method.Name,
+			Title:     meta.title,
+			Category:  meta.category,
+			Dangerous: meta.dangerous,
+			Params:    params,
+		})
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Category != actions[j].Category {
+			return actions[i].Category < actions[j].Category
+		}
+		return actions[i].ID < actions[j].ID
+	})
+	return actions
+}
+
+// schemaType maps a Go type to its JSON schema type name
+func schemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -2,44 +2,184 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"git-ai-tools/internal/ai"
+	"git-ai-tools/internal/avatar"
+	"git-ai-tools/internal/backup"
+	"git-ai-tools/internal/codeowners"
 	"git-ai-tools/internal/config"
+	"git-ai-tools/internal/crash"
+	"git-ai-tools/internal/database"
+	"git-ai-tools/internal/deps"
+	"git-ai-tools/internal/forge"
 	"git-ai-tools/internal/git"
+	"git-ai-tools/internal/jobs"
+	"git-ai-tools/internal/logging"
+	"git-ai-tools/internal/mail"
 	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/release"
+	"git-ai-tools/internal/semantic"
+	"git-ai-tools/internal/share"
+	"git-ai-tools/internal/shellenv"
+	"git-ai-tools/internal/teamconfig"
+	"git-ai-tools/internal/trace"
+	"git-ai-tools/internal/trash"
+	"git-ai-tools/internal/update"
+	"git-ai-tools/internal/watch"
+	"github.com/google/uuid"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // App struct
 type App struct {
-	ctx            context.Context
-	gitService     *git.GitService
-	aiService      *ai.AIService
-	configService  *config.ConfigService
+	ctx             context.Context
+	gitService      *git.GitService
+	aiService       *ai.AIService
+	configService   *config.ConfigService
+	forgeService    *forge.ForgeService
+	avatarService   *avatar.AvatarService
+	shareService    *share.ShareService
+	backupService   *backup.BackupService
+	semanticIndex   *semantic.Index
+	watcher         *watch.Watcher
 	templateService *TemplateService
+	updateService   *update.UpdateService
+	releaseService  *release.ReleaseService
+	pendingClone    atomic.Pointer[models.CloneOptions]
+	health          *models.RepositoryHealth
+	team            *models.TeamConfig
+	logStreams      sync.Map // stream ID -> context.CancelFunc
+
+	generationMu     sync.Mutex
+	cancelGeneration context.CancelFunc
 }
 
 // NewApp creates a new App application struct
 func NewApp(configService *config.ConfigService) *App {
-	return &App{
-		gitService:     git.NewGitService(),
-		aiService:      ai.NewAIService(),
-		configService:  configService,
+	aiService := ai.NewAIService()
+	gitService := git.NewGitService()
+	a := &App{
+		gitService:      gitService,
+		aiService:       aiService,
+		configService:   configService,
+		forgeService:    forge.NewForgeService(),
+		avatarService:   avatar.NewAvatarService(),
+		shareService:    share.NewShareService(),
+		backupService:   backup.NewBackupService(),
+		semanticIndex:   semantic.NewIndex(aiService),
 		templateService: NewTemplateService(),
+		updateService:   update.NewUpdateService(),
+		releaseService:  release.NewReleaseService(gitService, aiService),
 	}
+	a.watcher = watch.NewWatcher(
+		func(repoPath string) {
+			a.emit("repo:statusChanged", repoPath)
+		},
+		func(repoPath, branch string) {
+			a.emit("repo:branchChanged", models.BranchChange{RepoPath: repoPath, Branch: branch})
+		},
+	)
+	return a
 }
 
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
+	// Find git the way a terminal would, before anything runs it
+	if err := shellenv.Load(); err != nil {
+		logging.Logger().Warn("login shell environment not loaded", "error", err.Error())
+	}
+	if path := a.configService.GetPathOverride(); path != "" {
+		shellenv.OverridePath(path)
+	}
+	if err := git.SetExecutable(a.configService.GetGitExecutable()); err != nil {
+		logging.Logger().Warn("git executable setting ignored", "error", err.Error())
+	}
+
+	// Turn a crash of the last run into a report before capture starts again
+	if report, err := crash.Collect(update.Version, a.crashScrubber()); err != nil {
+		logging.Logger().Warn("failed to collect crash report", "error", err.Error())
+	} else if report != nil {
+		logging.Logger().Info("crash report collected", "id", report.ID)
+	}
+	if a.configService.GetCrashReporting() {
+		if err := crash.Enable(true); err != nil {
+			logging.Logger().Warn("crash reporting not enabled", "error", err.Error())
+		}
+	}
+
 	// Load AI config
 	if aiConfig := a.configService.GetAIConfig(); aiConfig.APIKey != "" {
 		a.aiService.SetConfig(aiConfig)
 	}
+
+	trace.SetEnabled(a.configService.GetTracingEnabled())
+	a.gitService.SetProtectedPaths(a.configService.GetProtectedPaths())
+	a.gitService.SetMaxOutput(a.configService.GetMaxOutput())
+	a.applyOfflineMode(a.configService.GetOfflineMode())
+	a.backupService.Start()
+	update.Cleanup()
+
+	// Make sure git and its helpers do not outlive the app
+	if err := git.ContainChildProcesses(); err != nil {
+		logging.Logger().Warn("child processes are not tied to the app", "error", err.Error())
+	}
+
+	// Reopen the window where it was closed
+	if window := a.configService.GetWindowConfig(); window.X != 0 || window.Y != 0 {
+		runtime.WindowSetPosition(ctx, window.X, window.Y)
+	}
+
+	// Handle a protocol link the app was launched with
+	a.handleArgs(os.Args[1:])
+}
+
+// beforeClose saves the window size and position while the window still exists
+func (a *App) beforeClose(ctx context.Context) bool {
+	if !runtime.WindowIsMaximised(ctx) && !runtime.WindowIsMinimised(ctx) {
+		window := models.WindowConfig{}
+		window.Width, window.Height = runtime.WindowGetSize(ctx)
+		window.X, window.Y = runtime.WindowGetPosition(ctx)
+		if err := a.configService.SetWindowConfig(window); err != nil {
+			logging.Logger().Warn("failed to save window state", "error", err.Error())
+		}
+	}
+	return false
+}
+
+// shutdown is called when the app closes
+// Background work and AI requests are cancelled and running git commands are killed, so no
+// index.lock is left behind, before the database is closed
+func (a *App) shutdown(ctx context.Context) {
+	a.CancelGeneration()
+	a.logStreams.Range(func(_, cancel any) bool {
+		cancel.(context.CancelFunc)()
+		return true
+	})
+	a.watcher.Stop()
+	a.backupService.Stop()
+	if err := a.shareService.Stop(); err != nil {
+		logging.Logger().Warn("failed to stop sharing", "error", err.Error())
+	}
+
+	git.KillRunning()
+
+	if err := a.updateService.InstallPending(); err != nil {
+		logging.Logger().Warn("failed to install update", "error", err.Error())
+	}
+
+	if err := database.Close(); err != nil {
+		logging.Logger().Warn("failed to close database", "error", err.Error())
+	}
 }
 
 // ============ Repository Operations ============
@@ -49,6 +189,27 @@ func (a *App) SelectRepository(path string) error {
 	if err := a.gitService.SetPath(path); err != nil {
 		return err
 	}
+	// Opening a subdirectory selects the repository it belongs to
+	path = a.gitService.GetCurrentPath()
+
+	// Apply the path scope configured for this repository
+	if repo := a.configService.GetRepositoryByPath(path); repo != nil {
+		a.gitService.SetScope(repo.PathScope)
+	}
+
+	// Probe repository health so GetRepositoryInfo can surface warnings
+	a.health, _ = a.gitService.CheckHealth()
+
+	// Apply the team settings committed with the repository
+	a.loadTeamConfig()
+
+	// Report changes made outside the app, such as commits from a terminal
+	a.watcher.Start(path)
+
+	// Embed commits made since the repository was last indexed
+	if a.configService.GetSemanticSearchEnabled() && !a.aiService.IsOffline() {
+		go a.refreshSemanticIndex(path)
+	}
 
 	// Add to recent repos
 	a.configService.AddRecentRepo(path)
@@ -56,17 +217,181 @@ func (a *App) SelectRepository(path string) error {
 	return nil
 }
 
+// overviewLogLimit is the number of commits loaded with a repository overview
+const overviewLogLimit = 20
+
+// OpenRepository selects a registered repository and loads its status, branches, tags,
+// remotes and recent history in parallel, emitting "repository:ready" with the result
+func (a *App) OpenRepository(id string) (*models.RepositoryOverview, error) {
+	repo := a.GetRepository(id)
+	if repo == nil {
+		return nil, fmt.Errorf("repository not found: %s", id)
+	}
+	if err := a.SelectRepository(repo.Path); err != nil {
+		return nil, err
+	}
+
+	// The loaders share one snapshot so a concurrent SelectRepository cannot switch
+	// the repository or scope under them halfway through
+	view := a.gitService.Snapshot()
+	overview := &models.RepositoryOverview{Repository: repo, Errors: map[string]string{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	load := func(part string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				overview.Errors[part] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	load("info", func() (err error) { overview.Info, err = a.repositoryInfo(view); return })
+	load("status", func() (err error) { overview.Status, err = view.GetStatus(); return })
+	load("branches", func() (err error) { overview.Branches, err = view.GetBranches(); return })
+	load("tags", func() (err error) { overview.Tags, err = view.GetTags(); return })
+	load("remotes", func() (err error) { overview.Remotes, err = view.GetRemotes(); return })
+	load("log", func() (err error) { overview.Log, err = view.GetLog(overviewLogLimit); return })
+	wg.Wait()
+
+	a.emit("repository:ready", overview)
+	return overview, nil
+}
+
+// InitRepository creates a new repository and registers it
+func (a *App) InitRepository(path string) error {
+	if err := a.gitService.Init(path); err != nil {
+		return err
+	}
+	path = a.gitService.GetCurrentPath()
+
+	if _, err := a.configService.AddRepository(path, filepath.Base(path), ""); err != nil {
+		return err
+	}
+	return a.SelectRepository(path)
+}
+
+// HandleDroppedPath registers and selects the repository containing a dropped path
+// Plain folders are reported back so the UI can offer init or clone
+func (a *App) HandleDroppedPath(path string) (*models.DroppedPathResult, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("path does not exist: %s", path)
+	}
+
+	root, err := a.gitService.FindRepositoryRoot(path)
+	if err != nil {
+		if !info.IsDir() {
+			path = filepath.Dir(path)
+		}
+		return &models.DroppedPathResult{Kind: "folder", Path: path}, nil
+	}
+
+	if _, err := a.configService.AddRepository(root, filepath.Base(root), ""); err != nil {
+		return nil, err
+	}
+	if err := a.SelectRepository(root); err != nil {
+		return nil, err
+	}
+
+	return &models.DroppedPathResult{
+		Kind:       "repository",
+		Path:       root,
+		Repository: a.configService.GetRepositoryByPath(root),
+	}, nil
+}
+
 // CloneRepository clones a remote repository
 func (a *App) CloneRepository(url, path, branch string) error {
-	opts := models.CloneOptions{
+	return a.CloneRepositoryWithOptions(models.CloneOptions{
 		URL:    url,
 		Path:   path,
 		Branch: branch,
+	})
+}
+
+// errStepSkipped marks a clone step that had nothing to do
+var errStepSkipped = errors.New("step skipped")
+
+// confirmCommands is returned by a clone step whose commands wait for the user to confirm them
+type confirmCommands []string
+
+func (c confirmCommands) Error() string { return "commands need confirmation" }
+
+// CloneRepositoryWithOptions clones a repository, then checks out its submodules and runs
+// the requested post-clone steps, emitting "clone:progress" as each step starts and ends
+// The repository stays registered when a later step fails, so that step can be redone by hand
+func (a *App) CloneRepositoryWithOptions(opts models.CloneOptions) error {
+	type step struct {
+		name string
+		run  func() (string, error)
+	}
+	steps := []step{{models.CloneStepClone, func() (string, error) { return "", a.cloneRepository(opts) }}}
+	if opts.RecurseSubmodules {
+		steps = append(steps, step{models.CloneStepSubmodules, func() (string, error) {
+			return "", a.gitService.UpdateSubmodules(opts.Depth)
+		}})
+	}
+	for _, hook := range opts.PostClone {
+		switch hook {
+		case models.PostCloneLFS:
+			steps = append(steps, step{models.CloneStepLFS, func() (string, error) {
+				return "", a.gitService.PullLFS()
+			}})
+		case models.PostCloneSetup:
+			steps = append(steps, step{models.CloneStepSetup, a.runCloneSetup})
+		default:
+			return fmt.Errorf("unknown post-clone step: %s", hook)
+		}
+	}
+
+	for _, s := range steps {
+		progress := models.CloneProgress{Path: opts.Path, Step: s.name, Status: "running"}
+		a.emit("clone:progress", progress)
+
+		output, err := s.run()
+		progress.Output = output
+		var pending confirmCommands
+		switch {
+		case errors.Is(err, errStepSkipped):
+			progress.Status = "skipped"
+		case errors.As(err, &pending):
+			progress.Status = "confirm"
+			progress.Commands = pending
+		case err != nil:
+			progress.Status = "failed"
+			progress.Error = err.Error()
+		default:
+			progress.Status = "done"
+		}
+		a.emit("clone:progress", progress)
+		if progress.Status == "failed" {
+			return fmt.Errorf("%s step failed: %w", s.name, err)
+		}
 	}
+	return nil
+}
 
-	if err := a.gitService.Clone(opts); err != nil {
+// cloneRepository runs git clone and registers the new repository
+func (a *App) cloneRepository(opts models.CloneOptions) error {
+	// Record the clone so a crash mid-way can be cleaned up on next start
+	_, statErr := os.Stat(opts.Path)
+	jobID := jobs.Begin(models.PendingOperation{
+		Kind:       jobs.KindClone,
+		Repo:       opts.Path,
+		URL:        opts.URL,
+		Branch:     opts.Branch,
+		CreatedDir: os.IsNotExist(statErr),
+	})
+	err := a.gitService.Clone(opts)
+	jobs.Finish(jobID)
+	if err != nil {
 		return err
 	}
+	a.health, _ = a.gitService.CheckHealth()
 
 	// Add to recent repos
 	a.configService.AddRecentRepo(opts.Path)
@@ -74,6 +399,62 @@ func (a *App) CloneRepository(url, path, branch string) error {
 	return nil
 }
 
+// runCloneSetup lists the setup commands of the cloned repository's .gitai.yml without
+// running them; the user confirms each one and runs it with RunSetupCommand
+func (a *App) runCloneSetup() (string, error) {
+	commands, err := a.GetSetupCommands()
+	if err != nil {
+		return "", err
+	}
+	if len(commands) == 0 {
+		return "", errStepSkipped
+	}
+	return "", confirmCommands(commands)
+}
+
+// GetSetupCommands returns the setup commands of the current repository's .gitai.yml
+func (a *App) GetSetupCommands() ([]string, error) {
+	team, err := teamconfig.Load(a.gitService.GetCurrentPath())
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return []string{}, nil
+	}
+	return team.Setup, nil
+}
+
+// RunSetupCommand runs one setup command of the current repository's .gitai.yml after the
+// user confirmed it; commands the file does not list are refused
+func (a *App) RunSetupCommand(command string) (string, error) {
+	commands, err := a.GetSetupCommands()
+	if err != nil {
+		return "", err
+	}
+	for _, c := range commands {
+		if c == command {
+			return a.gitService.RunSetupCommand(command)
+		}
+	}
+	return "", fmt.Errorf("not a setup command of this repository: %s", command)
+}
+
+// GetCloneSettings returns the default clone directory and folder layout
+func (a *App) GetCloneSettings() models.CloneSettings {
+	return a.configService.GetCloneSettings()
+}
+
+// SetCloneSettings updates the default clone directory and folder layout
+func (a *App) SetCloneSettings(settings models.CloneSettings) error {
+	return a.configService.SetCloneSettings(settings)
+}
+
+// SuggestClonePath proposes a destination folder for cloning url into the default clone directory
+func (a *App) SuggestClonePath(url string) (string, error) {
+	settings := a.configService.GetCloneSettings()
+	return forge.SuggestClonePath(settings.Root, settings.Grouping, url)
+}
+
 // GetRemotes returns all remotes in the current repository
 func (a *App) GetRemotes() ([]models.Remote, error) {
 	return a.gitService.GetRemotes()
@@ -81,12 +462,22 @@ func (a *App) GetRemotes() ([]models.Remote, error) {
 
 // AddRemote adds a new remote to the current repository
 func (a *App) AddRemote(name, url string) error {
-	return a.gitService.AddRemote(name, url)
+	return a.changed(a.gitService.AddRemote(name, url), changeRemotes)
+}
+
+// AddRemoteVerified adds a remote after validating its URL, optionally testing and fetching it
+// The remote is rolled back if a check fails
+func (a *App) AddRemoteVerified(opts models.AddRemoteOptions) (*models.AddRemoteResult, error) {
+	result, err := a.gitService.AddRemoteVerified(opts)
+	if err == nil {
+		a.notifyChanged(changeRemotes | changeBranches)
+	}
+	return result, err
 }
 
 // RemoveRemote removes a remote from the current repository
 func (a *App) RemoveRemote(name string) error {
-	return a.gitService.RemoveRemote(name)
+	return a.changed(a.gitService.RemoveRemote(name), changeRemotes|changeBranches)
 }
 
 // GetCurrentRepository returns the current repository path
@@ -99,6 +490,17 @@ func (a *App) GetStatus() (*models.GitStatus, error) {
 	return a.gitService.GetStatus()
 }
 
+// GetStatusWithOptions returns the git status using the given options
+// The UI can pass UntrackedMode "no" for a fast first pass on large repositories
+func (a *App) GetStatusWithOptions(opts models.StatusOptions) (*models.GitStatus, error) {
+	return a.gitService.GetStatusWithOptions(opts)
+}
+
+// ExpandUntrackedDir lists the untracked files below a collapsed directory
+func (a *App) ExpandUntrackedDir(dir string, threshold int) (*models.UntrackedListing, error) {
+	return a.gitService.ExpandUntrackedDir(dir, threshold)
+}
+
 // GetRecentRepositories returns recent repositories
 func (a *App) GetRecentRepositories() []string {
 	return a.configService.GetRecentRepos()
@@ -108,129 +510,2033 @@ func (a *App) GetRecentRepositories() []string {
 
 // StageFiles stages the given files
 func (a *App) StageFiles(files []string) error {
-	return a.gitService.StageFiles(files)
+	return a.changed(a.gitService.StageFiles(files), changeStatus)
 }
 
 // StageAll stages all changes
 func (a *App) StageAll() error {
-	return a.gitService.StageFiles([]string{"."})
+	return a.changed(a.gitService.StageFiles([]string{"."}), changeStatus)
 }
 
 // UnstageFiles unstages the given files
 func (a *App) UnstageFiles(files []string) error {
-	return a.gitService.UnstageFiles(files)
+	return a.changed(a.gitService.UnstageFiles(files), changeStatus)
 }
 
 // UnstageAll unstages all changes
 func (a *App) UnstageAll() error {
-	return a.gitService.UnstageFiles([]string{"."})
+	return a.changed(a.gitService.UnstageFiles([]string{"."}), changeStatus)
+}
+
+// StageChanges stages the given changes, handling both paths of renamed files
+func (a *App) StageChanges(changes []models.FileChange) error {
+	return a.changed(a.gitService.StageChanges(changes), changeStatus)
+}
+
+// UnstageChanges unstages the given changes, handling both paths of renamed files
+func (a *App) UnstageChanges(changes []models.FileChange) error {
+	return a.changed(a.gitService.UnstageChanges(changes), changeStatus)
+}
+
+// GetChangeDiff returns the diff for a change from the status lists
+func (a *App) GetChangeDiff(change models.FileChange, staged bool) (string, error) {
+	return a.gitService.GetChangeDiff(change, staged)
 }
 
 // DiscardChanges discards changes to the given file
 func (a *App) DiscardChanges(filePath string) error {
-	return a.gitService.DiscardChanges(filePath)
+	if err := a.gitService.CheckProtectedDiscard([]string{filePath}); err != nil {
+		return err
+	}
+	a.checkpoint("discard " + filePath)
+	if err := a.moveToTrash(trash.KindDiscard, []string{filePath}); err != nil {
+		return err
+	}
+	return a.changed(a.gitService.DiscardChanges(filePath), changeStatus)
 }
 
-// ============ Commit Operations ============
+// GetFileDiffBetween returns the diff of a file between two revisions, or a revision and the working tree
+func (a *App) GetFileDiffBetween(path, revA, revB string) (string, error) {
+	return a.gitService.GetFileDiffBetween(path, revA, revB)
+}
 
-// Commit creates a commit with the given message
-func (a *App) Commit(message string) error {
-	return a.gitService.Commit(message)
+// GetFileAtRevision returns the raw content of a file at a revision, or in the index when rev is empty
+func (a *App) GetFileAtRevision(path, rev string) ([]byte, error) {
+	return a.gitService.GetFileAtRevision(path, rev)
 }
 
-// GenerateCommitMessage generates a commit message using AI
-func (a *App) GenerateCommitMessage() (string, error) {
-	status, err := a.gitService.GetStatus()
+// ExportArchive writes an archive of a revision to dest in zip, tar or tar.gz format
+func (a *App) ExportArchive(rev, format, dest string) error {
+	return a.gitService.ExportArchive(rev, format, dest)
+}
+
+// GetMaxOutput returns the byte limit for diffs and file contents
+func (a *App) GetMaxOutput() int64 {
+	if limit := a.configService.GetMaxOutput(); limit > 0 {
+		return limit
+	}
+	return git.DefaultMaxOutput
+}
+
+// SetMaxOutput sets the byte limit for diffs and file contents, 0 restores the default
+func (a *App) SetMaxOutput(limit int64) error {
+	if err := a.configService.SetMaxOutput(limit); err != nil {
+		return err
+	}
+	a.gitService.SetMaxOutput(limit)
+	return nil
+}
+
+// CompareWithBranch returns the diff between a file and its version on a branch
+func (a *App) CompareWithBranch(path, branch string) (string, error) {
+	if branch == "" {
+		branch = a.repositoryDefaults().DefaultBranch
+	}
+	return a.gitService.CompareWithBranch(path, branch)
+}
+
+// StagePaths stages a selection of files and directories, reporting each path
+func (a *App) StagePaths(paths []string) ([]models.PathResult, error) {
+	return a.pathsChanged(a.gitService.StagePaths(paths))
+}
+
+// UnstagePaths unstages a selection of files and directories, reporting each path
+func (a *App) UnstagePaths(paths []string) ([]models.PathResult, error) {
+	return a.pathsChanged(a.gitService.UnstagePaths(paths))
+}
+
+// DiscardPaths discards changes of a selection of files and directories, reporting each path
+func (a *App) DiscardPaths(paths []string) ([]models.PathResult, error) {
+	if err := a.gitService.CheckProtectedDiscard(paths); err != nil {
+		return nil, err
+	}
+	a.checkpoint("discard " + strings.Join(paths, ", "))
+	if err := a.moveToTrash(trash.KindDiscard, paths); err != nil {
+		return nil, err
+	}
+	return a.pathsChanged(a.gitService.DiscardPaths(paths))
+}
+
+// CleanPaths removes untracked files in a selection of files and directories, reporting each path
+func (a *App) CleanPaths(paths []string) ([]models.PathResult, error) {
+	if err := a.gitService.CheckProtectedClean(paths); err != nil {
+		return nil, err
+	}
+	if err := a.moveToTrash(trash.KindClean, paths); err != nil {
+		return nil, err
+	}
+	return a.pathsChanged(a.gitService.CleanPaths(paths))
+}
+
+// ============ Trash ============
+
+// ListDiscarded returns the discarded changes and removed files kept for the current repository
+func (a *App) ListDiscarded() ([]models.DiscardedEntry, error) {
+	repo := a.gitService.GetCurrentPath()
+	if repo == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return trash.List(repo)
+}
+
+// GetDiscardedPatch returns the patch saved in a trash entry
+func (a *App) GetDiscardedPatch(id string) (string, error) {
+	repo := a.gitService.GetCurrentPath()
+	if repo == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	patch, err := trash.Patch(repo, id)
+	return string(patch), err
+}
+
+// RestoreDiscarded puts the content of a trash entry back into the working tree
+// The entry is removed only when everything was restored
+func (a *App) RestoreDiscarded(id string) error {
+	repo := a.gitService.GetCurrentPath()
+	if repo == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	entry, err := trash.Get(repo, id)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// Get diff of staged changes
-	diff := ""
-	for _, file := range status.Staged {
-		fileDiff, err := a.gitService.GetDiff(file.Path, true)
+	var failures []string
+	if entry.HasPatch {
+		patch, err := trash.Patch(repo, id)
+		if err == nil {
+			err = a.gitService.ApplyPatch(patch)
+		}
 		if err != nil {
-			continue
+			failures = append(failures, err.Error())
 		}
-		diff += fmt.Sprintf("\n=== %s ===\n%s\n", file.Path, fileDiff)
+	}
+	for _, name := range entry.Files {
+		content, err := trash.File(repo, id, name)
+		if err == nil {
+			err = a.gitService.RestoreFile(name, content)
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	a.notifyChanged(changeStatus)
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to restore: %s", strings.Join(failures, "; "))
 	}
 
-	if diff == "" {
-		return "", fmt.Errorf("no staged changes to generate commit message for")
+	return trash.Remove(repo, id)
+}
+
+// moveToTrash saves what discarding or cleaning paths would remove
+func (a *App) moveToTrash(kind string, paths []string) error {
+	repo := a.gitService.GetCurrentPath()
+	if repo == "" {
+		return nil
 	}
 
-	return a.aiService.GenerateCommitMessage(diff)
+	var patch []byte
+	var files map[string]string
+	var err error
+	if kind == trash.KindClean {
+		files, err = a.gitService.UntrackedFiles(paths)
+	} else {
+		patch, err = a.gitService.DiscardablePatch(paths)
+	}
+	if err == nil {
+		_, err = trash.Store(repo, kind, paths, patch, files)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save removed content to trash: %w", err)
+	}
+	return nil
 }
 
-// ============ Branch Operations ============
+// ============ Team Configuration ============
 
-// GetBranches returns all branches
-func (a *App) GetBranches() ([]models.Branch, error) {
-	return a.gitService.GetBranches()
+// GetTeamConfig reloads and returns the .gitai.yml of the open repository, nil when it has none
+func (a *App) GetTeamConfig() (*models.TeamConfig, error) {
+	if a.gitService.GetCurrentPath() == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	team, err := teamconfig.Load(a.gitService.GetCurrentPath())
+	if err != nil {
+		return nil, err
+	}
+	a.team = team
+	return team, nil
 }
 
-// CheckoutBranch switches to the given branch
-func (a *App) CheckoutBranch(branch string) error {
-	return a.gitService.CheckoutBranch(branch)
+// loadTeamConfig reads the .gitai.yml of the open repository, ignoring an invalid file
+// so a broken team file never prevents opening the repository
+func (a *App) loadTeamConfig() {
+	team, err := teamconfig.Load(a.gitService.GetCurrentPath())
+	if err != nil {
+		logging.Logger().Warn("ignoring team configuration", "error", err.Error())
+	}
+	a.team = team
 }
 
-// CreateBranch creates a new branch
-func (a *App) CreateBranch(branch string, checkout bool) error {
-	return a.gitService.CreateBranch(branch, checkout)
+// messageStyle returns the user's commit message style with the team's overrides applied
+func (a *App) messageStyle() models.MessageStyle {
+	return teamconfig.ApplyStyle(a.configService.GetMessageStyle(), a.team)
 }
 
-// ============ Diff Operations ============
+// generateCommitMessage asks the AI for a commit message, using the team's preferred
+// prompt template, matched by name or ID, when one is configured
+func (a *App) generateCommitMessage(ctx context.Context, diff string) (string, error) {
+	return a.withGeneration(ctx, func(service *ai.AIService) (string, error) {
+		if a.team != nil && a.team.Prompt != "" {
+			for _, p := range a.templateService.GetPrompts() {
+				if p.Name == a.team.Prompt || p.ID == a.team.Prompt {
+					return service.GenerateCommitMessageWithTemplate(diff, p.Template)
+				}
+			}
+			logging.Logger().Warn("team prompt template not found", "prompt", a.team.Prompt)
+		}
+		return service.GenerateCommitMessage(diff)
+	})
+}
 
-// GetDiff returns the diff for the given file
-func (a *App) GetDiff(filePath string, staged bool) (string, error) {
-	return a.gitService.GetDiff(filePath, staged)
+// withGeneration runs an AI request whose reply is streamed as "ai:token" events and can be
+// stopped with CancelGeneration; starting a generation cancels the one in progress
+func (a *App) withGeneration(ctx context.Context, request func(*ai.AIService) (string, error)) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	a.generationMu.Lock()
+	if a.cancelGeneration != nil {
+		a.cancelGeneration()
+	}
+	a.cancelGeneration = cancel
+	a.generationMu.Unlock()
+
+	message, err := request(a.aiService.Streaming(ctx, func(token string) {
+		a.emit("ai:token", token)
+	}))
+	if errors.Is(err, context.Canceled) {
+		return "", fmt.Errorf("generation cancelled")
+	}
+	return message, err
+}
+
+// CancelGeneration stops the AI generation in progress, if any
+func (a *App) CancelGeneration() {
+	a.generationMu.Lock()
+	defer a.generationMu.Unlock()
+	if a.cancelGeneration != nil {
+		a.cancelGeneration()
+		a.cancelGeneration = nil
+	}
+}
+
+// checkProtectedBranch refuses to delete or rewrite a branch the team protects, the
+// current branch when branch is empty
+func (a *App) checkProtectedBranch(branch string) error {
+	if branch == "" {
+		branch, _ = a.gitService.CurrentBranch()
+	}
+	if teamconfig.IsProtectedBranch(a.team, branch) {
+		return fmt.Errorf("branch %s is protected by %s", branch, teamconfig.FileName)
+	}
+	return nil
+}
+
+// ============ Protected Paths ============
+
+// GetProtectedPaths returns the patterns of files guarded against discard and clean
+func (a *App) GetProtectedPaths() models.ProtectedPaths {
+	return a.configService.GetProtectedPaths()
+}
+
+// SetProtectedPaths saves the patterns of files guarded against discard and clean
+func (a *App) SetProtectedPaths(protected models.ProtectedPaths) error {
+	if err := a.configService.SetProtectedPaths(protected); err != nil {
+		return err
+	}
+	a.gitService.SetProtectedPaths(protected)
+	return nil
+}
+
+// ConfirmProtectedPaths allows protected files to be discarded for the next minute
+func (a *App) ConfirmProtectedPaths(paths []string) error {
+	return a.gitService.ConfirmProtectedPaths(paths)
+}
+
+// ============ Email Patches ============
+
+// FormatPatchSeries formats a range of commits as mailbox patches
+func (a *App) FormatPatchSeries(revRange string, coverLetter bool) ([]models.PatchFile, error) {
+	return a.gitService.FormatPatchSeries(revRange, coverLetter)
+}
+
+// SendEmailPatch formats a range of commits and mails them to a list
+func (a *App) SendEmailPatch(opts models.SendPatchOptions) error {
+	patches, err := a.gitService.FormatPatchSeries(opts.Range, opts.CoverLetter)
+	if err != nil {
+		return err
+	}
+	if opts.CoverLetter {
+		mail.FillCoverLetter(&patches[0], opts.CoverSubject, opts.CoverBody)
+	}
+
+	if opts.UseGit {
+		return a.gitService.SendPatchesWithGit(patches, opts.To, opts.Cc)
+	}
+	return mail.SendPatches(a.configService.GetSMTPConfig(), opts.To, opts.Cc, patches)
+}
+
+// GetSMTPConfig returns the account used to mail patches, without its password
+func (a *App) GetSMTPConfig() models.SMTPConfig {
+	config := a.configService.GetSMTPConfig()
+	config.Password = ""
+	return config
+}
+
+// SetSMTPConfig updates the account used to mail patches
+// An empty password keeps the stored one
+func (a *App) SetSMTPConfig(config models.SMTPConfig) error {
+	return a.configService.SetSMTPConfig(config)
+}
+
+// ============ Sharing ============
+
+// ShareRepository serves the current repository over HTTP so peers on the network can clone it
+func (a *App) ShareRepository(port int, readOnly bool) (*models.ShareInfo, error) {
+	return a.shareService.Start(a.gitService.GetCurrentPath(), port, readOnly)
+}
+
+// StopSharing stops serving the shared repository
+func (a *App) StopSharing() error {
+	return a.shareService.Stop()
 }
 
-// ============ History Operations ============
+// GetShareStatus returns the repository currently being shared
+func (a *App) GetShareStatus() models.ShareInfo {
+	return a.shareService.Status()
+}
+
+// ============ Snapshots ============
+
+// CreateSnapshot saves the working tree and index as a local checkpoint
+func (a *App) CreateSnapshot(message string) (*models.Snapshot, error) {
+	return a.gitService.CreateSnapshot(message, false)
+}
+
+// ListSnapshots returns the checkpoints of the current repository
+func (a *App) ListSnapshots() ([]models.Snapshot, error) {
+	return a.gitService.ListSnapshots()
+}
+
+// GetSnapshotDiff returns the diff between a checkpoint and the working tree
+func (a *App) GetSnapshotDiff(id string) (string, error) {
+	return a.gitService.GetSnapshotDiff(id)
+}
+
+// RestoreSnapshot restores the files and index of a checkpoint
+func (a *App) RestoreSnapshot(id string) error {
+	return a.changed(a.gitService.RestoreSnapshot(id), changeStatus)
+}
+
+// DeleteSnapshot removes a checkpoint
+func (a *App) DeleteSnapshot(id string) error {
+	return a.gitService.DeleteSnapshot(id)
+}
+
+// TryChanges applies a suggested or pasted patch to the working tree so it can be trialed
+// and then kept with KeepTry or undone with RevertTry
+func (a *App) TryChanges(patch string) (*models.TryState, error) {
+	state, err := a.gitService.TryChanges(patch)
+	return state, a.changed(err, changeStatus)
+}
+
+// RevertTry undoes the patch being tried, leaving other edits in place
+func (a *App) RevertTry() error {
+	return a.changed(a.gitService.RevertTry(), changeStatus)
+}
+
+// KeepTry keeps the patch being tried and ends the trial
+func (a *App) KeepTry() error {
+	return a.gitService.KeepTry()
+}
+
+// GetTryState returns the patch being tried, if any
+func (a *App) GetTryState() models.TryState {
+	return a.gitService.GetTryState()
+}
+
+// ApplySuggestedPatch writes a suggested unified diff for one file into the working tree,
+// matching hunks that moved or differ in whitespace; the snapshot taken first undoes it
+func (a *App) ApplySuggestedPatch(filePath string, unifiedDiff string) (*models.SuggestedPatchResult, error) {
+	result, err := a.gitService.ApplySuggestedPatch(filePath, unifiedDiff)
+	return result, a.changed(err, changeStatus)
+}
+
+// checkpoint takes an automatic snapshot before a risky operation
+// Failures are logged and never block the operation itself
+func (a *App) checkpoint(reason string) {
+	if a.gitService.GetCurrentPath() == "" {
+		return
+	}
+	if _, err := a.gitService.CreateSnapshot("before "+reason, true); err != nil && !errors.Is(err, git.ErrNothingToSnapshot) {
+		logging.Logger().Warn("automatic snapshot failed", "reason", reason, "error", err.Error())
+	}
+}
+
+// ============ Commit Operations ============
+
+// Commit creates a commit with the given message and the repository's trailers
+func (a *App) Commit(message string) error {
+	message, err := a.withTrailers(message)
+	if err != nil {
+		return err
+	}
+	if err := a.gitService.Commit(message); err != nil {
+		return err
+	}
+	a.notifyChanged(changeStatus | changeLog)
+	a.backupService.RunAfterCommit(a.gitService.GetCurrentPath())
+	return nil
+}
+
+// CommitPaths commits only the selected paths, regardless of what is staged
+func (a *App) CommitPaths(message string, paths []string) error {
+	message, err := a.withTrailers(message)
+	if err != nil {
+		return err
+	}
+	if err := a.gitService.CommitPaths(message, paths); err != nil {
+		return err
+	}
+	a.notifyChanged(changeStatus | changeLog)
+	a.backupService.RunAfterCommit(a.gitService.GetCurrentPath())
+	return nil
+}
+
+// GetCommitTrailers returns the trailers appended to commits of the open repository
+func (a *App) GetCommitTrailers() []models.CommitTrailer {
+	return a.configService.GetCommitTrailers(a.gitService.GetCurrentPath())
+}
+
+// SetCommitTrailers sets the trailers appended to commits of the open repository, such as
+// "Closes: {issue}" or "Reviewed-by: Name <email>"
+func (a *App) SetCommitTrailers(trailers []models.CommitTrailer) error {
+	path := a.gitService.GetCurrentPath()
+	if path == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	for i := range trailers {
+		trailers[i].Value = strings.TrimSpace(trailers[i].Value)
+		if err := git.ValidateTrailer(trailers[i]); err != nil {
+			return err
+		}
+	}
+	return a.configService.SetCommitTrailers(path, trailers)
+}
+
+// withTrailers appends the repository's trailers to a commit message, filling {issue}
+// with the issue linked to the current branch
+func (a *App) withTrailers(message string) (string, error) {
+	path := a.gitService.GetCurrentPath()
+	trailers := a.configService.GetCommitTrailers(path)
+	if len(trailers) == 0 || strings.TrimSpace(message) == "" {
+		return message, nil
+	}
+	branch, err := a.gitService.CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+	issue := ""
+	if branch != "" {
+		issue = a.configService.GetBranchIssue(path, branch)
+	}
+	return a.gitService.AppendTrailers(message, trailers, issue)
+}
+
+// CommitFixup commits the staged changes as a fixup of an earlier commit
+func (a *App) CommitFixup(targetHash string) error {
+	return a.changed(a.gitService.CommitFixup(targetHash), changeStatus|changeLog)
+}
+
+// AutosquashRebase folds fixup commits after base into the commits they target
+func (a *App) AutosquashRebase(base string) error {
+	if err := a.checkProtectedBranch(""); err != nil {
+		return err
+	}
+	a.checkpoint("autosquash onto " + base)
+	return a.changedEvenOnError(a.gitService.AutosquashRebase(base), changeHistory)
+}
+
+// RewordCommit replaces the message of a commit
+func (a *App) RewordCommit(hash, newMessage string) error {
+	if err := a.checkProtectedBranch(""); err != nil {
+		return err
+	}
+	a.checkpoint("rewording " + hash)
+	return a.changed(a.gitService.RewordCommit(hash, newMessage), changeLog)
+}
+
+// GenerateRewordSuggestion asks the AI for a better message for an existing commit
+func (a *App) GenerateRewordSuggestion(hash string) (string, error) {
+	ctx, end := trace.Begin(context.Background(), "GenerateRewordSuggestion")
+	defer end()
+	patch, err := a.gitService.CommitPatch(hash)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(patch) == "" {
+		return "", fmt.Errorf("commit %s has no changes to describe", hash)
+	}
+
+	message, err := a.generateCommitMessage(ctx, patch)
+	if err != nil {
+		return "", err
+	}
+	return ai.PostProcessMessage(message, a.messageStyle()), nil
+}
+
+// ReorderCommits rewrites the commits after base in a new order, oldest first
+func (a *App) ReorderCommits(base string, newOrder []string) error {
+	if err := a.checkProtectedBranch(""); err != nil {
+		return err
+	}
+	a.checkpoint("reordering commits after " + base)
+	return a.changedEvenOnError(a.gitService.ReorderCommits(base, newOrder), changeHistory)
+}
+
+// GetRewrittenCommits reports the commits after base up to tip that are already on a remote,
+// which Reset, rebases, rewording and reordering refuse to rewrite until acknowledged
+func (a *App) GetRewrittenCommits(base, tip string) (*models.RewrittenCommits, error) {
+	return a.gitService.RewrittenCommits(base, tip)
+}
+
+// AcknowledgeRewrite allows the given published commits to be rewritten for the next minute
+func (a *App) AcknowledgeRewrite(hashes []string) error {
+	return a.gitService.AcknowledgeRewrite(hashes)
+}
+
+// PreviewRebaseOnto lists the commits RebaseOnto would transplant
+func (a *App) PreviewRebaseOnto(newBase, oldBase, branch string) (*models.RebaseOntoPreview, error) {
+	return a.gitService.PreviewRebaseOnto(newBase, oldBase, branch)
+}
+
+// RebaseOnto moves the commits of branch after oldBase onto newBase
+func (a *App) RebaseOnto(newBase, oldBase, branch string) error {
+	if err := a.checkProtectedBranch(branch); err != nil {
+		return err
+	}
+	a.checkpoint("rebasing onto " + newBase)
+	return a.changedEvenOnError(a.gitService.RebaseOnto(newBase, oldBase, branch), changeHistory)
+}
+
+// CommitAllowEmpty creates a commit even when nothing is staged
+func (a *App) CommitAllowEmpty(message string) error {
+	message, err := a.withTrailers(message)
+	if err != nil {
+		return err
+	}
+	if err := a.gitService.CommitAllowEmpty(message); err != nil {
+		return err
+	}
+	a.notifyChanged(changeStatus | changeLog)
+	a.backupService.RunAfterCommit(a.gitService.GetCurrentPath())
+	return nil
+}
+
+// CreateInitialCommit creates the first commit in a repository without history
+func (a *App) CreateInitialCommit(message string) error {
+	message, err := a.withTrailers(message)
+	if err != nil {
+		return err
+	}
+	if err := a.gitService.CreateInitialCommit(message); err != nil {
+		return err
+	}
+	a.notifyChanged(changeStatus | changeLog)
+	a.backupService.RunAfterCommit(a.gitService.GetCurrentPath())
+	return nil
+}
+
+// PreviewCommit shows what would be committed and runs the commit checks without committing
+func (a *App) PreviewCommit(message string) (*models.CommitPreview, error) {
+	preview, err := a.gitService.PreviewCommit(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if problems := ai.CheckMessage(message, a.messageStyle()); len(problems) > 0 {
+		preview.Checks = append(preview.Checks, models.HookResult{
+			Name:   "message style",
+			Passed: false,
+			Output: strings.Join(problems, "\n"),
+		})
+		preview.CanCommit = false
+	}
+
+	if issues, err := a.gitService.CheckLineEndings(); err == nil && len(issues) > 0 {
+		lines := make([]string, len(issues))
+		for i, issue := range issues {
+			lines[i] = issue.Path + ": " + issue.Detail
+		}
+		preview.Checks = append(preview.Checks, models.HookResult{
+			Name:   "line endings",
+			Passed: false,
+			Output: strings.Join(lines, "\n"),
+		})
+		preview.CanCommit = false
+	}
+	return preview, nil
+}
+
+// GenerateCommitMessage generates a commit message with the configured generator
+// Offline, the rules-based generator is used so the commit flow keeps working
+func (a *App) GenerateCommitMessage() (string, error) {
+	ctx, end := trace.Begin(context.Background(), "GenerateCommitMessage")
+	defer end()
+	status, fileDiffs, err := a.stagedDiff()
+	if err != nil {
+		return "", err
+	}
+	diff := a.joinDiffs(stagedPaths(status), fileDiffs)
+	if diff == "" {
+		return "", fmt.Errorf("no staged changes to generate commit message for")
+	}
+
+	generator := a.configService.GetCommitGenerator()
+	if generator == models.GeneratorHeuristic || a.aiService.IsOffline() {
+		return a.finishCommitMessage(status, ai.HeuristicCommitMessage(status.Staged, fileDiffs)), nil
+	}
+
+	var message string
+	if generator == models.GeneratorSeeded {
+		seed := ai.HeuristicCommitMessage(status.Staged, fileDiffs)
+		message, err = a.withGeneration(ctx, func(service *ai.AIService) (string, error) {
+			return service.GenerateCommitMessageFromSeed(diff, seed)
+		})
+	} else {
+		message, err = a.generateCommitMessage(ctx, diff)
+	}
+	if err != nil {
+		return "", err
+	}
+	return a.finishCommitMessage(status, message), nil
+}
+
+// GenerateHeuristicCommitMessage generates a commit message for the staged changes from
+// rules alone, without calling the AI provider
+func (a *App) GenerateHeuristicCommitMessage() (string, error) {
+	status, fileDiffs, err := a.stagedDiff()
+	if err != nil {
+		return "", err
+	}
+	if len(status.Staged) == 0 {
+		return "", fmt.Errorf("no staged changes to generate commit message for")
+	}
+	return a.finishCommitMessage(status, ai.HeuristicCommitMessage(status.Staged, fileDiffs)), nil
+}
+
+// GetCommitGenerator returns how commit messages are generated
+func (a *App) GetCommitGenerator() string {
+	return a.configService.GetCommitGenerator()
+}
+
+// SetCommitGenerator sets how commit messages are generated: "ai", "heuristic" for the
+// rules-based generator or "seeded" for a rules-based draft refined by AI
+func (a *App) SetCommitGenerator(generator string) error {
+	switch generator {
+	case models.GeneratorAI, models.GeneratorHeuristic, models.GeneratorSeeded:
+		return a.configService.SetCommitGenerator(generator)
+	}
+	return fmt.Errorf("unknown commit message generator: %s", generator)
+}
+
+// RegenerateCommitMessage revises the previous suggestion for the staged changes following
+// the user's feedback, instead of generating a new message from scratch
+func (a *App) RegenerateCommitMessage(previous, feedback string) (string, error) {
+	ctx, end := trace.Begin(context.Background(), "RegenerateCommitMessage")
+	defer end()
+	status, fileDiffs, err := a.stagedDiff()
+	if err != nil {
+		return "", err
+	}
+	diff := a.joinDiffs(stagedPaths(status), fileDiffs)
+	if diff == "" {
+		return "", fmt.Errorf("no staged changes to generate commit message for")
+	}
+
+	message, err := a.withGeneration(ctx, func(service *ai.AIService) (string, error) {
+		return service.RefineCommitMessage(diff, previous, feedback)
+	})
+	if err != nil {
+		return "", err
+	}
+	return a.finishCommitMessage(status, message), nil
+}
+
+// finishCommitMessage applies the message style and team scope to a generated message
+// and references the issue the branch was created from
+func (a *App) finishCommitMessage(status *models.GitStatus, message string) string {
+	message = ai.PostProcessMessage(message, a.messageStyle())
+	message = ai.InsertScope(message, teamconfig.ScopeFor(a.team, stagedPaths(status)))
+
+	// A trailer referencing the issue is added when committing instead
+	for _, t := range a.configService.GetCommitTrailers(a.gitService.GetCurrentPath()) {
+		if strings.Contains(t.Value, git.IssuePlaceholder) {
+			return message
+		}
+	}
+	if issueKey := a.configService.GetBranchIssue(a.gitService.GetCurrentPath(), status.Branch); issueKey != "" && !strings.Contains(message, issueKey) {
+		message += "\n\nRefs " + issueKey
+	}
+	return message
+}
+
+// stagedDiff returns the status and the diff of each staged file
+func (a *App) stagedDiff() (*models.GitStatus, []string, error) {
+	status, err := a.gitService.GetStatus()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileDiffs, err := a.gitService.GetChangeDiffs(status.Staged, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return status, fileDiffs, nil
+}
+
+// joinDiffs combines the diffs of the files at paths into one, with a section per file
+// Version changes in dependency manifests and lockfiles are replaced by a summary, which
+// says more to the model than their noisy diffs; the other edits of a manifest are kept.
+// Files excluded by the AI context filter are replaced by a line counting their changes
+func (a *App) joinDiffs(paths, fileDiffs []string) string {
+	bumps, rest := deps.Summarize(paths, fileDiffs)
+
+	filter := a.configService.GetAIContextFilter()
+	generated := map[string]bool{}
+	if filter.Generated {
+		if files, err := a.gitService.GeneratedFiles(paths); err == nil {
+			generated = files
+		}
+	}
+
+	var diff strings.Builder
+	if len(bumps) > 0 {
+		fmt.Fprintf(&diff, "\n=== 依赖变更 ===\n%s\n", deps.FormatBumps(bumps))
+	}
+	for i, path := range paths {
+		if rest[i] == "" {
+			continue
+		}
+		if generated[path] || git.MatchesAnyPattern(filter.Patterns, path) {
+			added, deleted := countDiffLines(fileDiffs[i])
+			fmt.Fprintf(&diff, "\n=== %s ===\n(生成文件或锁文件，已省略 diff：+%d -%d 行)\n", path, added, deleted)
+			continue
+		}
+		fmt.Fprintf(&diff, "\n=== %s ===\n%s\n", path, rest[i])
+	}
+	return diff.String()
+}
+
+// countDiffLines counts the added and deleted lines of a diff
+func countDiffLines(diff string) (int, int) {
+	added, deleted := 0, 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			deleted++
+		}
+	}
+	return added, deleted
+}
+
+// stagedPaths lists the paths of the staged files
+func stagedPaths(status *models.GitStatus) []string {
+	paths := make([]string, len(status.Staged))
+	for i, file := range status.Staged {
+		paths[i] = file.Path
+	}
+	return paths
+}
+
+// GetOwnersForChanges returns the CODEOWNERS owners of the staged files, who will be asked
+// to review them
+func (a *App) GetOwnersForChanges() (*models.ChangeOwners, error) {
+	status, err := a.gitService.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	file, err := codeowners.Load(a.gitService.GetCurrentPath())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ChangeOwners{Files: []models.FileOwners{}, Owners: []string{}, Unowned: []string{}}
+	if file == nil {
+		return result, nil
+	}
+	result.File = file.Path
+
+	seen := map[string]bool{}
+	for _, change := range status.Staged {
+		owners, pattern := file.Owners(change.Path)
+		if len(owners) == 0 {
+			result.Unowned = append(result.Unowned, change.Path)
+			continue
+		}
+		result.Files = append(result.Files, models.FileOwners{Path: change.Path, Owners: owners, Pattern: pattern})
+		for _, owner := range owners {
+			if !seen[owner] {
+				seen[owner] = true
+				result.Owners = append(result.Owners, owner)
+			}
+		}
+	}
+	sort.Strings(result.Owners)
+	result.Users, result.Teams = codeowners.SplitReviewers(result.Owners)
+	return result, nil
+}
+
+// SuggestTestsForChanges lists the existing tests related to the staged files and, when AI
+// is configured, asks which to run and what coverage is missing
+// The path-based mapping is returned even if the AI request fails
+func (a *App) SuggestTestsForChanges() (*models.TestImpact, error) {
+	status, fileDiffs, err := a.stagedDiff()
+	if err != nil {
+		return nil, err
+	}
+	diff := a.joinDiffs(stagedPaths(status), fileDiffs)
+	if len(status.Staged) == 0 {
+		return nil, fmt.Errorf("no staged changes")
+	}
+
+	var sources []string
+	impact := &models.TestImpact{Tests: []models.TestTarget{}, Untested: []string{}}
+	index := map[string]int{}
+	addTest := func(path, source string, staged bool) {
+		i, ok := index[path]
+		if !ok {
+			i = len(impact.Tests)
+			index[path] = i
+			impact.Tests = append(impact.Tests, models.TestTarget{Path: path, Sources: []string{}})
+		}
+		if source != "" {
+			impact.Tests[i].Sources = append(impact.Tests[i].Sources, source)
+		}
+		impact.Tests[i].Staged = impact.Tests[i].Staged || staged
+	}
+	for _, file := range status.Staged {
+		if git.IsTestFile(file.Path) {
+			addTest(file.Path, "", true)
+		} else if !strings.HasPrefix(file.Status, "Deleted") {
+			sources = append(sources, file.Path)
+		}
+	}
+
+	related, err := a.gitService.FindRelatedTests(sources)
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range sources {
+		tests := related[source]
+		if len(tests) == 0 {
+			impact.Untested = append(impact.Untested, source)
+		}
+		for _, test := range tests {
+			addTest(test, source, false)
+		}
+	}
+
+	if a.aiService.ValidateConfig() == nil && diff != "" {
+		tests := make([]string, len(impact.Tests))
+		for i, t := range impact.Tests {
+			tests[i] = t.Path
+		}
+		suggestions, err := a.aiService.SuggestTests(diff, tests, impact.Untested)
+		if err != nil {
+			impact.AIError = err.Error()
+		} else {
+			impact.Suggestions = strings.TrimSpace(suggestions)
+			impact.AIUsed = true
+		}
+	}
+	return impact, nil
+}
+
+// CheckLineEndings flags staged files with line-ending or encoding problems
+func (a *App) CheckLineEndings() ([]models.FileIssue, error) {
+	return a.gitService.CheckLineEndings()
+}
+
+// ApplyAttributesFix appends the suggested rules to .gitattributes
+func (a *App) ApplyAttributesFix(lines []string) error {
+	return a.changed(a.gitService.ApplyAttributes(lines), changeStatus)
+}
+
+// ContinueOperation continues the in-progress merge, rebase, cherry-pick or revert
+func (a *App) ContinueOperation() error {
+	return a.changedEvenOnError(a.gitService.ContinueOperation(), changeHistory)
+}
+
+// AbortOperation aborts the in-progress merge, rebase, cherry-pick, revert or bisect
+func (a *App) AbortOperation() error {
+	a.checkpoint("abort operation")
+	return a.changed(a.gitService.AbortOperation(), changeHistory)
+}
+
+// SkipOperationStep skips the current commit of the in-progress operation
+func (a *App) SkipOperationStep() error {
+	return a.changedEvenOnError(a.gitService.SkipOperationStep(), changeHistory)
+}
+
+// ============ Branch Operations ============
+
+// GetBranches returns all branches
+func (a *App) GetBranches() ([]models.Branch, error) {
+	return a.gitService.GetBranches()
+}
+
+// CheckoutBranch switches to the given branch
+func (a *App) CheckoutBranch(branch string) error {
+	a.refreshRemoteView()
+	return a.changed(a.gitService.CheckoutBranch(branch), changeHistory)
+}
+
+// CheckoutBranchWithStrategy switches to the given branch, handling uncommitted changes
+// with one of: block, stash, discard, carry
+func (a *App) CheckoutBranchWithStrategy(branch string, strategy string) (*models.CheckoutResult, error) {
+	a.refreshRemoteView()
+	if git.CheckoutStrategy(strategy) == git.CheckoutDiscard {
+		a.checkpoint("switching to " + branch)
+	}
+	result, err := a.gitService.CheckoutBranchWithStrategy(branch, git.CheckoutStrategy(strategy))
+	if err == nil {
+		a.notifyChanged(changeHistory)
+	}
+	return result, err
+}
+
+// GetDirtyPaths returns the tracked files that would be affected by switching branches
+func (a *App) GetDirtyPaths() ([]string, error) {
+	return a.gitService.DirtyPaths()
+}
+
+// CreateBranch creates a new branch
+func (a *App) CreateBranch(branch string, checkout bool) error {
+	parts := changeBranches
+	if checkout {
+		parts = changeHistory
+	}
+	return a.changed(a.gitService.CreateBranch(branch, checkout), parts)
+}
+
+// ============ Diff Operations ============
+
+// GetDiff returns the diff for the given file
+func (a *App) GetDiff(filePath string, staged bool) (string, error) {
+	return a.gitService.GetDiff(filePath, staged)
+}
+
+// GetStructuredDiff returns the diff for the given file parsed into hunks and numbered lines
+func (a *App) GetStructuredDiff(filePath string, staged bool) (*models.StructuredDiff, error) {
+	return a.gitService.GetStructuredDiff(filePath, staged)
+}
+
+// ExportSelectionPatch writes the staged or unstaged changes of the selected files as a
+// patch to the clipboard or to a file the user picks, for sharing without committing
+// It returns the path of the saved file, "" for the clipboard or when the dialog is cancelled
+func (a *App) ExportSelectionPatch(files []string, staged bool, destination string) (string, error) {
+	if destination != models.PatchToClipboard && destination != models.PatchToFile {
+		return "", fmt.Errorf("unknown patch destination: %s", destination)
+	}
+	patch, err := a.gitService.SelectionPatch(files, staged)
+	if err != nil {
+		return "", err
+	}
+	if a.ctx == nil {
+		return "", fmt.Errorf("application context not initialized")
+	}
+
+	if destination == models.PatchToClipboard {
+		return "", runtime.ClipboardSetText(a.ctx, patch)
+	}
+	name := "changes.patch"
+	if len(files) == 1 {
+		name = filepath.Base(filepath.FromSlash(files[0])) + ".patch"
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Patch",
+		DefaultFilename: name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	if err := os.WriteFile(path, []byte(patch), 0644); err != nil {
+		return "", fmt.Errorf("failed to write patch: %w", err)
+	}
+	return path, nil
+}
+
+// ============ History Operations ============
+
+// GetLog returns commit history
+func (a *App) GetLog(limit int) ([]models.CommitInfo, error) {
+	return a.gitService.GetLog(limit)
+}
+
+// GetCommitGraph returns the newest commits of all branches with parents, refs and lanes
+// for drawing the history graph
+func (a *App) GetCommitGraph(limit int) ([]models.GraphCommit, error) {
+	return a.gitService.GetCommitGraph(limit)
+}
+
+// GetLogWithOptions returns commit history filtered by path, author or message
+func (a *App) GetLogWithOptions(opts models.LogOptions) ([]models.CommitInfo, error) {
+	return a.gitService.GetLogWithOptions(opts)
+}
+
+// GetLogStream starts streaming history as "log:chunk" events and returns the stream ID
+// A Limit of 0 streams the whole history
+func (a *App) GetLogStream(opts models.LogOptions, chunkSize int) (string, error) {
+	if a.gitService.GetCurrentPath() == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	id := uuid.New().String()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.logStreams.Store(id, cancel)
+
+	go func() {
+		defer a.logStreams.Delete(id)
+		defer cancel()
+
+		total, err := a.gitService.StreamLog(ctx, opts, chunkSize, func(commits []models.CommitInfo) {
+			a.emit("log:chunk", models.LogChunk{StreamID: id, Commits: commits})
+		})
+		done := models.LogChunk{StreamID: id, Commits: []models.CommitInfo{}, Done: true, Total: total}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			done.Error = err.Error()
+		}
+		a.emit("log:chunk", done)
+	}()
+	return id, nil
+}
+
+// CancelLogStream stops a history stream started by GetLogStream
+func (a *App) CancelLogStream(id string) {
+	if cancel, ok := a.logStreams.Load(id); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
+
+// emit sends an event to the frontend once it is running
+func (a *App) emit(name string, data interface{}) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, name, data)
+	}
+}
+
+// changeSet names the parts of a repository a mutating call changed
+type changeSet int
+
+const (
+	changeStatus changeSet = 1 << iota
+	changeBranches
+	changeLog
+	changeTags
+	changeRemotes
+)
+
+// changeHistory is what moving HEAD affects: the working tree, the branch list and the log
+const changeHistory = changeStatus | changeBranches | changeLog
+
+// changed emits "repo:changed" for the given parts when err is nil and returns err unchanged,
+// so the frontend reloads only the affected panels
+func (a *App) changed(err error, parts changeSet) error {
+	if err == nil {
+		a.notifyChanged(parts)
+	}
+	return err
+}
+
+// changedEvenOnError emits "repo:changed" for the given parts whatever err is, for
+// operations such as merge, pull and rebase that stop with conflicts after changing the
+// index and HEAD, and returns err unchanged
+func (a *App) changedEvenOnError(err error, parts changeSet) error {
+	a.notifyChanged(parts)
+	return err
+}
+
+// pathsChanged emits "repo:changed" for the status after a per-path batch whatever err is,
+// since some paths may have changed even when others failed, and returns its arguments
+func (a *App) pathsChanged(results []models.PathResult, err error) ([]models.PathResult, error) {
+	a.notifyChanged(changeStatus)
+	return results, err
+}
+
+// notifyChanged emits "repo:changed" describing which parts of the repository changed
+func (a *App) notifyChanged(parts changeSet) {
+	repoPath := a.gitService.GetCurrentPath()
+	if repoPath == "" {
+		return
+	}
+	change := models.RepoChange{
+		RepoPath: repoPath,
+		Status:   parts&changeStatus != 0,
+		Branches: parts&changeBranches != 0,
+		Log:      parts&changeLog != 0,
+		Tags:     parts&changeTags != 0,
+		Remotes:  parts&changeRemotes != 0,
+	}
+	if change.Log {
+		change.Head, _ = a.gitService.ResolveCommit("HEAD")
+	}
+	a.emit("repo:changed", change)
+}
+
+// GetAuthorAvatars returns avatar URLs keyed by author email
+// In offline mode only avatars already in the cache are returned
+func (a *App) GetAuthorAvatars(emails []string) map[string]string {
+	var github *models.ForgeConfig
+	if config, err := a.configService.GetForgeConfig(models.ForgeGitHub); err == nil {
+		github = &config
+	}
+	return a.avatarService.GetAvatars(emails, github, a.aiService.IsOffline())
+}
+
+// GetBlame annotates each line of a file with the commit that last changed it
+// An empty rev blames the working tree version
+func (a *App) GetBlame(filePath string, rev string) ([]models.BlameLine, error) {
+	return a.gitService.GetBlame(filePath, rev)
+}
+
+// WhenWasLineChanged returns the commits that last touched the given lines of a file
+func (a *App) WhenWasLineChanged(filePath string, lines models.LineRange, limit int) ([]models.CommitPatch, error) {
+	return a.gitService.WhenWasLineChanged(filePath, lines, limit)
+}
+
+// FindCommitsTouchingString finds commits that added or removed text, or matched a regex with regex set
+func (a *App) FindCommitsTouchingString(text string, regex bool, filePath string) ([]models.CommitPatch, error) {
+	return a.gitService.FindCommitsTouchingString(text, regex, filePath, 50)
+}
+
+// maxQualityCommits caps how many commits AnalyzeCommitQuality scores
+// qualityBatchSize is the number of subjects rated per AI request
+const (
+	maxQualityCommits = 500
+	qualityBatchSize  = 50
+)
+
+// AnalyzeCommitQuality scores the last limit commit messages against the commit convention
+// and reports per-author statistics; descriptiveness is rated by AI when it is configured
+func (a *App) AnalyzeCommitQuality(limit int) (*models.CommitQualityReport, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	limit = min(limit, maxQualityCommits)
+
+	log, err := a.gitService.GetLog(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	style := a.messageStyle()
+	report := &models.CommitQualityReport{Commits: []models.CommitQuality{}, Authors: []models.AuthorQuality{}}
+	var subjects []string
+	for _, c := range log {
+		// Merge commits carry git's generated subject
+		if strings.HasPrefix(c.Message, "Merge ") {
+			continue
+		}
+		score, problems := ai.ScoreMessage(c.Message, style)
+		report.Commits = append(report.Commits, models.CommitQuality{
+			Hash:      c.Hash,
+			ShortHash: c.ShortHash,
+			Author:    c.Author,
+			Email:     c.Email,
+			Subject:   c.Message,
+			Score:     score,
+			Typed:     ai.HasTypePrefix(c.Message),
+			Problems:  problems,
+		})
+		subjects = append(subjects, c.Message)
+	}
+
+	if a.aiService.ValidateConfig() == nil && len(subjects) > 0 {
+		report.AIUsed = true
+		for start := 0; start < len(subjects); start += qualityBatchSize {
+			end := min(start+qualityBatchSize, len(subjects))
+			ratings, err := a.aiService.RateDescriptiveness(subjects[start:end])
+			if err != nil {
+				report.AIUsed = false
+				report.AIError = err.Error()
+				break
+			}
+			for i, rating := range ratings {
+				report.Commits[start+i].AIScore = rating
+			}
+		}
+		if report.AIUsed {
+			for i := range report.Commits {
+				c := &report.Commits[i]
+				c.Score = (c.Score + c.AIScore*20) / 2
+			}
+		} else {
+			for i := range report.Commits {
+				report.Commits[i].AIScore = 0
+			}
+		}
+	}
+
+	byAuthor := map[string]*models.AuthorQuality{}
+	var order []string
+	var total int
+	typed := map[string]int{}
+	for _, c := range report.Commits {
+		stats, ok := byAuthor[c.Email]
+		if !ok {
+			stats = &models.AuthorQuality{Author: c.Author, Email: c.Email}
+			byAuthor[c.Email] = stats
+			order = append(order, c.Email)
+		}
+		stats.Commits++
+		stats.AverageScore += float64(c.Score)
+		if c.Typed {
+			typed[c.Email]++
+		}
+		total += c.Score
+	}
+	for _, email := range order {
+		stats := byAuthor[email]
+		stats.TypedPercent = float64(typed[email]) * 100 / float64(stats.Commits)
+		stats.AverageScore /= float64(stats.Commits)
+		report.Authors = append(report.Authors, *stats)
+	}
+	if len(report.Commits) > 0 {
+		report.AverageScore = float64(total) / float64(len(report.Commits))
+	}
+	return report, nil
+}
+
+// ============ Semantic Search ============
+
+// maxIndexedCommits caps how much history is embedded for semantic search
+const maxIndexedCommits = 2000
+
+// GetSemanticSearchEnabled reports whether semantic commit search is turned on
+func (a *App) GetSemanticSearchEnabled() bool {
+	return a.configService.GetSemanticSearchEnabled()
+}
+
+// SetSemanticSearchEnabled turns semantic commit search on or off, indexing the current
+// repository in the background when turned on
+func (a *App) SetSemanticSearchEnabled(enabled bool) error {
+	if err := a.configService.SetSemanticSearchEnabled(enabled); err != nil {
+		return err
+	}
+	if path := a.gitService.GetCurrentPath(); enabled && path != "" && !a.aiService.IsOffline() {
+		go a.refreshSemanticIndex(path)
+	}
+	return nil
+}
+
+// SemanticSearchCommits finds the commits whose messages are closest in meaning to the
+// query, indexing new commits first
+func (a *App) SemanticSearchCommits(query string, limit int) ([]models.SemanticMatch, error) {
+	if !a.configService.GetSemanticSearchEnabled() {
+		return nil, fmt.Errorf("semantic search is disabled")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query is empty")
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	if _, err := a.RefreshSemanticIndex(); err != nil {
+		return nil, err
+	}
+	return a.semanticIndex.Search(a.gitService.GetCurrentPath(), query, limit)
+}
+
+// RefreshSemanticIndex embeds the recent commits not indexed yet and returns how many were added
+func (a *App) RefreshSemanticIndex() (int, error) {
+	commits, err := a.gitService.GetCommitMessages(maxIndexedCommits)
+	if err != nil {
+		return 0, err
+	}
+	return a.semanticIndex.Refresh(a.gitService.GetCurrentPath(), commits)
+}
+
+// ClearSemanticIndex removes the embeddings of the current repository
+func (a *App) ClearSemanticIndex() error {
+	path := a.gitService.GetCurrentPath()
+	if path == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	return a.semanticIndex.Clear(path)
+}
+
+// refreshSemanticIndex indexes a repository in the background with its own GitService,
+// so selecting another repository meanwhile does not matter
+func (a *App) refreshSemanticIndex(path string) {
+	service := git.NewGitService()
+	if err := service.SetPath(path); err != nil {
+		return
+	}
+	commits, err := service.GetCommitMessages(maxIndexedCommits)
+	if err == nil {
+		_, err = a.semanticIndex.Refresh(path, commits)
+	}
+	if err != nil {
+		logging.Logger().Warn("semantic index refresh failed", "repo", path, "error", err)
+	}
+}
+
+// ============ AI Configuration ============
+
+// GetAIConfig returns the AI configuration
+func (a *App) GetAIConfig() models.AIConfig {
+	return a.configService.GetAIConfig()
+}
+
+// SetAIConfig updates the AI configuration
+func (a *App) SetAIConfig(config models.AIConfig) error {
+	// First set the config to the AI service
+	a.aiService.SetConfig(config)
+
+	// Then validate the new config
+	if err := a.aiService.ValidateConfig(); err != nil {
+		return fmt.Errorf("AI configuration validation failed: %w", err)
+	}
+
+	// Finally save to config service
+	if err := a.configService.SetAIConfig(config); err != nil {
+		return fmt.Errorf("failed to save AI configuration: %w", err)
+	}
+	return nil
+}
+
+// GetMessageStyle returns the commit message post-processing settings
+func (a *App) GetMessageStyle() models.MessageStyle {
+	return a.configService.GetMessageStyle()
+}
+
+// SetMessageStyle updates the commit message post-processing settings
+func (a *App) SetMessageStyle(style models.MessageStyle) error {
+	return a.configService.SetMessageStyle(style)
+}
+
+// TestAIConnection tests the AI service connection
+// If config is provided, it validates the given config without modifying internal state
+// If no config is provided (detected by empty Provider field), it validates the current configuration
+func (a *App) TestAIConnection(config models.AIConfig) error {
+	if config.Provider != "" {
+		// Validate the provided config without modifying internal state
+		if err := a.aiService.ValidateConfigParam(config); err != nil {
+			return fmt.Errorf("AI configuration validation failed: %w", err)
+		}
+		return nil
+	}
+	// Validate current configuration
+	if err := a.aiService.ValidateConfig(); err != nil {
+		return fmt.Errorf("AI configuration validation failed: %w", err)
+	}
+	return nil
+}
+
+// GetAIContextFilter returns the files whose diffs are left out of AI requests
+func (a *App) GetAIContextFilter() models.AIContextFilter {
+	return a.configService.GetAIContextFilter()
+}
+
+// SetAIContextFilter updates the files whose diffs are left out of AI requests
+func (a *App) SetAIContextFilter(filter models.AIContextFilter) error {
+	return a.configService.SetAIContextFilter(filter)
+}
+
+// ============ Environment ============
+
+// GetPathOverride returns the PATH configured for git, "" when the system PATH is used
+func (a *App) GetPathOverride() string {
+	return a.configService.GetPathOverride()
+}
+
+// SetPathOverride sets the PATH used to find git, ssh and credential helpers
+// An empty path goes back to the PATH the app started with
+func (a *App) SetPathOverride(path string) error {
+	path = strings.TrimSpace(path)
+	if err := a.configService.SetPathOverride(path); err != nil {
+		return err
+	}
+	shellenv.OverridePath(path)
+	return nil
+}
+
+// GetGitExecutable returns the configured git binary and global options
+func (a *App) GetGitExecutable() models.GitExecutable {
+	return a.configService.GetGitExecutable()
+}
+
+// SetGitExecutable sets the git binary and the global options, such as "-c" settings,
+// put before every git command. The binary is checked with "git version" first
+func (a *App) SetGitExecutable(exe models.GitExecutable) (string, error) {
+	exe.Path = strings.TrimSpace(exe.Path)
+	version, err := git.Version(exe)
+	if err != nil {
+		return "", err
+	}
+	if err := git.SetExecutable(exe); err != nil {
+		return "", err
+	}
+	if err := a.configService.SetGitExecutable(exe); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// ============ Offline Mode ============
+
+// GetOfflineMode reports whether offline mode is on
+func (a *App) GetOfflineMode() bool {
+	return a.aiService.IsOffline()
+}
+
+// SetOfflineMode turns offline mode on or off: AI requests fail with a clear offline error,
+// commit messages are generated from the staged files and background fetches and backups pause
+func (a *App) SetOfflineMode(offline bool) error {
+	if err := a.configService.SetOfflineMode(offline); err != nil {
+		return err
+	}
+	a.applyOfflineMode(offline)
+	a.emit("offline:changed", offline)
+	return nil
+}
+
+// applyOfflineMode switches the services that reach the network in the background
+func (a *App) applyOfflineMode(offline bool) {
+	a.aiService.SetOffline(offline)
+	a.backupService.SetPaused(offline)
+}
+
+// ============ Updates ============
+
+// GetAppVersion returns the version of this build, "dev" for development builds
+func (a *App) GetAppVersion() string {
+	return update.Version
+}
+
+// GetUpdateChannel returns the release channel checked for updates
+func (a *App) GetUpdateChannel() models.UpdateChannel {
+	return a.configService.GetUpdateChannel()
+}
+
+// SetUpdateChannel switches between stable releases and prereleases
+func (a *App) SetUpdateChannel(channel models.UpdateChannel) error {
+	return a.configService.SetUpdateChannel(channel)
+}
+
+// CheckForUpdates looks for a newer release on the configured channel
+func (a *App) CheckForUpdates() (*models.UpdateInfo, error) {
+	if a.aiService.IsOffline() {
+		return nil, fmt.Errorf("cannot check for updates in offline mode")
+	}
+	return a.updateService.Check(a.configService.GetUpdateChannel())
+}
+
+// DownloadUpdate downloads and verifies the release found by CheckForUpdates
+func (a *App) DownloadUpdate() (*models.UpdateInfo, error) {
+	if a.aiService.IsOffline() {
+		return nil, fmt.Errorf("cannot download updates in offline mode")
+	}
+	return a.updateService.Download()
+}
+
+// InstallUpdateOnRestart replaces the executable with the downloaded update when the app exits
+func (a *App) InstallUpdateOnRestart() error {
+	return a.updateService.InstallOnExit()
+}
+
+// ============ Forge Integration ============
+
+// GetForgeConfigs returns the configured code hosting accounts, without their tokens
+func (a *App) GetForgeConfigs() []models.ForgeConfig {
+	configs := a.configService.GetForgeConfigs()
+	for i := range configs {
+		configs[i].Token = ""
+	}
+	return configs
+}
+
+// SetForgeConfig adds or updates a code hosting account
+// A new token is validated first, recording the user, scopes and expiry it reports
+func (a *App) SetForgeConfig(config models.ForgeConfig) (models.ForgeConfig, error) {
+	if config.Provider != models.ForgeGitHub && config.Provider != models.ForgeGitLab {
+		return models.ForgeConfig{}, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+	if config.Token != "" {
+		info, err := a.forgeService.ValidateToken(config)
+		if err != nil {
+			return models.ForgeConfig{}, fmt.Errorf("token validation failed: %w", err)
+		}
+		if info.Expired {
+			return models.ForgeConfig{}, fmt.Errorf("token expired at %s", info.ExpiresAt)
+		}
+		applyTokenInfo(&config, info)
+	}
+
+	saved, err := a.configService.SetForgeConfig(config)
+	saved.Token = ""
+	return saved, err
+}
+
+// DeleteForgeConfig removes a code hosting account
+func (a *App) DeleteForgeConfig(id string) error {
+	return a.configService.DeleteForgeConfig(id)
+}
+
+// ValidateForgeAccount checks the stored token of an account again, updating its user,
+// scopes and expiry
+func (a *App) ValidateForgeAccount(id string) (*models.TokenInfo, error) {
+	config, err := a.configService.GetForgeAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := a.forgeService.ValidateToken(config)
+	if err != nil {
+		return nil, err
+	}
+	applyTokenInfo(&config, info)
+	if _, err := a.configService.SetForgeConfig(config); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// applyTokenInfo records what validating a token reported on its account, keeping a
+// name the user gave
+func applyTokenInfo(config *models.ForgeConfig, info *models.TokenInfo) {
+	config.Username = info.Username
+	config.Scopes = info.Scopes
+	config.ExpiresAt = info.ExpiresAt
+	if config.Name == "" {
+		config.Name = info.Username
+	}
+}
+
+// ListRemoteRepositories lists repositories from a code hosting service for the clone dialog
+func (a *App) ListRemoteRepositories(provider models.ForgeProvider, query string) ([]models.RemoteRepository, error) {
+	config, err := a.configService.GetForgeConfig(provider)
+	if err != nil {
+		return nil, err
+	}
+	return a.forgeService.ListRepositories(config, query)
+}
+
+// GetChecksStatus returns the CI status of a branch or commit, the current branch when ref is empty
+func (a *App) GetChecksStatus(ref string) (*models.ChecksStatus, error) {
+	config, remote, err := a.resolveForge()
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := a.gitService.ResolveCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := a.forgeService.GetChecksStatus(config, remote.Project, commit)
+	if err != nil {
+		return nil, err
+	}
+	status.Ref = ref
+	return status, nil
+}
+
+// PreflightPush checks what pushing branch to remote would send against the branch
+// protection of the forge, warning about unsigned commits, merges, force pushes and other
+// violations that would get the push rejected. Remotes not on a configured forge are only
+// inspected locally
+func (a *App) PreflightPush(remote, branch string) (*models.PushPreflight, error) {
+	if remote == "" {
+		remote = a.repositoryDefaults().DefaultRemote
+	}
+	outgoing, err := a.gitService.OutgoingCommits(remote, branch)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.PushPreflight{Outgoing: *outgoing, Warnings: []models.PushWarning{}}
+
+	config, info, err := a.resolveForgeRemote(outgoing.Remote)
+	if err != nil {
+		return result, nil
+	}
+	protection, err := a.forgeService.GetBranchProtection(config, info.Project, outgoing.RemoteBranch)
+	if err != nil {
+		return nil, err
+	}
+	result.Protection = protection
+	result.Warnings = forge.PushWarnings(*outgoing, protection)
+	return result, nil
+}
+
+// GeneratePullRequestDescription asks the AI for a pull request title and Markdown
+// description from the commits and cumulative diff of HEAD against baseBranch, the
+// repository's default branch when empty
+func (a *App) GeneratePullRequestDescription(baseBranch string) (*models.PullRequestDescription, error) {
+	_, end := trace.Begin(context.Background(), "GeneratePullRequestDescription")
+	defer end()
+	if baseBranch == "" {
+		baseBranch = a.repositoryDefaults().DefaultBranch
+	}
+	changes, err := a.gitService.BranchChanges(baseBranch)
+	if err != nil {
+		return nil, err
+	}
+	// Lockfiles and generated files are summarized like they are for commit messages
+	paths, fileDiffs := git.SplitFileDiffs(changes.Diff)
+	return a.aiService.GeneratePullRequestDescription(changes.Commits, a.joinDiffs(paths, fileDiffs))
+}
+
+// GetPullRequestComments returns the review comments of the pull request for the current branch
+func (a *App) GetPullRequestComments() (*models.PullRequestComments, error) {
+	config, remote, err := a.resolveForge()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := a.gitService.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	if status.Branch == "" || status.Branch == "HEAD" {
+		return nil, fmt.Errorf("not on a branch")
+	}
+
+	number := forge.PullRequestNumber(status.Branch, a.gitService.UpstreamRef(status.Branch))
+	return a.forgeService.GetPullRequestComments(config, remote.Project, status.Branch, number)
+}
+
+// GetMyReviewQueue returns the open pull and merge requests awaiting the user's review on
+// every forge account, limited to projects that are a remote of a managed repository
+// Accounts that cannot be queried are reported in Errors without failing the queue
+func (a *App) GetMyReviewQueue() (*models.ReviewQueue, error) {
+	type location struct {
+		repo   models.Repository
+		remote string
+	}
+	managed := map[string]location{}
+	for _, repo := range a.configService.GetAllRepositories() {
+		remotes, err := a.gitService.GetRemotesAt(repo.Path)
+		if err != nil {
+			continue
+		}
+		for _, r := range remotes {
+			info, err := forge.ParseRemoteURL(r.URL)
+			if err != nil {
+				continue
+			}
+			key := info.Host + "/" + strings.ToLower(info.Project)
+			if _, ok := managed[key]; !ok {
+				managed[key] = location{repo: repo, remote: r.Name}
+			}
+		}
+	}
+
+	queue := &models.ReviewQueue{Requests: []models.ReviewRequest{}, Errors: map[string]string{}}
+	seen := map[string]bool{}
+	for _, account := range a.configService.GetForgeConfigs() {
+		if !account.HasToken {
+			continue
+		}
+		name := account.Name
+		if name == "" {
+			name = account.ID
+		}
+		if err := checkTokenExpiry(account); err != nil {
+			queue.Errors[name] = err.Error()
+			continue
+		}
+
+		requests, err := a.forgeService.ListReviewRequests(account)
+		if err != nil {
+			queue.Errors[name] = err.Error()
+			continue
+		}
+		for _, r := range requests {
+			key := r.Host + "/" + strings.ToLower(r.Project)
+			loc, ok := managed[key]
+			if !ok || seen[r.URL] {
+				continue
+			}
+			seen[r.URL] = true
+			r.RepositoryID = loc.repo.ID
+			r.RepositoryPath = loc.repo.Path
+			r.Remote = loc.remote
+			queue.Requests = append(queue.Requests, r)
+		}
+	}
+
+	sort.Slice(queue.Requests, func(i, j int) bool {
+		return queue.Requests[i].UpdatedAt > queue.Requests[j].UpdatedAt
+	})
+	return queue, nil
+}
+
+// CheckoutReviewRequest opens the repository of a review request, fetches the head of the
+// pull or merge request into the local branch pr/<number> and switches to it
+func (a *App) CheckoutReviewRequest(request models.ReviewRequest) error {
+	if a.gitService.GetCurrentPath() != request.RepositoryPath {
+		if _, err := a.OpenRepository(request.RepositoryID); err != nil {
+			return err
+		}
+	}
+
+	return a.checkoutPullRequestRef(request.Remote, request.HeadRef, request.Number)
+}
+
+// CheckoutPullRequest fetches the head of a pull or merge request of the open repository
+// into the local branch pr/<number> and switches to it
+// The request is fetched from the upstream remote of a fork when there is one, otherwise
+// from the repository's default remote or origin
+func (a *App) CheckoutPullRequest(number int) error {
+	if number <= 0 {
+		return fmt.Errorf("invalid pull request number: %d", number)
+	}
+
+	remotes, err := a.gitService.GetRemotes()
+	if err != nil {
+		return err
+	}
+	name := a.repositoryDefaults().DefaultRemote
+	if name == "" {
+		name = "origin"
+	}
+	var remoteURL string
+	for _, r := range remotes {
+		if r.Name == "upstream" {
+			name, remoteURL = r.Name, r.URL
+			break
+		}
+		if r.Name == name {
+			remoteURL = r.URL
+		}
+	}
+	if remoteURL == "" {
+		return fmt.Errorf("remote not found: %s", name)
+	}
+
+	remote, err := forge.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return err
+	}
+	var provider models.ForgeProvider
+	switch forge.WebFlavor(remote, a.configService.GetForgeConfigs()) {
+	case forge.FlavorGitLab:
+		provider = models.ForgeGitLab
+	case forge.FlavorBitbucket:
+		return fmt.Errorf("bitbucket does not publish pull request refs")
+	default:
+		provider = models.ForgeGitHub
+	}
+	return a.checkoutPullRequestRef(name, forge.PullRequestRef(provider, number), number)
+}
+
+// checkoutPullRequestRef fetches a pull request head ref into pr/<number> and switches to it
+func (a *App) checkoutPullRequestRef(remote, ref string, number int) error {
+	branch := fmt.Sprintf("pr/%d", number)
+	if err := a.gitService.FetchRefToBranch(remote, ref, branch); err != nil {
+		return err
+	}
+	return a.CheckoutBranch(branch)
+}
+
+// ListIssues lists the issues of the current repository's project
+func (a *App) ListIssues(provider models.ForgeProvider, filter models.IssueFilter) ([]models.Issue, error) {
+	config, remote, err := a.resolveForgeFor(provider)
+	if err != nil {
+		return nil, err
+	}
+	return a.forgeService.ListIssues(config, remote.Project, filter)
+}
+
+// GetIssueBranchPattern returns the pattern used to name branches created from issues
+func (a *App) GetIssueBranchPattern() string {
+	return a.configService.GetIssueBranchPattern()
+}
+
+// SetIssueBranchPattern updates the branch naming pattern, {number} and {title} are replaced
+func (a *App) SetIssueBranchPattern(pattern string) error {
+	if !strings.Contains(pattern, "{number}") {
+		return fmt.Errorf("pattern must contain {number}")
+	}
+	return a.configService.SetIssueBranchPattern(pattern)
+}
+
+// CreateBranchFromIssue creates and checks out a branch named after an issue
+// The issue key is remembered so generated commit messages reference it
+func (a *App) CreateBranchFromIssue(provider models.ForgeProvider, issueNumber int) (string, error) {
+	config, remote, err := a.resolveForgeFor(provider)
+	if err != nil {
+		return "", err
+	}
+
+	issue, err := a.forgeService.GetIssue(config, remote.Project, issueNumber)
+	if err != nil {
+		return "", err
+	}
+
+	branch := forge.IssueBranchName(a.configService.GetIssueBranchPattern(), issue)
+	if err := a.gitService.CreateBranch(branch, true); err != nil {
+		return "", err
+	}
+	a.notifyChanged(changeHistory)
+
+	if err := a.configService.SetBranchIssue(a.gitService.GetCurrentPath(), branch, issue.Key); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// FormatCommitReference formats a commit for copying: "plain" gives "abc1234 (subject)",
+// "markdown" links the hash to the forge when origin is a known host, "patch" gives the full patch
+func (a *App) FormatCommitReference(hash, style string) (string, error) {
+	if style == "patch" {
+		return a.gitService.CommitAsPatch(hash)
+	}
+
+	short, full, subject, err := a.gitService.CommitSummary(hash)
+	if err != nil {
+		return "", err
+	}
+	switch style {
+	case "", "plain":
+		return fmt.Sprintf("%s (%s)", short, subject), nil
+	case "markdown":
+		if link := a.commitWebURL(full); link != "" {
+			return fmt.Sprintf("[`%s`](%s) %s", short, link, subject), nil
+		}
+		return fmt.Sprintf("`%s` %s", short, subject), nil
+	}
+	return "", fmt.Errorf("unknown reference style: %s", style)
+}
+
+// GetWebURLs returns the browser pages of a commit, a file at a revision, a branch or a
+// comparison on the forge of each remote, origin first
+// ref defaults to HEAD for commits and files, to the current branch for branches, and a
+// comparison given only a head branch is made against the repository's default branch
+func (a *App) GetWebURLs(kind, ref, path string, line int) ([]models.WebURL, error) {
+	ref, err := a.webRef(kind, ref)
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := a.gitService.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range remotes {
+		if r.Name == "origin" {
+			remotes[0], remotes[i] = remotes[i], remotes[0]
+			break
+		}
+	}
+
+	configs := a.configService.GetForgeConfigs()
+	urls := []models.WebURL{}
+	for _, r := range remotes {
+		remote, err := forge.ParseRemoteURL(r.URL)
+		if err != nil {
+			continue
+		}
+		flavor := forge.WebFlavor(remote, configs)
+		link, err := forge.WebURL(remote, flavor, kind, ref, filepath.ToSlash(path), line)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, models.WebURL{Remote: r.Name, Flavor: flavor, URL: link})
+	}
+	return urls, nil
+}
+
+// webRef fills in the default reference of a web page kind, resolving commits to full
+// hashes so links stay valid as branches move
+func (a *App) webRef(kind, ref string) (string, error) {
+	switch kind {
+	case forge.WebCommit, forge.WebFile:
+		if ref == "" {
+			ref = "HEAD"
+		}
+		return a.gitService.ResolveCommit(ref)
+	case forge.WebBranch, forge.WebCompare:
+		if kind == forge.WebCompare && strings.Contains(ref, "...") {
+			return ref, nil
+		}
+		if ref == "" {
+			current, err := a.gitService.CurrentBranch()
+			if err != nil {
+				return "", err
+			}
+			if current == "" {
+				return "", fmt.Errorf("HEAD is not on a branch")
+			}
+			ref = current
+		}
+		if kind == forge.WebBranch {
+			return ref, nil
+		}
+		base := a.repositoryDefaults().DefaultBranch
+		if base == "" {
+			base = "main"
+		}
+		return base + "..." + ref, nil
+	}
+	return "", fmt.Errorf("unknown web URL kind: %s", kind)
+}
+
+// commitWebURL returns the forge page of a commit on origin, or "" when origin is not a forge
+func (a *App) commitWebURL(hash string) string {
+	urls, err := a.GetWebURLs(forge.WebCommit, hash, "", 0)
+	if err != nil || len(urls) == 0 || urls[0].Remote != "origin" {
+		return ""
+	}
+	return urls[0].URL
+}
+
+// resolveForgeFor uses the configuration of the given provider with the project of the origin remote
+// The repository's forge account is preferred when it belongs to the provider
+func (a *App) resolveForgeFor(provider models.ForgeProvider) (models.ForgeConfig, *forge.RemoteInfo, error) {
+	if provider == "" {
+		return a.resolveForge()
+	}
+
+	config, err := a.configService.GetForgeAccount(a.repositoryDefaults().ForgeAccount)
+	if err != nil || config.Provider != provider {
+		config, err = a.configService.GetForgeConfig(provider)
+		if err != nil {
+			return models.ForgeConfig{}, nil, err
+		}
+	}
+	if err := checkTokenExpiry(config); err != nil {
+		return models.ForgeConfig{}, nil, err
+	}
+
+	remoteURL, err := a.gitService.GetRemoteURL("origin")
+	if err != nil {
+		return models.ForgeConfig{}, nil, err
+	}
 
-// GetLog returns commit history
-func (a *App) GetLog(limit int) ([]models.CommitInfo, error) {
-	return a.gitService.GetLog(limit)
+	remote, err := forge.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return models.ForgeConfig{}, nil, err
+	}
+	return config, remote, nil
 }
 
-// ============ AI Configuration ============
-
-// GetAIConfig returns the AI configuration
-func (a *App) GetAIConfig() models.AIConfig {
-	return a.configService.GetAIConfig()
+// resolveForge finds the forge configuration and project for the origin remote
+func (a *App) resolveForge() (models.ForgeConfig, *forge.RemoteInfo, error) {
+	return a.resolveForgeRemote("origin")
 }
 
-// SetAIConfig updates the AI configuration
-func (a *App) SetAIConfig(config models.AIConfig) error {
-	// First set the config to the AI service
-	a.aiService.SetConfig(config)
+// resolveForgeRemote finds the forge configuration and project for a remote
+// The repository's forge account is used when one is selected, otherwise the account is
+// matched by the host of the remote
+func (a *App) resolveForgeRemote(remoteName string) (models.ForgeConfig, *forge.RemoteInfo, error) {
+	remoteURL, err := a.gitService.GetRemoteURL(remoteName)
+	if err != nil {
+		return models.ForgeConfig{}, nil, err
+	}
 
-	// Then validate the new config
-	if err := a.aiService.ValidateConfig(); err != nil {
-		return fmt.Errorf("AI configuration validation failed: %w", err)
+	remote, err := forge.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return models.ForgeConfig{}, nil, err
 	}
 
-	// Finally save to config service
-	if err := a.configService.SetAIConfig(config); err != nil {
-		return fmt.Errorf("failed to save AI configuration: %w", err)
+	config, err := a.configService.GetForgeAccount(a.repositoryDefaults().ForgeAccount)
+	if err != nil {
+		config, err = forge.MatchConfig(remote, a.configService.GetForgeConfigs())
+		if err != nil {
+			return models.ForgeConfig{}, nil, err
+		}
 	}
-	return nil
+	if err := checkTokenExpiry(config); err != nil {
+		return models.ForgeConfig{}, nil, err
+	}
+	return config, remote, nil
 }
 
-// TestAIConnection tests the AI service connection
-// If config is provided, it validates the given config without modifying internal state
-// If no config is provided (detected by empty Provider field), it validates the current configuration
-func (a *App) TestAIConnection(config models.AIConfig) error {
-	if config.Provider != "" {
-		// Validate the provided config without modifying internal state
-		if err := a.aiService.ValidateConfigParam(config); err != nil {
-			return fmt.Errorf("AI configuration validation failed: %w", err)
-		}
+// checkTokenExpiry rejects accounts whose token is known to have expired
+func checkTokenExpiry(config models.ForgeConfig) error {
+	if config.ExpiresAt == "" {
 		return nil
 	}
-	// Validate current configuration
-	if err := a.aiService.ValidateConfig(); err != nil {
-		return fmt.Errorf("AI configuration validation failed: %w", err)
+	if expires, err := time.Parse(time.RFC3339, config.ExpiresAt); err == nil && time.Now().After(expires) {
+		return fmt.Errorf("token of forge account %s expired at %s", config.Name, config.ExpiresAt)
 	}
 	return nil
 }
@@ -251,13 +2557,19 @@ func (a *App) SelectDirectory() (string, error) {
 	return path, nil
 }
 
-// IsValidGitRepository checks if a path is a valid git repository
-func (a *App) IsValidGitRepository(path string) bool {
-	gitDir := filepath.Join(path, ".git")
-	if _, err := os.Stat(gitDir); err == nil {
-		return true
+// CopyToClipboard copies text such as a commit hash to the system clipboard
+func (a *App) CopyToClipboard(text string) error {
+	if a.ctx == nil {
+		return fmt.Errorf("application context not initialized")
 	}
-	return false
+	return runtime.ClipboardSetText(a.ctx, text)
+}
+
+// IsValidGitRepository checks if a path is inside a repository with a working tree,
+// including worktrees and submodules where .git is a file
+func (a *App) IsValidGitRepository(path string) bool {
+	_, err := a.gitService.FindRepositoryRoot(path)
+	return err == nil
 }
 
 // OpenRepositoryInTerminal opens the repository in terminal (placeholder)
@@ -272,38 +2584,192 @@ func (a *App) OpenFileInEditor(filePath string) error {
 	return nil
 }
 
-// GetRepositoryInfo returns repository information
-func (a *App) GetRepositoryInfo() (map[string]interface{}, error) {
-	currentPath := a.gitService.GetCurrentPath()
+// GetRepositoryInfo returns the branch, tracking, stash, fetch and operation state of the
+// selected repository in one call for the status bar
+func (a *App) GetRepositoryInfo() (*models.RepositoryInfo, error) {
+	return a.repositoryInfo(a.gitService)
+}
+
+// repositoryInfo builds the repository info from g, which may be a snapshot of gitService
+func (a *App) repositoryInfo(g *git.GitService) (*models.RepositoryInfo, error) {
+	currentPath := g.GetCurrentPath()
+	info := &models.RepositoryInfo{Path: currentPath, Operation: models.OperationInfo{State: models.OperationNone}}
 	if currentPath == "" {
-		return map[string]interface{}{
-			"path":       "",
-			"branch":     "",
-			"hasChanges": false,
-			"isRepo":     false,
-		}, nil
+		return info, nil
+	}
+	info.Scope = g.GetScope()
+
+	if g.IsBare() {
+		info.IsRepo = true
+		info.Bare = true
+		info.HasCommits = g.HasCommits()
+		if branch, err := g.CurrentBranch(); err == nil {
+			info.Branch = branch
+		}
+		return info, nil
 	}
 
-	status, err := a.gitService.GetStatus()
+	status, err := g.GetStatus()
 	if err != nil {
 		// If no repository is selected, return isRepo=false
 		if strings.Contains(err.Error(), "no repository selected") {
-			return map[string]interface{}{
-				"path":       currentPath,
-				"branch":     "",
-				"hasChanges": false,
-				"isRepo":     false,
-			}, nil
+			return info, nil
 		}
 		return nil, err
 	}
 
-	return map[string]interface{}{
-		"path":       currentPath,
-		"branch":     status.Branch,
-		"hasChanges": status.HasChanges,
-		"isRepo":     status.IsRepo,
-	}, nil
+	info.Branch = status.Branch
+	info.Upstream = status.Upstream
+	info.Ahead = status.Ahead
+	info.Behind = status.Behind
+	info.Detached = status.HasCommits && status.Branch == "HEAD"
+	info.HasChanges = status.HasChanges
+	info.IsRepo = status.IsRepo
+	info.Scope = status.Scope
+	info.HasCommits = status.HasCommits
+	info.Operation = status.Operation
+	info.Health = a.health
+	info.RemoteView = a.remoteStaleness(g)
+	info.LastFetch = info.RemoteView.LastFetch
+
+	if info.StashCount, err = g.StashCount(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// ============ Remote Freshness ============
+
+// Fetch updates the remote-tracking refs of a remote without touching local branches,
+// optionally pruning refs of deleted branches and fetching all tags
+func (a *App) Fetch(remote string, prune, tags bool) (*models.FetchResult, error) {
+	jobID := jobs.Begin(models.PendingOperation{
+		Kind:   jobs.KindFetch,
+		Repo:   a.gitService.GetCurrentPath(),
+		Remote: remote,
+	})
+	defer jobs.Finish(jobID)
+
+	result, err := a.gitService.Fetch(remote, prune, tags)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChanged(changeBranches | changeTags)
+	return result, nil
+}
+
+// FetchAll fetches every remote, reporting the outcome of each one
+func (a *App) FetchAll(prune, tags bool) ([]models.FetchResult, error) {
+	// An empty remote resumes as a fetch of every remote
+	jobID := jobs.Begin(models.PendingOperation{
+		Kind: jobs.KindFetch,
+		Repo: a.gitService.GetCurrentPath(),
+	})
+	defer jobs.Finish(jobID)
+
+	results, err := a.gitService.FetchAll(prune, tags)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChanged(changeBranches | changeTags)
+	return results, nil
+}
+
+// CheckRemoteStaleness reports when the remotes were last fetched and whether that is too long ago
+func (a *App) CheckRemoteStaleness() models.RemoteStaleness {
+	return a.remoteStaleness(a.gitService)
+}
+
+// GetFetchPolicy returns how remote refs are refreshed before branch operations
+func (a *App) GetFetchPolicy() models.FetchPolicy {
+	return a.configService.GetFetchPolicy()
+}
+
+// SetFetchPolicy updates how remote refs are refreshed before branch operations
+func (a *App) SetFetchPolicy(policy models.FetchPolicy) error {
+	return a.configService.SetFetchPolicy(policy)
+}
+
+// remoteStaleness measures the age of the last fetch in g against the configured limit
+func (a *App) remoteStaleness(g *git.GitService) models.RemoteStaleness {
+	policy := a.configService.GetFetchPolicy()
+	staleness := models.RemoteStaleness{}
+
+	// Repositories without remotes have nothing to go stale
+	if names, err := g.GetRemoteNames(); err != nil || len(names) == 0 {
+		return staleness
+	}
+
+	last, ok := g.LastFetchTime()
+	if !ok {
+		staleness.Stale = true
+		return staleness
+	}
+	staleness.LastFetch = last.Format(time.RFC3339)
+	staleness.AgeMinutes = int(time.Since(last).Minutes())
+	staleness.Stale = policy.StaleMinutes > 0 && staleness.AgeMinutes >= policy.StaleMinutes
+	return staleness
+}
+
+// refreshRemoteView runs before branch operations: it fetches stale remotes when
+// auto-fetch is on and emits a "remote:stale" event when the refs are still stale
+func (a *App) refreshRemoteView() {
+	staleness := a.remoteStaleness(a.gitService)
+	if !staleness.Stale {
+		return
+	}
+
+	if a.configService.GetFetchPolicy().AutoFetch && !a.aiService.IsOffline() {
+		if err := a.fetchAll(); err != nil {
+			staleness.Error = err.Error()
+		} else {
+			staleness = a.remoteStaleness(a.gitService)
+			staleness.Fetched = true
+			if !staleness.Stale {
+				return
+			}
+		}
+	}
+
+	a.emit("remote:stale", staleness)
+}
+
+// fetchAll fetches every remote, failing when any remote could not be fetched
+func (a *App) fetchAll() error {
+	results, err := a.gitService.FetchAll(false, false)
+	if err != nil {
+		return err
+	}
+	var failures []string
+	for _, result := range results {
+		if result.Error != "" {
+			failures = append(failures, result.Remote+": "+result.Error)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("fetch failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// CheckRepositoryHealth re-runs the repository health probe
+func (a *App) CheckRepositoryHealth() (*models.RepositoryHealth, error) {
+	health, err := a.gitService.CheckHealth()
+	if err != nil {
+		return nil, err
+	}
+	a.health = health
+	return health, nil
+}
+
+// RunMaintenance runs a maintenance action suggested by the health probe
+func (a *App) RunMaintenance(action string) error {
+	if err := a.gitService.RunMaintenance(action); err != nil {
+		return err
+	}
+	a.health, _ = a.gitService.CheckHealth()
+	return nil
 }
 
 // RemoveRecentRepository removes a repository from recent list
@@ -311,14 +2777,75 @@ func (a *App) RemoveRecentRepository(path string) error {
 	return a.configService.RemoveRecentRepo(path)
 }
 
-// Push pushes the current branch to remote
-func (a *App) Push(remote string) error {
-	return a.gitService.Push(remote)
+// Push pushes to a remote as described by opts
+// The repository's default remote is used when none is given, and its "upstream" push
+// behavior makes the pushed branch track the remote
+func (a *App) Push(opts models.PushOptions) error {
+	defaults := a.repositoryDefaults()
+	if opts.Remote == "" {
+		opts.Remote = defaults.DefaultRemote
+	}
+	if defaults.PushBehavior == models.PushUpstream && opts.Refspec == "" {
+		opts.SetUpstream = true
+	}
+
+	parts := changeBranches
+	if opts.Tags {
+		parts |= changeTags
+	}
+	return a.changed(a.gitService.Push(opts), parts)
 }
 
 // Pull pulls changes from remote
 func (a *App) Pull(remote string, branch string) error {
-	return a.gitService.Pull(remote, branch)
+	if remote == "" {
+		remote = a.repositoryDefaults().DefaultRemote
+	}
+	jobID := jobs.Begin(models.PendingOperation{
+		Kind:   jobs.KindPull,
+		Repo:   a.gitService.GetCurrentPath(),
+		Remote: remote,
+		Branch: branch,
+	})
+	defer jobs.Finish(jobID)
+
+	return a.changedEvenOnError(a.gitService.Pull(remote, branch), changeHistory)
+}
+
+// ============ Interrupted Operations ============
+
+// GetPendingOperations returns the clone, fetch and pull operations a previous run did not finish
+func (a *App) GetPendingOperations() []models.PendingOperation {
+	return jobs.Pending()
+}
+
+// ResumeOrCleanupPendingOperations re-runs interrupted fetches and removes half-cloned directories
+// An interrupted pull is resumed as a fetch only, merging is left to the user
+func (a *App) ResumeOrCleanupPendingOperations() []models.PendingOperationResult {
+	results := []models.PendingOperationResult{}
+	for _, op := range jobs.Pending() {
+		result := models.PendingOperationResult{Operation: op}
+
+		var err error
+		switch op.Kind {
+		case jobs.KindClone:
+			result.Action = "cleaned"
+			err = a.gitService.CleanupPartialClone(op.Repo, op.CreatedDir)
+		case jobs.KindFetch, jobs.KindPull:
+			result.Action = "resumed"
+			err = a.gitService.FetchRepository(op.Repo, op.Remote)
+		default:
+			result.Action = "skipped"
+		}
+		if err != nil {
+			result.Action = "skipped"
+			result.Error = err.Error()
+		}
+
+		jobs.Finish(op.ID)
+		results = append(results, result)
+	}
+	return results
 }
 
 // ResetType represents the type of reset (exposed for frontend)
@@ -330,14 +2857,25 @@ const (
 	ResetHard  ResetType = git.ResetHard
 )
 
+// ResolveRef expands a branch, tag or abbreviated hash to the full commit hash
+func (a *App) ResolveRef(ref string) (string, error) {
+	return a.gitService.ResolveCommit(ref)
+}
+
+// ResolveLocation tells the UI whether a token is a branch, tag, commit or file
+func (a *App) ResolveLocation(token string) (*models.Location, error) {
+	return a.gitService.ResolveLocation(token)
+}
+
 // Reset resets the current branch
 func (a *App) Reset(resetType ResetType, commit string) error {
-	return a.gitService.Reset(resetType, commit)
+	a.checkpoint(fmt.Sprintf("reset --%s %s", resetType, commit))
+	return a.changed(a.gitService.Reset(resetType, commit), changeHistory)
 }
 
 // Revert creates a new commit that undoes changes
 func (a *App) Revert(commit string, noCommit bool) error {
-	return a.gitService.Revert(commit, noCommit)
+	return a.changedEvenOnError(a.gitService.Revert(commit, noCommit), changeStatus|changeLog)
 }
 
 // GetRemoteNames returns available remote names
@@ -346,49 +2884,67 @@ func (a *App) GetRemoteNames() ([]string, error) {
 }
 
 // Tag represents a git tag (type alias)
-type Tag = git.Tag
+type Tag = models.Tag
 
 // GetTags returns all tags
 func (a *App) GetTags() ([]Tag, error) {
-	tags, err := a.gitService.GetTags()
-	if err != nil {
-		return nil, err
-	}
-	// Convert to app-level Tag type
-	result := make([]Tag, len(tags))
-	for i, t := range tags {
-		result[i] = Tag(t)
-	}
-	return result, nil
+	return a.gitService.GetTags()
 }
 
 // CreateTag creates a new tag
 func (a *App) CreateTag(name string, message string, commit string) error {
-	return a.gitService.CreateTag(name, message, commit)
+	return a.changed(a.gitService.CreateTag(name, message, commit), changeTags|changeLog)
 }
 
 // DeleteTag deletes a tag
 func (a *App) DeleteTag(name string) error {
-	return a.gitService.DeleteTag(name)
+	return a.changed(a.gitService.DeleteTag(name), changeTags|changeLog)
 }
 
 // CheckoutTag checks out a tag
 func (a *App) CheckoutTag(name string) error {
-	return a.gitService.CheckoutTag(name)
+	return a.changed(a.gitService.CheckoutTag(name), changeHistory)
+}
+
+// GenerateChangelog writes release notes for the commits after fromRef up to toRef, grouped
+// by Conventional Commit type and optionally polished by AI
+// toRef defaults to HEAD and fromRef to the latest tag before it
+func (a *App) GenerateChangelog(fromRef, toRef string, useAI bool) (*models.Changelog, error) {
+	return a.releaseService.Changelog(fromRef, toRef, useAI)
 }
 
 // MergeBranch merges a branch
 func (a *App) MergeBranch(branch string, noFF bool) error {
-	return a.gitService.MergeBranch(branch, noFF)
+	a.refreshRemoteView()
+	return a.changedEvenOnError(a.gitService.MergeBranch(branch, noFF), changeStatus|changeLog)
+}
+
+// PredictMergeConflicts reports which files would conflict when merging source into
+// target, the current branch when target is empty, without touching the working tree
+func (a *App) PredictMergeConflicts(source, target string) (*models.MergePrediction, error) {
+	return a.gitService.PredictMergeConflicts(source, target)
+}
+
+// PreviewDeleteBranch reports unmerged and unpushed work before a branch is deleted
+func (a *App) PreviewDeleteBranch(name string) (*models.DeleteBranchPreview, error) {
+	a.refreshRemoteView()
+	return a.gitService.PreviewDeleteBranch(name)
 }
 
 // DeleteBranch deletes a branch
 func (a *App) DeleteBranch(name string, force bool) error {
-	return a.gitService.DeleteBranch(name, force)
+	if err := a.checkProtectedBranch(name); err != nil {
+		return err
+	}
+	a.refreshRemoteView()
+	return a.changed(a.gitService.DeleteBranch(name, force), changeBranches)
 }
 
 // DiffBranches compares two branches
 func (a *App) DiffBranches(branch1 string, branch2 string) (string, error) {
+	if branch1 == "" {
+		branch1 = a.repositoryDefaults().DefaultBranch
+	}
 	return a.gitService.DiffBranches(branch1, branch2)
 }
 
@@ -397,6 +2953,122 @@ func (a *App) GetCommitDetail(commitHash string) (map[string]interface{}, error)
 	return a.gitService.GetCommitDetail(commitHash)
 }
 
+// ============ Performance ============
+
+// GetPerformanceReport summarizes git command and AI call timings of the last days days
+func (a *App) GetPerformanceReport(days int) (*models.PerformanceReport, error) {
+	return trace.Report(days)
+}
+
+// SetPerformanceTracing turns recording of operation timings on or off
+func (a *App) SetPerformanceTracing(enabled bool) error {
+	if err := a.configService.SetTracingEnabled(enabled); err != nil {
+		return err
+	}
+	trace.SetEnabled(enabled)
+	return nil
+}
+
+// ClearPerformanceData deletes all recorded operation timings
+func (a *App) ClearPerformanceData() error {
+	return trace.Clear()
+}
+
+// GetFSMonitorStatus reports the filesystem monitor setup and recent git status latency
+func (a *App) GetFSMonitorStatus() (*models.FSMonitorStatus, error) {
+	status, err := a.gitService.GetFSMonitorStatus()
+	if err != nil {
+		return nil, err
+	}
+	status.StatusLatency, _ = trace.CommandStats("git status", a.gitService.GetCurrentPath(), 7)
+	return status, nil
+}
+
+// SetFSMonitor enables or disables the filesystem monitor for the current repository
+func (a *App) SetFSMonitor(enabled bool) error {
+	return a.gitService.SetFSMonitor(enabled)
+}
+
+// ============ Diagnostics ============
+
+// GetRecentLogs returns the newest log entries at or above level
+func (a *App) GetRecentLogs(level string, limit int) ([]models.LogEntry, error) {
+	return logging.Recent(level, limit)
+}
+
+// GetLogDirectory returns the directory holding the log files
+func (a *App) GetLogDirectory() string {
+	return logging.Dir()
+}
+
+// ============ Crash Reports ============
+
+// GetCrashReporting reports whether crash reports are captured
+func (a *App) GetCrashReporting() bool {
+	return a.configService.GetCrashReporting()
+}
+
+// SetCrashReporting opts in to or out of capturing crash reports; reports stay on this
+// machine until the user exports or submits them
+func (a *App) SetCrashReporting(enabled bool) error {
+	if err := crash.Enable(enabled); err != nil {
+		return err
+	}
+	return a.configService.SetCrashReporting(enabled)
+}
+
+// ListCrashReports returns the captured crash reports, newest first
+func (a *App) ListCrashReports() ([]models.CrashReport, error) {
+	return crash.List()
+}
+
+// ExportCrashReport saves a crash report to a file the user picks and returns its path,
+// "" when the dialog is cancelled
+func (a *App) ExportCrashReport(id string) (string, error) {
+	if a.ctx == nil {
+		return "", fmt.Errorf("application context not initialized")
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Crash Report",
+		DefaultFilename: "crash-" + id + ".json",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if path == "" {
+		return "", nil
+	}
+	return path, crash.Export(id, path)
+}
+
+// SubmitCrashReport opens a prefilled issue for the report in the browser, where the user
+// reviews it before anything is sent
+func (a *App) SubmitCrashReport(id string) error {
+	link, err := crash.IssueURL(id)
+	if err != nil {
+		return err
+	}
+	if a.ctx == nil {
+		return fmt.Errorf("application context not initialized")
+	}
+	runtime.BrowserOpenURL(a.ctx, link)
+	return nil
+}
+
+// DeleteCrashReport removes a captured crash report
+func (a *App) DeleteCrashReport(id string) error {
+	return crash.Delete(id)
+}
+
+// crashScrubber hides the managed and recent repositories in crash reports
+func (a *App) crashScrubber() *crash.Scrubber {
+	paths := a.configService.GetRecentRepos()
+	for _, repo := range a.configService.GetAllRepositories() {
+		paths = append(paths, repo.Path)
+	}
+	return crash.NewScrubber(paths)
+}
+
 // ============ Prompt Management ============
 
 // GetPrompts returns all prompts
@@ -434,6 +3106,31 @@ func (a *App) SetDefaultPrompt(id string) error {
 	return a.templateService.SetDefaultPrompt(id)
 }
 
+// GetPromptHistory returns the saved revisions of a prompt
+func (a *App) GetPromptHistory(id string) []models.TemplateRevision {
+	return a.templateService.GetPromptHistory(id)
+}
+
+// RollbackPrompt restores a prompt to a saved revision
+func (a *App) RollbackPrompt(id, versionID string) (*models.Prompt, error) {
+	return a.templateService.RollbackPrompt(id, versionID)
+}
+
+// GetPromptLibrary returns the built-in prompt templates and whether each is installed
+func (a *App) GetPromptLibrary() ([]models.LibraryPrompt, error) {
+	return a.templateService.GetPromptLibrary()
+}
+
+// InstallLibraryPrompt adds a built-in prompt template to the user's prompts
+func (a *App) InstallLibraryPrompt(id string) (*models.Prompt, error) {
+	return a.templateService.InstallLibraryPrompt(id)
+}
+
+// UpdateLibraryPrompt upgrades an installed built-in prompt template to its latest version
+func (a *App) UpdateLibraryPrompt(id string) (*models.Prompt, error) {
+	return a.templateService.UpdateLibraryPrompt(id)
+}
+
 // ============ Command Management ============
 
 // GetCommands returns all commands
@@ -471,21 +3168,40 @@ func (a *App) DeleteCommand(id string) error {
 	return a.templateService.DeleteCommand(id)
 }
 
+// GetCommandHistory returns the saved revisions of a command
+func (a *App) GetCommandHistory(id string) []models.TemplateRevision {
+	return a.templateService.GetCommandHistory(id)
+}
+
+// RollbackCommand restores a command to a saved revision
+func (a *App) RollbackCommand(id, versionID string) (*models.Command, error) {
+	return a.templateService.RollbackCommand(id, versionID)
+}
+
 // ============ Repository Management ============
 
 // GetAllRepositories returns all managed repositories
 func (a *App) GetAllRepositories() []models.Repository {
-	return a.configService.GetAllRepositories()
+	return a.withBackupStatus(a.configService.GetAllRepositories())
 }
 
 // GetRepository returns a repository by ID
 func (a *App) GetRepository(id string) *models.Repository {
-	return a.configService.GetRepository(id)
+	repo := a.configService.GetRepository(id)
+	if repo != nil {
+		repo.LastBackup = a.backupService.LastSuccess(repo.Path)
+	}
+	return repo
 }
 
 // AddRepository adds a new repository
+// A subdirectory is saved as the repository it belongs to, the path SelectRepository uses
 func (a *App) AddRepository(path, alias, description string) (*models.Repository, error) {
-	return a.configService.AddRepository(path, alias, description)
+	root, err := a.gitService.FindRepositoryRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	return a.configService.AddRepository(root, alias, description)
 }
 
 // UpdateRepository updates an existing repository
@@ -498,6 +3214,62 @@ func (a *App) UpdateRepositoryAlias(id, alias string) error {
 	return a.configService.UpdateRepositoryAlias(id, alias)
 }
 
+// SetRepositoryScope sets the path scope of a repository
+// An empty scope makes status and log cover the whole repository again
+func (a *App) SetRepositoryScope(id, scope string) error {
+	repo := a.configService.GetRepository(id)
+	if repo == nil {
+		return fmt.Errorf("repository not found: %s", id)
+	}
+
+	scope, err := git.ScopePath(repo.Path, scope)
+	if err != nil {
+		return err
+	}
+	if scope != "" {
+		info, err := os.Stat(filepath.Join(repo.Path, filepath.FromSlash(scope)))
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("scope is not a directory in the repository: %s", scope)
+		}
+	}
+
+	if err := a.configService.UpdateRepositoryScope(id, scope); err != nil {
+		return err
+	}
+
+	// Apply immediately if this is the open repository
+	if repo.Path == a.gitService.GetCurrentPath() {
+		a.gitService.SetScope(scope)
+	}
+	return nil
+}
+
+// SetRepositoryDefaults sets the default remote, base branch and push behavior of a repository
+func (a *App) SetRepositoryDefaults(id string, defaults models.RepositoryDefaults) error {
+	if a.configService.GetRepository(id) == nil {
+		return fmt.Errorf("repository not found: %s", id)
+	}
+	switch defaults.PushBehavior {
+	case "", models.PushUpstream, models.PushCurrent:
+	default:
+		return fmt.Errorf("invalid push behavior: %s", defaults.PushBehavior)
+	}
+	if defaults.ForgeAccount != "" {
+		if _, err := a.configService.GetForgeAccount(defaults.ForgeAccount); err != nil {
+			return err
+		}
+	}
+	return a.configService.UpdateRepositoryDefaults(id, defaults)
+}
+
+// repositoryDefaults returns the defaults of the open repository, empty when it is not managed
+func (a *App) repositoryDefaults() models.RepositoryDefaults {
+	if repo := a.configService.GetRepositoryByPath(a.gitService.GetCurrentPath()); repo != nil {
+		return repo.Defaults
+	}
+	return models.RepositoryDefaults{}
+}
+
 // DeleteRepository deletes a repository by ID
 func (a *App) DeleteRepository(id string) error {
 	return a.configService.DeleteRepository(id)
@@ -505,5 +3277,38 @@ func (a *App) DeleteRepository(id string) error {
 
 // SearchRepositories searches repositories by keyword
 func (a *App) SearchRepositories(keyword string) []models.Repository {
-	return a.configService.SearchRepositories(keyword)
+	return a.withBackupStatus(a.configService.SearchRepositories(keyword))
+}
+
+// withBackupStatus fills in the time of the last successful backup of each repository
+func (a *App) withBackupStatus(repos []models.Repository) []models.Repository {
+	for i := range repos {
+		repos[i].LastBackup = a.backupService.LastSuccess(repos[i].Path)
+	}
+	return repos
+}
+
+// ============ Backups ============
+
+// GetBackupTargets returns the backup remotes of the current repository
+func (a *App) GetBackupTargets() []models.BackupTarget {
+	return a.backupService.GetTargets(a.gitService.GetCurrentPath())
+}
+
+// SaveBackupTarget creates or updates a backup remote, defaulting to the current repository
+func (a *App) SaveBackupTarget(target models.BackupTarget) (*models.BackupTarget, error) {
+	if target.RepoPath == "" {
+		target.RepoPath = a.gitService.GetCurrentPath()
+	}
+	return a.backupService.SaveTarget(target)
+}
+
+// DeleteBackupTarget removes a backup remote
+func (a *App) DeleteBackupTarget(id string) error {
+	return a.backupService.DeleteTarget(id)
+}
+
+// RunBackup pushes a backup remote now
+func (a *App) RunBackup(id string) (*models.BackupTarget, error) {
+	return a.backupService.Run(id)
 }
@@ -20,7 +20,7 @@ set OUTPUT_DIR=bin
 if not exist %OUTPUT_DIR% mkdir %OUTPUT_DIR%
 
 echo 正在使用 wails build...
-wails build -platform windows/amd64 -o "%OUTPUT_DIR%\git-tools-%VERSION%-windows-x64.exe"
+wails build -platform windows/amd64 -ldflags "-X git-ai-tools/internal/update.Version=%VERSION%" -o "%OUTPUT_DIR%\git-tools-%VERSION%-windows-x64.exe"
 
 if %ERRORLEVEL% equ 0 (
     echo ============================================
@@ -3,70 +3,244 @@
 import {models} from '../models';
 import {git} from '../models';
 
+export function AbortOperation():Promise<void>;
+
+export function AcknowledgeRewrite(arg1:Array<string>):Promise<void>;
+
 export function AddRemote(arg1:string,arg2:string):Promise<void>;
 
+export function AddRemoteVerified(arg1:models.AddRemoteOptions):Promise<models.AddRemoteResult>;
+
 export function AddRepository(arg1:string,arg2:string,arg3:string):Promise<models.Repository>;
 
+export function AnalyzeCommitQuality(arg1:number):Promise<models.CommitQualityReport>;
+
+export function ApplyAttributesFix(arg1:Array<string>):Promise<void>;
+
+export function ApplySuggestedPatch(arg1:string,arg2:string):Promise<models.SuggestedPatchResult>;
+
+export function AutosquashRebase(arg1:string):Promise<void>;
+
+export function CancelGeneration():Promise<void>;
+
+export function CancelLogStream(arg1:string):Promise<void>;
+
+export function CheckForUpdates():Promise<models.UpdateInfo>;
+
+export function CheckLineEndings():Promise<Array<models.FileIssue>>;
+
+export function CheckRemoteStaleness():Promise<models.RemoteStaleness>;
+
+export function CheckRepositoryHealth():Promise<models.RepositoryHealth>;
+
 export function CheckoutBranch(arg1:string):Promise<void>;
 
+export function CheckoutBranchWithStrategy(arg1:string,arg2:string):Promise<models.CheckoutResult>;
+
+export function CheckoutPullRequest(arg1:number):Promise<void>;
+
+export function CheckoutReviewRequest(arg1:models.ReviewRequest):Promise<void>;
+
 export function CheckoutTag(arg1:string):Promise<void>;
 
+export function CleanPaths(arg1:Array<string>):Promise<Array<models.PathResult>>;
+
+export function ClearPerformanceData():Promise<void>;
+
+export function ClearSemanticIndex():Promise<void>;
+
 export function CloneRepository(arg1:string,arg2:string,arg3:string):Promise<void>;
 
+export function CloneRepositoryWithOptions(arg1:models.CloneOptions):Promise<void>;
+
 export function Commit(arg1:string):Promise<void>;
 
+export function CommitAllowEmpty(arg1:string):Promise<void>;
+
+export function CommitFixup(arg1:string):Promise<void>;
+
+export function CommitPaths(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function CompareWithBranch(arg1:string,arg2:string):Promise<string>;
+
+export function ConfirmProtectedPaths(arg1:Array<string>):Promise<void>;
+
+export function ContinueOperation():Promise<void>;
+
+export function CopyToClipboard(arg1:string):Promise<void>;
+
 export function CreateBranch(arg1:string,arg2:boolean):Promise<void>;
 
+export function CreateBranchFromIssue(arg1:models.ForgeProvider,arg2:number):Promise<string>;
+
 export function CreateCommand(arg1:string,arg2:string,arg3:string,arg4:string):Promise<models.Command>;
 
+export function CreateInitialCommit(arg1:string):Promise<void>;
+
 export function CreatePrompt(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<models.Prompt>;
 
+export function CreateSnapshot(arg1:string):Promise<models.Snapshot>;
+
 export function CreateTag(arg1:string,arg2:string,arg3:string):Promise<void>;
 
+export function DeleteBackupTarget(arg1:string):Promise<void>;
+
 export function DeleteBranch(arg1:string,arg2:boolean):Promise<void>;
 
 export function DeleteCommand(arg1:string):Promise<void>;
 
+export function DeleteCrashReport(arg1:string):Promise<void>;
+
+export function DeleteForgeConfig(arg1:string):Promise<void>;
+
 export function DeletePrompt(arg1:string):Promise<void>;
 
 export function DeleteRepository(arg1:string):Promise<void>;
 
+export function DeleteSnapshot(arg1:string):Promise<void>;
+
 export function DeleteTag(arg1:string):Promise<void>;
 
 export function DiffBranches(arg1:string,arg2:string):Promise<string>;
 
 export function DiscardChanges(arg1:string):Promise<void>;
 
+export function DiscardPaths(arg1:Array<string>):Promise<Array<models.PathResult>>;
+
+export function DownloadUpdate():Promise<models.UpdateInfo>;
+
+export function ExpandUntrackedDir(arg1:string,arg2:number):Promise<models.UntrackedListing>;
+
+export function ExportArchive(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function ExportCrashReport(arg1:string):Promise<string>;
+
+export function ExportSelectionPatch(arg1:Array<string>,arg2:boolean,arg3:string):Promise<string>;
+
+export function Fetch(arg1:string,arg2:boolean,arg3:boolean):Promise<models.FetchResult>;
+
+export function FetchAll(arg1:boolean,arg2:boolean):Promise<Array<models.FetchResult>>;
+
+export function FindCommitsTouchingString(arg1:string,arg2:boolean,arg3:string):Promise<Array<models.CommitPatch>>;
+
+export function FormatCommitReference(arg1:string,arg2:string):Promise<string>;
+
+export function FormatPatchSeries(arg1:string,arg2:boolean):Promise<Array<models.PatchFile>>;
+
+export function GenerateChangelog(arg1:string,arg2:string,arg3:boolean):Promise<models.Changelog>;
+
 export function GenerateCommitMessage():Promise<string>;
 
+export function GenerateHeuristicCommitMessage():Promise<string>;
+
+export function GeneratePullRequestDescription(arg1:string):Promise<models.PullRequestDescription>;
+
+export function GenerateRewordSuggestion(arg1:string):Promise<string>;
+
 export function GetAIConfig():Promise<models.AIConfig>;
 
+export function GetAIContextFilter():Promise<models.AIContextFilter>;
+
 export function GetAllRepositories():Promise<Array<models.Repository>>;
 
+export function GetAppVersion():Promise<string>;
+
+export function GetAuthorAvatars(arg1:Array<string>):Promise<Record<string, string>>;
+
+export function GetBackupTargets():Promise<Array<models.BackupTarget>>;
+
+export function GetBlame(arg1:string,arg2:string):Promise<Array<models.BlameLine>>;
+
 export function GetBranches():Promise<Array<models.Branch>>;
 
 export function GetCategories():Promise<Array<string>>;
 
+export function GetChangeDiff(arg1:models.FileChange,arg2:boolean):Promise<string>;
+
+export function GetChecksStatus(arg1:string):Promise<models.ChecksStatus>;
+
+export function GetCloneSettings():Promise<models.CloneSettings>;
+
 export function GetCommand(arg1:string):Promise<models.Command>;
 
+export function GetCommandHistory(arg1:string):Promise<Array<models.TemplateRevision>>;
+
 export function GetCommands():Promise<Array<models.Command>>;
 
 export function GetCommandsByCategory(arg1:string):Promise<Array<models.Command>>;
 
 export function GetCommitDetail(arg1:string):Promise<Record<string, any>>;
 
+export function GetCommitGenerator():Promise<string>;
+
+export function GetCommitGraph(arg1:number):Promise<Array<models.GraphCommit>>;
+
+export function GetCommitTrailers():Promise<Array<models.CommitTrailer>>;
+
+export function GetCrashReporting():Promise<boolean>;
+
 export function GetCurrentRepository():Promise<string>;
 
 export function GetDefaultPrompt():Promise<models.Prompt>;
 
 export function GetDiff(arg1:string,arg2:boolean):Promise<string>;
 
+export function GetDirtyPaths():Promise<Array<string>>;
+
+export function GetDiscardedPatch(arg1:string):Promise<string>;
+
+export function GetFSMonitorStatus():Promise<models.FSMonitorStatus>;
+
+export function GetFetchPolicy():Promise<models.FetchPolicy>;
+
+export function GetFileAtRevision(arg1:string,arg2:string):Promise<Array<number>>;
+
+export function GetFileDiffBetween(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function GetForgeConfigs():Promise<Array<models.ForgeConfig>>;
+
+export function GetGitExecutable():Promise<models.GitExecutable>;
+
+export function GetIssueBranchPattern():Promise<string>;
+
 export function GetLog(arg1:number):Promise<Array<models.CommitInfo>>;
 
+export function GetLogDirectory():Promise<string>;
+
+export function GetLogStream(arg1:models.LogOptions,arg2:number):Promise<string>;
+
+export function GetLogWithOptions(arg1:models.LogOptions):Promise<Array<models.CommitInfo>>;
+
+export function GetMaxOutput():Promise<number>;
+
+export function GetMessageStyle():Promise<models.MessageStyle>;
+
+export function GetMyReviewQueue():Promise<models.ReviewQueue>;
+
+export function GetOfflineMode():Promise<boolean>;
+
+export function GetOwnersForChanges():Promise<models.ChangeOwners>;
+
+export function GetPathOverride():Promise<string>;
+
+export function GetPendingOperations():Promise<Array<models.PendingOperation>>;
+
+export function GetPerformanceReport(arg1:number):Promise<models.PerformanceReport>;
+
 export function GetPrompt(arg1:string):Promise<models.Prompt>;
 
+export function GetPromptHistory(arg1:string):Promise<Array<models.TemplateRevision>>;
+
+export function GetPromptLibrary():Promise<Array<models.LibraryPrompt>>;
+
 export function GetPrompts():Promise<Array<models.Prompt>>;
 
+export function GetProtectedPaths():Promise<models.ProtectedPaths>;
+
+export function GetPullRequestComments():Promise<models.PullRequestComments>;
+
+export function GetRecentLogs(arg1:string,arg2:number):Promise<Array<models.LogEntry>>;
+
 export function GetRecentRepositories():Promise<Array<string>>;
 
 export function GetRemoteNames():Promise<Array<string>>;
@@ -75,56 +249,224 @@ export function GetRemotes():Promise<Array<models.Remote>>;
 
 export function GetRepository(arg1:string):Promise<models.Repository>;
 
-export function GetRepositoryInfo():Promise<Record<string, any>>;
+export function GetRepositoryInfo():Promise<models.RepositoryInfo>;
+
+export function GetRewrittenCommits(arg1:string,arg2:string):Promise<models.RewrittenCommits>;
+
+export function GetSMTPConfig():Promise<models.SMTPConfig>;
+
+export function GetSemanticSearchEnabled():Promise<boolean>;
+
+export function GetSetupCommands():Promise<Array<string>>;
+
+export function GetShareStatus():Promise<models.ShareInfo>;
+
+export function GetSnapshotDiff(arg1:string):Promise<string>;
 
 export function GetStatus():Promise<models.GitStatus>;
 
-export function GetTags():Promise<Array<git.Tag>>;
+export function GetStatusWithOptions(arg1:models.StatusOptions):Promise<models.GitStatus>;
+
+export function GetStructuredDiff(arg1:string,arg2:boolean):Promise<models.StructuredDiff>;
+
+export function GetTags():Promise<Array<models.Tag>>;
+
+export function GetTeamConfig():Promise<models.TeamConfig>;
+
+export function GetTryState():Promise<models.TryState>;
+
+export function GetUpdateChannel():Promise<models.UpdateChannel>;
+
+export function GetWebURLs(arg1:string,arg2:string,arg3:string,arg4:number):Promise<Array<models.WebURL>>;
+
+export function HandleDroppedPath(arg1:string):Promise<models.DroppedPathResult>;
+
+export function InitRepository(arg1:string):Promise<void>;
+
+export function InstallLibraryPrompt(arg1:string):Promise<models.Prompt>;
+
+export function InstallUpdateOnRestart():Promise<void>;
 
 export function IsValidGitRepository(arg1:string):Promise<boolean>;
 
+export function KeepTry():Promise<void>;
+
+export function ListActions():Promise<Array<models.ActionInfo>>;
+
+export function ListCrashReports():Promise<Array<models.CrashReport>>;
+
+export function ListDiscarded():Promise<Array<models.DiscardedEntry>>;
+
+export function ListIssues(arg1:models.ForgeProvider,arg2:models.IssueFilter):Promise<Array<models.Issue>>;
+
+export function ListRemoteRepositories(arg1:models.ForgeProvider,arg2:string):Promise<Array<models.RemoteRepository>>;
+
+export function ListSnapshots():Promise<Array<models.Snapshot>>;
+
 export function MergeBranch(arg1:string,arg2:boolean):Promise<void>;
 
 export function OpenFileInEditor(arg1:string):Promise<void>;
 
+export function OpenRepository(arg1:string):Promise<models.RepositoryOverview>;
+
 export function OpenRepositoryInTerminal():Promise<void>;
 
+export function PredictMergeConflicts(arg1:string,arg2:string):Promise<models.MergePrediction>;
+
+export function PreflightPush(arg1:string,arg2:string):Promise<models.PushPreflight>;
+
+export function PreviewCommit(arg1:string):Promise<models.CommitPreview>;
+
+export function PreviewDeleteBranch(arg1:string):Promise<models.DeleteBranchPreview>;
+
+export function PreviewRebaseOnto(arg1:string,arg2:string,arg3:string):Promise<models.RebaseOntoPreview>;
+
 export function Pull(arg1:string,arg2:string):Promise<void>;
 
-export function Push(arg1:string):Promise<void>;
+export function Push(arg1:models.PushOptions):Promise<void>;
+
+export function RebaseOnto(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function RefreshSemanticIndex():Promise<number>;
+
+export function RegenerateCommitMessage(arg1:string,arg2:string):Promise<string>;
 
 export function RemoveRecentRepository(arg1:string):Promise<void>;
 
 export function RemoveRemote(arg1:string):Promise<void>;
 
+export function ReorderCommits(arg1:string,arg2:Array<string>):Promise<void>;
+
 export function Reset(arg1:git.ResetType,arg2:string):Promise<void>;
 
+export function ResolveLocation(arg1:string):Promise<models.Location>;
+
+export function ResolveRef(arg1:string):Promise<string>;
+
+export function RestoreDiscarded(arg1:string):Promise<void>;
+
+export function RestoreSnapshot(arg1:string):Promise<void>;
+
+export function ResumeOrCleanupPendingOperations():Promise<Array<models.PendingOperationResult>>;
+
 export function Revert(arg1:string,arg2:boolean):Promise<void>;
 
+export function RevertTry():Promise<void>;
+
+export function RewordCommit(arg1:string,arg2:string):Promise<void>;
+
+export function RollbackCommand(arg1:string,arg2:string):Promise<models.Command>;
+
+export function RollbackPrompt(arg1:string,arg2:string):Promise<models.Prompt>;
+
+export function RunBackup(arg1:string):Promise<models.BackupTarget>;
+
+export function RunMaintenance(arg1:string):Promise<void>;
+
+export function RunSetupCommand(arg1:string):Promise<string>;
+
+export function SaveBackupTarget(arg1:models.BackupTarget):Promise<models.BackupTarget>;
+
 export function SearchRepositories(arg1:string):Promise<Array<models.Repository>>;
 
 export function SelectDirectory():Promise<string>;
 
 export function SelectRepository(arg1:string):Promise<void>;
 
+export function SemanticSearchCommits(arg1:string,arg2:number):Promise<Array<models.SemanticMatch>>;
+
+export function SendEmailPatch(arg1:models.SendPatchOptions):Promise<void>;
+
 export function SetAIConfig(arg1:models.AIConfig):Promise<void>;
 
+export function SetAIContextFilter(arg1:models.AIContextFilter):Promise<void>;
+
+export function SetCloneSettings(arg1:models.CloneSettings):Promise<void>;
+
+export function SetCommitGenerator(arg1:string):Promise<void>;
+
+export function SetCommitTrailers(arg1:Array<models.CommitTrailer>):Promise<void>;
+
+export function SetCrashReporting(arg1:boolean):Promise<void>;
+
 export function SetDefaultPrompt(arg1:string):Promise<void>;
 
+export function SetFSMonitor(arg1:boolean):Promise<void>;
+
+export function SetFetchPolicy(arg1:models.FetchPolicy):Promise<void>;
+
+export function SetForgeConfig(arg1:models.ForgeConfig):Promise<models.ForgeConfig>;
+
+export function SetGitExecutable(arg1:models.GitExecutable):Promise<string>;
+
+export function SetIssueBranchPattern(arg1:string):Promise<void>;
+
+export function SetMaxOutput(arg1:number):Promise<void>;
+
+export function SetMessageStyle(arg1:models.MessageStyle):Promise<void>;
+
+export function SetOfflineMode(arg1:boolean):Promise<void>;
+
+export function SetPathOverride(arg1:string):Promise<void>;
+
+export function SetPerformanceTracing(arg1:boolean):Promise<void>;
+
+export function SetProtectedPaths(arg1:models.ProtectedPaths):Promise<void>;
+
+export function SetRepositoryDefaults(arg1:string,arg2:models.RepositoryDefaults):Promise<void>;
+
+export function SetRepositoryScope(arg1:string,arg2:string):Promise<void>;
+
+export function SetSMTPConfig(arg1:models.SMTPConfig):Promise<void>;
+
+export function SetSemanticSearchEnabled(arg1:boolean):Promise<void>;
+
+export function SetUpdateChannel(arg1:models.UpdateChannel):Promise<void>;
+
+export function ShareRepository(arg1:number,arg2:boolean):Promise<models.ShareInfo>;
+
+export function SkipOperationStep():Promise<void>;
+
 export function StageAll():Promise<void>;
 
+export function StageChanges(arg1:Array<models.FileChange>):Promise<void>;
+
 export function StageFiles(arg1:Array<string>):Promise<void>;
 
+export function StagePaths(arg1:Array<string>):Promise<Array<models.PathResult>>;
+
+export function StopSharing():Promise<void>;
+
+export function SubmitCrashReport(arg1:string):Promise<void>;
+
+export function SuggestClonePath(arg1:string):Promise<string>;
+
+export function SuggestTestsForChanges():Promise<models.TestImpact>;
+
+export function TakePendingCloneLink():Promise<models.CloneOptions>;
+
 export function TestAIConnection(arg1:models.AIConfig):Promise<void>;
 
+export function TryChanges(arg1:string):Promise<models.TryState>;
+
 export function UnstageAll():Promise<void>;
 
+export function UnstageChanges(arg1:Array<models.FileChange>):Promise<void>;
+
 export function UnstageFiles(arg1:Array<string>):Promise<void>;
 
+export function UnstagePaths(arg1:Array<string>):Promise<Array<models.PathResult>>;
+
 export function UpdateCommand(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<models.Command>;
 
+export function UpdateLibraryPrompt(arg1:string):Promise<models.Prompt>;
+
 export function UpdatePrompt(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<models.Prompt>;
 
 export function UpdateRepository(arg1:string,arg2:string,arg3:string):Promise<models.Repository>;
 
 export function UpdateRepositoryAlias(arg1:string,arg2:string):Promise<void>;
+
+export function ValidateForgeAccount(arg1:string):Promise<models.TokenInfo>;
+
+export function WhenWasLineChanged(arg1:string,arg2:models.LineRange,arg3:number):Promise<Array<models.CommitPatch>>;
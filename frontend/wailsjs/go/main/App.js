@@ -2,46 +2,170 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AbortOperation() {
+  return window['go']['main']['App']['AbortOperation']();
+}
+
+export function AcknowledgeRewrite(arg1) {
+  return window['go']['main']['App']['AcknowledgeRewrite'](arg1);
+}
+
 export function AddRemote(arg1, arg2) {
   return window['go']['main']['App']['AddRemote'](arg1, arg2);
 }
 
+export function AddRemoteVerified(arg1) {
+  return window['go']['main']['App']['AddRemoteVerified'](arg1);
+}
+
 export function AddRepository(arg1, arg2, arg3) {
   return window['go']['main']['App']['AddRepository'](arg1, arg2, arg3);
 }
 
+export function AnalyzeCommitQuality(arg1) {
+  return window['go']['main']['App']['AnalyzeCommitQuality'](arg1);
+}
+
+export function ApplyAttributesFix(arg1) {
+  return window['go']['main']['App']['ApplyAttributesFix'](arg1);
+}
+
+export function ApplySuggestedPatch(arg1, arg2) {
+  return window['go']['main']['App']['ApplySuggestedPatch'](arg1, arg2);
+}
+
+export function AutosquashRebase(arg1) {
+  return window['go']['main']['App']['AutosquashRebase'](arg1);
+}
+
+export function CancelGeneration() {
+  return window['go']['main']['App']['CancelGeneration']();
+}
+
+export function CancelLogStream(arg1) {
+  return window['go']['main']['App']['CancelLogStream'](arg1);
+}
+
+export function CheckForUpdates() {
+  return window['go']['main']['App']['CheckForUpdates']();
+}
+
+export function CheckLineEndings() {
+  return window['go']['main']['App']['CheckLineEndings']();
+}
+
+export function CheckRemoteStaleness() {
+  return window['go']['main']['App']['CheckRemoteStaleness']();
+}
+
+export function CheckRepositoryHealth() {
+  return window['go']['main']['App']['CheckRepositoryHealth']();
+}
+
 export function CheckoutBranch(arg1) {
   return window['go']['main']['App']['CheckoutBranch'](arg1);
 }
 
+export function CheckoutBranchWithStrategy(arg1, arg2) {
+  return window['go']['main']['App']['CheckoutBranchWithStrategy'](arg1, arg2);
+}
+
+export function CheckoutPullRequest(arg1) {
+  return window['go']['main']['App']['CheckoutPullRequest'](arg1);
+}
+
+export function CheckoutReviewRequest(arg1) {
+  return window['go']['main']['App']['CheckoutReviewRequest'](arg1);
+}
+
 export function CheckoutTag(arg1) {
   return window['go']['main']['App']['CheckoutTag'](arg1);
 }
 
+export function CleanPaths(arg1) {
+  return window['go']['main']['App']['CleanPaths'](arg1);
+}
+
+export function ClearPerformanceData() {
+  return window['go']['main']['App']['ClearPerformanceData']();
+}
+
+export function ClearSemanticIndex() {
+  return window['go']['main']['App']['ClearSemanticIndex']();
+}
+
 export function CloneRepository(arg1, arg2, arg3) {
   return window['go']['main']['App']['CloneRepository'](arg1, arg2, arg3);
 }
 
+export function CloneRepositoryWithOptions(arg1) {
+  return window['go']['main']['App']['CloneRepositoryWithOptions'](arg1);
+}
+
 export function Commit(arg1) {
   return window['go']['main']['App']['Commit'](arg1);
 }
 
+export function CommitAllowEmpty(arg1) {
+  return window['go']['main']['App']['CommitAllowEmpty'](arg1);
+}
+
+export function CommitFixup(arg1) {
+  return window['go']['main']['App']['CommitFixup'](arg1);
+}
+
+export function CommitPaths(arg1, arg2) {
+  return window['go']['main']['App']['CommitPaths'](arg1, arg2);
+}
+
+export function CompareWithBranch(arg1, arg2) {
+  return window['go']['main']['App']['CompareWithBranch'](arg1, arg2);
+}
+
+export function ConfirmProtectedPaths(arg1) {
+  return window['go']['main']['App']['ConfirmProtectedPaths'](arg1);
+}
+
+export function ContinueOperation() {
+  return window['go']['main']['App']['ContinueOperation']();
+}
+
+export function CopyToClipboard(arg1) {
+  return window['go']['main']['App']['CopyToClipboard'](arg1);
+}
+
 export function CreateBranch(arg1, arg2) {
   return window['go']['main']['App']['CreateBranch'](arg1, arg2);
 }
 
+export function CreateBranchFromIssue(arg1, arg2) {
+  return window['go']['main']['App']['CreateBranchFromIssue'](arg1, arg2);
+}
+
 export function CreateCommand(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['CreateCommand'](arg1, arg2, arg3, arg4);
 }
 
+export function CreateInitialCommit(arg1) {
+  return window['go']['main']['App']['CreateInitialCommit'](arg1);
+}
+
 export function CreatePrompt(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['CreatePrompt'](arg1, arg2, arg3, arg4);
 }
 
+export function CreateSnapshot(arg1) {
+  return window['go']['main']['App']['CreateSnapshot'](arg1);
+}
+
 export function CreateTag(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreateTag'](arg1, arg2, arg3);
 }
 
+export function DeleteBackupTarget(arg1) {
+  return window['go']['main']['App']['DeleteBackupTarget'](arg1);
+}
+
 export function DeleteBranch(arg1, arg2) {
   return window['go']['main']['App']['DeleteBranch'](arg1, arg2);
 }
@@ -50,6 +174,14 @@ export function DeleteCommand(arg1) {
   return window['go']['main']['App']['DeleteCommand'](arg1);
 }
 
+export function DeleteCrashReport(arg1) {
+  return window['go']['main']['App']['DeleteCrashReport'](arg1);
+}
+
+export function DeleteForgeConfig(arg1) {
+  return window['go']['main']['App']['DeleteForgeConfig'](arg1);
+}
+
 export function DeletePrompt(arg1) {
   return window['go']['main']['App']['DeletePrompt'](arg1);
 }
@@ -58,6 +190,10 @@ export function DeleteRepository(arg1) {
   return window['go']['main']['App']['DeleteRepository'](arg1);
 }
 
+export function DeleteSnapshot(arg1) {
+  return window['go']['main']['App']['DeleteSnapshot'](arg1);
+}
+
 export function DeleteTag(arg1) {
   return window['go']['main']['App']['DeleteTag'](arg1);
 }
@@ -70,18 +206,98 @@ export function DiscardChanges(arg1) {
   return window['go']['main']['App']['DiscardChanges'](arg1);
 }
 
+export function DiscardPaths(arg1) {
+  return window['go']['main']['App']['DiscardPaths'](arg1);
+}
+
+export function DownloadUpdate() {
+  return window['go']['main']['App']['DownloadUpdate']();
+}
+
+export function ExpandUntrackedDir(arg1, arg2) {
+  return window['go']['main']['App']['ExpandUntrackedDir'](arg1, arg2);
+}
+
+export function ExportArchive(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportArchive'](arg1, arg2, arg3);
+}
+
+export function ExportCrashReport(arg1) {
+  return window['go']['main']['App']['ExportCrashReport'](arg1);
+}
+
+export function ExportSelectionPatch(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportSelectionPatch'](arg1, arg2, arg3);
+}
+
+export function Fetch(arg1, arg2, arg3) {
+  return window['go']['main']['App']['Fetch'](arg1, arg2, arg3);
+}
+
+export function FetchAll(arg1, arg2) {
+  return window['go']['main']['App']['FetchAll'](arg1, arg2);
+}
+
+export function FindCommitsTouchingString(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FindCommitsTouchingString'](arg1, arg2, arg3);
+}
+
+export function FormatCommitReference(arg1, arg2) {
+  return window['go']['main']['App']['FormatCommitReference'](arg1, arg2);
+}
+
+export function FormatPatchSeries(arg1, arg2) {
+  return window['go']['main']['App']['FormatPatchSeries'](arg1, arg2);
+}
+
+export function GenerateChangelog(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GenerateChangelog'](arg1, arg2, arg3);
+}
+
 export function GenerateCommitMessage() {
   return window['go']['main']['App']['GenerateCommitMessage']();
 }
 
+export function GenerateHeuristicCommitMessage() {
+  return window['go']['main']['App']['GenerateHeuristicCommitMessage']();
+}
+
+export function GeneratePullRequestDescription(arg1) {
+  return window['go']['main']['App']['GeneratePullRequestDescription'](arg1);
+}
+
+export function GenerateRewordSuggestion(arg1) {
+  return window['go']['main']['App']['GenerateRewordSuggestion'](arg1);
+}
+
 export function GetAIConfig() {
   return window['go']['main']['App']['GetAIConfig']();
 }
 
+export function GetAIContextFilter() {
+  return window['go']['main']['App']['GetAIContextFilter']();
+}
+
 export function GetAllRepositories() {
   return window['go']['main']['App']['GetAllRepositories']();
 }
 
+export function GetAppVersion() {
+  return window['go']['main']['App']['GetAppVersion']();
+}
+
+export function GetAuthorAvatars(arg1) {
+  return window['go']['main']['App']['GetAuthorAvatars'](arg1);
+}
+
+export function GetBackupTargets() {
+  return window['go']['main']['App']['GetBackupTargets']();
+}
+
+export function GetBlame(arg1, arg2) {
+  return window['go']['main']['App']['GetBlame'](arg1, arg2);
+}
+
 export function GetBranches() {
   return window['go']['main']['App']['GetBranches']();
 }
@@ -90,10 +306,26 @@ export function GetCategories() {
   return window['go']['main']['App']['GetCategories']();
 }
 
+export function GetChangeDiff(arg1, arg2) {
+  return window['go']['main']['App']['GetChangeDiff'](arg1, arg2);
+}
+
+export function GetChecksStatus(arg1) {
+  return window['go']['main']['App']['GetChecksStatus'](arg1);
+}
+
+export function GetCloneSettings() {
+  return window['go']['main']['App']['GetCloneSettings']();
+}
+
 export function GetCommand(arg1) {
   return window['go']['main']['App']['GetCommand'](arg1);
 }
 
+export function GetCommandHistory(arg1) {
+  return window['go']['main']['App']['GetCommandHistory'](arg1);
+}
+
 export function GetCommands() {
   return window['go']['main']['App']['GetCommands']();
 }
@@ -106,6 +338,22 @@ export function GetCommitDetail(arg1) {
   return window['go']['main']['App']['GetCommitDetail'](arg1);
 }
 
+export function GetCommitGenerator() {
+  return window['go']['main']['App']['GetCommitGenerator']();
+}
+
+export function GetCommitGraph(arg1) {
+  return window['go']['main']['App']['GetCommitGraph'](arg1);
+}
+
+export function GetCommitTrailers() {
+  return window['go']['main']['App']['GetCommitTrailers']();
+}
+
+export function GetCrashReporting() {
+  return window['go']['main']['App']['GetCrashReporting']();
+}
+
 export function GetCurrentRepository() {
   return window['go']['main']['App']['GetCurrentRepository']();
 }
@@ -118,18 +366,118 @@ export function GetDiff(arg1, arg2) {
   return window['go']['main']['App']['GetDiff'](arg1, arg2);
 }
 
+export function GetDirtyPaths() {
+  return window['go']['main']['App']['GetDirtyPaths']();
+}
+
+export function GetDiscardedPatch(arg1) {
+  return window['go']['main']['App']['GetDiscardedPatch'](arg1);
+}
+
+export function GetFSMonitorStatus() {
+  return window['go']['main']['App']['GetFSMonitorStatus']();
+}
+
+export function GetFetchPolicy() {
+  return window['go']['main']['App']['GetFetchPolicy']();
+}
+
+export function GetFileAtRevision(arg1, arg2) {
+  return window['go']['main']['App']['GetFileAtRevision'](arg1, arg2);
+}
+
+export function GetFileDiffBetween(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetFileDiffBetween'](arg1, arg2, arg3);
+}
+
+export function GetForgeConfigs() {
+  return window['go']['main']['App']['GetForgeConfigs']();
+}
+
+export function GetGitExecutable() {
+  return window['go']['main']['App']['GetGitExecutable']();
+}
+
+export function GetIssueBranchPattern() {
+  return window['go']['main']['App']['GetIssueBranchPattern']();
+}
+
 export function GetLog(arg1) {
   return window['go']['main']['App']['GetLog'](arg1);
 }
 
+export function GetLogDirectory() {
+  return window['go']['main']['App']['GetLogDirectory']();
+}
+
+export function GetLogStream(arg1, arg2) {
+  return window['go']['main']['App']['GetLogStream'](arg1, arg2);
+}
+
+export function GetLogWithOptions(arg1) {
+  return window['go']['main']['App']['GetLogWithOptions'](arg1);
+}
+
+export function GetMaxOutput() {
+  return window['go']['main']['App']['GetMaxOutput']();
+}
+
+export function GetMessageStyle() {
+  return window['go']['main']['App']['GetMessageStyle']();
+}
+
+export function GetMyReviewQueue() {
+  return window['go']['main']['App']['GetMyReviewQueue']();
+}
+
+export function GetOfflineMode() {
+  return window['go']['main']['App']['GetOfflineMode']();
+}
+
+export function GetOwnersForChanges() {
+  return window['go']['main']['App']['GetOwnersForChanges']();
+}
+
+export function GetPathOverride() {
+  return window['go']['main']['App']['GetPathOverride']();
+}
+
+export function GetPendingOperations() {
+  return window['go']['main']['App']['GetPendingOperations']();
+}
+
+export function GetPerformanceReport(arg1) {
+  return window['go']['main']['App']['GetPerformanceReport'](arg1);
+}
+
 export function GetPrompt(arg1) {
   return window['go']['main']['App']['GetPrompt'](arg1);
 }
 
+export function GetPromptHistory(arg1) {
+  return window['go']['main']['App']['GetPromptHistory'](arg1);
+}
+
+export function GetPromptLibrary() {
+  return window['go']['main']['App']['GetPromptLibrary']();
+}
+
 export function GetPrompts() {
   return window['go']['main']['App']['GetPrompts']();
 }
 
+export function GetProtectedPaths() {
+  return window['go']['main']['App']['GetProtectedPaths']();
+}
+
+export function GetPullRequestComments() {
+  return window['go']['main']['App']['GetPullRequestComments']();
+}
+
+export function GetRecentLogs(arg1, arg2) {
+  return window['go']['main']['App']['GetRecentLogs'](arg1, arg2);
+}
+
 export function GetRecentRepositories() {
   return window['go']['main']['App']['GetRecentRepositories']();
 }
@@ -150,18 +498,110 @@ export function GetRepositoryInfo() {
   return window['go']['main']['App']['GetRepositoryInfo']();
 }
 
+export function GetRewrittenCommits(arg1, arg2) {
+  return window['go']['main']['App']['GetRewrittenCommits'](arg1, arg2);
+}
+
+export function GetSMTPConfig() {
+  return window['go']['main']['App']['GetSMTPConfig']();
+}
+
+export function GetSemanticSearchEnabled() {
+  return window['go']['main']['App']['GetSemanticSearchEnabled']();
+}
+
+export function GetSetupCommands() {
+  return window['go']['main']['App']['GetSetupCommands']();
+}
+
+export function GetShareStatus() {
+  return window['go']['main']['App']['GetShareStatus']();
+}
+
+export function GetSnapshotDiff(arg1) {
+  return window['go']['main']['App']['GetSnapshotDiff'](arg1);
+}
+
 export function GetStatus() {
   return window['go']['main']['App']['GetStatus']();
 }
 
+export function GetStatusWithOptions(arg1) {
+  return window['go']['main']['App']['GetStatusWithOptions'](arg1);
+}
+
+export function GetStructuredDiff(arg1, arg2) {
+  return window['go']['main']['App']['GetStructuredDiff'](arg1, arg2);
+}
+
 export function GetTags() {
   return window['go']['main']['App']['GetTags']();
 }
 
+export function GetTeamConfig() {
+  return window['go']['main']['App']['GetTeamConfig']();
+}
+
+export function GetTryState() {
+  return window['go']['main']['App']['GetTryState']();
+}
+
+export function GetUpdateChannel() {
+  return window['go']['main']['App']['GetUpdateChannel']();
+}
+
+export function GetWebURLs(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetWebURLs'](arg1, arg2, arg3, arg4);
+}
+
+export function HandleDroppedPath(arg1) {
+  return window['go']['main']['App']['HandleDroppedPath'](arg1);
+}
+
+export function InitRepository(arg1) {
+  return window['go']['main']['App']['InitRepository'](arg1);
+}
+
+export function InstallLibraryPrompt(arg1) {
+  return window['go']['main']['App']['InstallLibraryPrompt'](arg1);
+}
+
+export function InstallUpdateOnRestart() {
+  return window['go']['main']['App']['InstallUpdateOnRestart']();
+}
+
 export function IsValidGitRepository(arg1) {
   return window['go']['main']['App']['IsValidGitRepository'](arg1);
 }
 
+export function KeepTry() {
+  return window['go']['main']['App']['KeepTry']();
+}
+
+export function ListActions() {
+  return window['go']['main']['App']['ListActions']();
+}
+
+export function ListCrashReports() {
+  return window['go']['main']['App']['ListCrashReports']();
+}
+
+export function ListDiscarded() {
+  return window['go']['main']['App']['ListDiscarded']();
+}
+
+export function ListIssues(arg1, arg2) {
+  return window['go']['main']['App']['ListIssues'](arg1, arg2);
+}
+
+export function ListRemoteRepositories(arg1, arg2) {
+  return window['go']['main']['App']['ListRemoteRepositories'](arg1, arg2);
+}
+
+export function ListSnapshots() {
+  return window['go']['main']['App']['ListSnapshots']();
+}
+
 export function MergeBranch(arg1, arg2) {
   return window['go']['main']['App']['MergeBranch'](arg1, arg2);
 }
@@ -170,10 +610,34 @@ export function OpenFileInEditor(arg1) {
   return window['go']['main']['App']['OpenFileInEditor'](arg1);
 }
 
+export function OpenRepository(arg1) {
+  return window['go']['main']['App']['OpenRepository'](arg1);
+}
+
 export function OpenRepositoryInTerminal() {
   return window['go']['main']['App']['OpenRepositoryInTerminal']();
 }
 
+export function PredictMergeConflicts(arg1, arg2) {
+  return window['go']['main']['App']['PredictMergeConflicts'](arg1, arg2);
+}
+
+export function PreflightPush(arg1, arg2) {
+  return window['go']['main']['App']['PreflightPush'](arg1, arg2);
+}
+
+export function PreviewCommit(arg1) {
+  return window['go']['main']['App']['PreviewCommit'](arg1);
+}
+
+export function PreviewDeleteBranch(arg1) {
+  return window['go']['main']['App']['PreviewDeleteBranch'](arg1);
+}
+
+export function PreviewRebaseOnto(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewRebaseOnto'](arg1, arg2, arg3);
+}
+
 export function Pull(arg1, arg2) {
   return window['go']['main']['App']['Pull'](arg1, arg2);
 }
@@ -182,6 +646,18 @@ export function Push(arg1) {
   return window['go']['main']['App']['Push'](arg1);
 }
 
+export function RebaseOnto(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RebaseOnto'](arg1, arg2, arg3);
+}
+
+export function RefreshSemanticIndex() {
+  return window['go']['main']['App']['RefreshSemanticIndex']();
+}
+
+export function RegenerateCommitMessage(arg1, arg2) {
+  return window['go']['main']['App']['RegenerateCommitMessage'](arg1, arg2);
+}
+
 export function RemoveRecentRepository(arg1) {
   return window['go']['main']['App']['RemoveRecentRepository'](arg1);
 }
@@ -190,14 +666,70 @@ export function RemoveRemote(arg1) {
   return window['go']['main']['App']['RemoveRemote'](arg1);
 }
 
+export function ReorderCommits(arg1, arg2) {
+  return window['go']['main']['App']['ReorderCommits'](arg1, arg2);
+}
+
 export function Reset(arg1, arg2) {
   return window['go']['main']['App']['Reset'](arg1, arg2);
 }
 
+export function ResolveLocation(arg1) {
+  return window['go']['main']['App']['ResolveLocation'](arg1);
+}
+
+export function ResolveRef(arg1) {
+  return window['go']['main']['App']['ResolveRef'](arg1);
+}
+
+export function RestoreDiscarded(arg1) {
+  return window['go']['main']['App']['RestoreDiscarded'](arg1);
+}
+
+export function RestoreSnapshot(arg1) {
+  return window['go']['main']['App']['RestoreSnapshot'](arg1);
+}
+
+export function ResumeOrCleanupPendingOperations() {
+  return window['go']['main']['App']['ResumeOrCleanupPendingOperations']();
+}
+
 export function Revert(arg1, arg2) {
   return window['go']['main']['App']['Revert'](arg1, arg2);
 }
 
+export function RevertTry() {
+  return window['go']['main']['App']['RevertTry']();
+}
+
+export function RewordCommit(arg1, arg2) {
+  return window['go']['main']['App']['RewordCommit'](arg1, arg2);
+}
+
+export function RollbackCommand(arg1, arg2) {
+  return window['go']['main']['App']['RollbackCommand'](arg1, arg2);
+}
+
+export function RollbackPrompt(arg1, arg2) {
+  return window['go']['main']['App']['RollbackPrompt'](arg1, arg2);
+}
+
+export function RunBackup(arg1) {
+  return window['go']['main']['App']['RunBackup'](arg1);
+}
+
+export function RunMaintenance(arg1) {
+  return window['go']['main']['App']['RunMaintenance'](arg1);
+}
+
+export function RunSetupCommand(arg1) {
+  return window['go']['main']['App']['RunSetupCommand'](arg1);
+}
+
+export function SaveBackupTarget(arg1) {
+  return window['go']['main']['App']['SaveBackupTarget'](arg1);
+}
+
 export function SearchRepositories(arg1) {
   return window['go']['main']['App']['SearchRepositories'](arg1);
 }
@@ -210,38 +742,182 @@ export function SelectRepository(arg1) {
   return window['go']['main']['App']['SelectRepository'](arg1);
 }
 
+export function SemanticSearchCommits(arg1, arg2) {
+  return window['go']['main']['App']['SemanticSearchCommits'](arg1, arg2);
+}
+
+export function SendEmailPatch(arg1) {
+  return window['go']['main']['App']['SendEmailPatch'](arg1);
+}
+
 export function SetAIConfig(arg1) {
   return window['go']['main']['App']['SetAIConfig'](arg1);
 }
 
+export function SetAIContextFilter(arg1) {
+  return window['go']['main']['App']['SetAIContextFilter'](arg1);
+}
+
+export function SetCloneSettings(arg1) {
+  return window['go']['main']['App']['SetCloneSettings'](arg1);
+}
+
+export function SetCommitGenerator(arg1) {
+  return window['go']['main']['App']['SetCommitGenerator'](arg1);
+}
+
+export function SetCommitTrailers(arg1) {
+  return window['go']['main']['App']['SetCommitTrailers'](arg1);
+}
+
+export function SetCrashReporting(arg1) {
+  return window['go']['main']['App']['SetCrashReporting'](arg1);
+}
+
 export function SetDefaultPrompt(arg1) {
   return window['go']['main']['App']['SetDefaultPrompt'](arg1);
 }
 
+export function SetFSMonitor(arg1) {
+  return window['go']['main']['App']['SetFSMonitor'](arg1);
+}
+
+export function SetFetchPolicy(arg1) {
+  return window['go']['main']['App']['SetFetchPolicy'](arg1);
+}
+
+export function SetForgeConfig(arg1) {
+  return window['go']['main']['App']['SetForgeConfig'](arg1);
+}
+
+export function SetGitExecutable(arg1) {
+  return window['go']['main']['App']['SetGitExecutable'](arg1);
+}
+
+export function SetIssueBranchPattern(arg1) {
+  return window['go']['main']['App']['SetIssueBranchPattern'](arg1);
+}
+
+export function SetMaxOutput(arg1) {
+  return window['go']['main']['App']['SetMaxOutput'](arg1);
+}
+
+export function SetMessageStyle(arg1) {
+  return window['go']['main']['App']['SetMessageStyle'](arg1);
+}
+
+export function SetOfflineMode(arg1) {
+  return window['go']['main']['App']['SetOfflineMode'](arg1);
+}
+
+export function SetPathOverride(arg1) {
+  return window['go']['main']['App']['SetPathOverride'](arg1);
+}
+
+export function SetPerformanceTracing(arg1) {
+  return window['go']['main']['App']['SetPerformanceTracing'](arg1);
+}
+
+export function SetProtectedPaths(arg1) {
+  return window['go']['main']['App']['SetProtectedPaths'](arg1);
+}
+
+export function SetRepositoryDefaults(arg1, arg2) {
+  return window['go']['main']['App']['SetRepositoryDefaults'](arg1, arg2);
+}
+
+export function SetRepositoryScope(arg1, arg2) {
+  return window['go']['main']['App']['SetRepositoryScope'](arg1, arg2);
+}
+
+export function SetSMTPConfig(arg1) {
+  return window['go']['main']['App']['SetSMTPConfig'](arg1);
+}
+
+export function SetSemanticSearchEnabled(arg1) {
+  return window['go']['main']['App']['SetSemanticSearchEnabled'](arg1);
+}
+
+export function SetUpdateChannel(arg1) {
+  return window['go']['main']['App']['SetUpdateChannel'](arg1);
+}
+
+export function ShareRepository(arg1, arg2) {
+  return window['go']['main']['App']['ShareRepository'](arg1, arg2);
+}
+
+export function SkipOperationStep() {
+  return window['go']['main']['App']['SkipOperationStep']();
+}
+
 export function StageAll() {
   return window['go']['main']['App']['StageAll']();
 }
 
+export function StageChanges(arg1) {
+  return window['go']['main']['App']['StageChanges'](arg1);
+}
+
 export function StageFiles(arg1) {
   return window['go']['main']['App']['StageFiles'](arg1);
 }
 
+export function StagePaths(arg1) {
+  return window['go']['main']['App']['StagePaths'](arg1);
+}
+
+export function StopSharing() {
+  return window['go']['main']['App']['StopSharing']();
+}
+
+export function SubmitCrashReport(arg1) {
+  return window['go']['main']['App']['SubmitCrashReport'](arg1);
+}
+
+export function SuggestClonePath(arg1) {
+  return window['go']['main']['App']['SuggestClonePath'](arg1);
+}
+
+export function SuggestTestsForChanges() {
+  return window['go']['main']['App']['SuggestTestsForChanges']();
+}
+
+export function TakePendingCloneLink() {
+  return window['go']['main']['App']['TakePendingCloneLink']();
+}
+
 export function TestAIConnection(arg1) {
   return window['go']['main']['App']['TestAIConnection'](arg1);
 }
 
+export function TryChanges(arg1) {
+  return window['go']['main']['App']['TryChanges'](arg1);
+}
+
 export function UnstageAll() {
   return window['go']['main']['App']['UnstageAll']();
 }
 
+export function UnstageChanges(arg1) {
+  return window['go']['main']['App']['UnstageChanges'](arg1);
+}
+
 export function UnstageFiles(arg1) {
   return window['go']['main']['App']['UnstageFiles'](arg1);
 }
 
+export function UnstagePaths(arg1) {
+  return window['go']['main']['App']['UnstagePaths'](arg1);
+}
+
 export function UpdateCommand(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['UpdateCommand'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function UpdateLibraryPrompt(arg1) {
+  return window['go']['main']['App']['UpdateLibraryPrompt'](arg1);
+}
+
 export function UpdatePrompt(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['UpdatePrompt'](arg1, arg2, arg3, arg4, arg5);
 }
@@ -253,3 +929,11 @@ export function UpdateRepository(arg1, arg2, arg3) {
 export function UpdateRepositoryAlias(arg1, arg2) {
   return window['go']['main']['App']['UpdateRepositoryAlias'](arg1, arg2);
 }
+
+export function ValidateForgeAccount(arg1) {
+  return window['go']['main']['App']['ValidateForgeAccount'](arg1);
+}
+
+export function WhenWasLineChanged(arg1, arg2, arg3) {
+  return window['go']['main']['App']['WhenWasLineChanged'](arg1, arg2, arg3);
+}
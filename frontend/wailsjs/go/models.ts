@@ -1,26 +1,3 @@
-export namespace git {
-	
-	export class Tag {
-	    name: string;
-	    commitHash: string;
-	    message: string;
-	    isAnnotated: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new Tag(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.commitHash = source["commitHash"];
-	        this.message = source["message"];
-	        this.isAnnotated = source["isAnnotated"];
-	    }
-	}
-
-}
-
 export namespace models {
 	
 	export class AIConfig {
@@ -28,6 +5,8 @@ export namespace models {
 	    apiKey: string;
 	    baseUrl: string;
 	    model: string;
+	    embeddingModel: string;
+	    tokenBudget: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new AIConfig(source);
@@ -39,102 +18,325 @@ export namespace models {
 	        this.apiKey = source["apiKey"];
 	        this.baseUrl = source["baseUrl"];
 	        this.model = source["model"];
+	        this.embeddingModel = source["embeddingModel"];
+	        this.tokenBudget = source["tokenBudget"];
 	    }
 	}
-	export class Branch {
+	export class AIContextFilter {
+	    patterns: string[];
+	    generated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AIContextFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.patterns = source["patterns"];
+	        this.generated = source["generated"];
+	    }
+	}
+	export class ActionParam {
 	    name: string;
-	    isCurrent: boolean;
+	    type: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Branch(source);
+	        return new ActionParam(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.isCurrent = source["isCurrent"];
+	        this.type = source["type"];
 	    }
 	}
-	export class Command {
+	export class ActionInfo {
 	    id: string;
-	    name: string;
-	    description: string;
-	    command: string;
+	    title: string;
 	    category: string;
-	    createdAt: string;
-	    updatedAt: string;
+	    dangerous: boolean;
+	    params: ActionParam[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Command(source);
+	        return new ActionInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.description = source["description"];
-	        this.command = source["command"];
+	        this.title = source["title"];
 	        this.category = source["category"];
-	        this.createdAt = source["createdAt"];
-	        this.updatedAt = source["updatedAt"];
+	        this.dangerous = source["dangerous"];
+	        this.params = this.convertValues(source["params"], ActionParam);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class AddRemoteOptions {
+	    name: string;
+	    url: string;
+	    validate: boolean;
+	    testConnection: boolean;
+	    fetch: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AddRemoteOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	        this.validate = source["validate"];
+	        this.testConnection = source["testConnection"];
+	        this.fetch = source["fetch"];
 	    }
 	}
-	export class CommitInfo {
+	export class Remote {
+	    name: string;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Remote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	    }
+	}
+	export class AddRemoteResult {
+	    remote: Remote;
+	    branches: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AddRemoteResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = this.convertValues(source["remote"], Remote);
+	        this.branches = source["branches"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AppliedHunk {
+	    index: number;
+	    line: number;
+	    offset: number;
+	    fuzz: number;
+	    whitespaceInsensitive: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppliedHunk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.line = source["line"];
+	        this.offset = source["offset"];
+	        this.fuzz = source["fuzz"];
+	        this.whitespaceInsensitive = source["whitespaceInsensitive"];
+	    }
+	}
+	export class AuthorQuality {
+	    author: string;
+	    email: string;
+	    commits: number;
+	    averageScore: number;
+	    typedPercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthorQuality(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.author = source["author"];
+	        this.email = source["email"];
+	        this.commits = source["commits"];
+	        this.averageScore = source["averageScore"];
+	        this.typedPercent = source["typedPercent"];
+	    }
+	}
+	export class BackupTarget {
+	    id: string;
+	    repoPath: string;
+	    remote: string;
+	    mirror: boolean;
+	    branches: string[];
+	    intervalMinutes: number;
+	    onCommit: boolean;
+	    lastAttemptAt: string;
+	    lastSuccessAt: string;
+	    lastError: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.repoPath = source["repoPath"];
+	        this.remote = source["remote"];
+	        this.mirror = source["mirror"];
+	        this.branches = source["branches"];
+	        this.intervalMinutes = source["intervalMinutes"];
+	        this.onCommit = source["onCommit"];
+	        this.lastAttemptAt = source["lastAttemptAt"];
+	        this.lastSuccessAt = source["lastSuccessAt"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	export class BlameLine {
+	    line: number;
+	    origLine: number;
+	    origPath: string;
 	    hash: string;
-	    message: string;
+	    shortHash: string;
 	    author: string;
+	    email: string;
 	    date: string;
+	    timestamp: number;
+	    summary: string;
+	    content: string;
+	    uncommitted: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitInfo(source);
+	        return new BlameLine(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.origLine = source["origLine"];
+	        this.origPath = source["origPath"];
 	        this.hash = source["hash"];
-	        this.message = source["message"];
+	        this.shortHash = source["shortHash"];
 	        this.author = source["author"];
+	        this.email = source["email"];
 	        this.date = source["date"];
+	        this.timestamp = source["timestamp"];
+	        this.summary = source["summary"];
+	        this.content = source["content"];
+	        this.uncommitted = source["uncommitted"];
 	    }
 	}
-	export class FileChange {
-	    path: string;
-	    status: string;
-	    additions: number;
-	    deletions: number;
+	export class Branch {
+	    name: string;
+	    isCurrent: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileChange(source);
+	        return new Branch(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.status = source["status"];
-	        this.additions = source["additions"];
-	        this.deletions = source["deletions"];
+	        this.name = source["name"];
+	        this.isCurrent = source["isCurrent"];
 	    }
 	}
-	export class GitStatus {
+	export class BranchProtection {
 	    branch: string;
-	    staged: FileChange[];
-	    unstaged: FileChange[];
-	    untracked: string[];
-	    isRepo: boolean;
-	    hasChanges: boolean;
+	    protected: boolean;
+	    detailed: boolean;
+	    pushRestricted: boolean;
+	    requirePullRequest: boolean;
+	    requireSignatures: boolean;
+	    requireLinearHistory: boolean;
+	    allowForcePush: boolean;
+	    requiredChecks: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new GitStatus(source);
+	        return new BranchProtection(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.branch = source["branch"];
-	        this.staged = this.convertValues(source["staged"], FileChange);
-	        this.unstaged = this.convertValues(source["unstaged"], FileChange);
-	        this.untracked = source["untracked"];
-	        this.isRepo = source["isRepo"];
-	        this.hasChanges = source["hasChanges"];
+	        this.protected = source["protected"];
+	        this.detailed = source["detailed"];
+	        this.pushRestricted = source["pushRestricted"];
+	        this.requirePullRequest = source["requirePullRequest"];
+	        this.requireSignatures = source["requireSignatures"];
+	        this.requireLinearHistory = source["requireLinearHistory"];
+	        this.allowForcePush = source["allowForcePush"];
+	        this.requiredChecks = source["requiredChecks"];
+	    }
+	}
+	export class FileOwners {
+	    path: string;
+	    owners: string[];
+	    pattern: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileOwners(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.owners = source["owners"];
+	        this.pattern = source["pattern"];
+	    }
+	}
+	export class ChangeOwners {
+	    file: string;
+	    files: FileOwners[];
+	    owners: string[];
+	    users: string[];
+	    teams: string[];
+	    unowned: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeOwners(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.files = this.convertValues(source["files"], FileOwners);
+	        this.owners = source["owners"];
+	        this.users = source["users"];
+	        this.teams = source["teams"];
+	        this.unowned = source["unowned"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -155,64 +357,2661 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class Prompt {
-	    id: string;
-	    name: string;
+	export class ChangelogEntry {
+	    hash: string;
+	    shortHash: string;
+	    scope: string;
 	    description: string;
-	    template: string;
-	    isDefault: boolean;
-	    createdAt: string;
-	    updatedAt: string;
+	    breaking: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Prompt(source);
+	        return new ChangelogEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.scope = source["scope"];
 	        this.description = source["description"];
-	        this.template = source["template"];
-	        this.isDefault = source["isDefault"];
-	        this.createdAt = source["createdAt"];
-	        this.updatedAt = source["updatedAt"];
+	        this.breaking = source["breaking"];
 	    }
 	}
-	export class Remote {
-	    name: string;
-	    url: string;
+	export class ChangelogSection {
+	    type: string;
+	    title: string;
+	    entries: ChangelogEntry[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Remote(source);
+	        return new ChangelogSection(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.url = source["url"];
+	        this.type = source["type"];
+	        this.title = source["title"];
+	        this.entries = this.convertValues(source["entries"], ChangelogEntry);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class Repository {
-	    id: string;
-	    path: string;
-	    alias: string;
-	    description: string;
-	    createdAt: string;
-	    updatedAt: string;
+	export class Changelog {
+	    from: string;
+	    to: string;
+	    sections: ChangelogSection[];
+	    breaking: ChangelogEntry[];
+	    markdown: string;
+	    aiGenerated: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Repository(source);
+	        return new Changelog(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.path = source["path"];
-	        this.alias = source["alias"];
-	        this.description = source["description"];
-	        this.createdAt = source["createdAt"];
-	        this.updatedAt = source["updatedAt"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.sections = this.convertValues(source["sections"], ChangelogSection);
+	        this.breaking = this.convertValues(source["breaking"], ChangelogEntry);
+	        this.markdown = source["markdown"];
+	        this.aiGenerated = source["aiGenerated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class CheckRun {
+	    name: string;
+	    status: string;
+	    conclusion: string;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CheckRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.conclusion = source["conclusion"];
+	        this.url = source["url"];
+	    }
+	}
+	export class CheckoutResult {
+	    branch: string;
+	    strategy: string;
+	    changes: string[];
+	    stashed: boolean;
+	    reapplied: boolean;
+	    conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CheckoutResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.strategy = source["strategy"];
+	        this.changes = source["changes"];
+	        this.stashed = source["stashed"];
+	        this.reapplied = source["reapplied"];
+	        this.conflicts = source["conflicts"];
+	    }
+	}
+	export class ChecksStatus {
+	    ref: string;
+	    commit: string;
+	    state: string;
+	    checks: CheckRun[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChecksStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ref = source["ref"];
+	        this.commit = source["commit"];
+	        this.state = source["state"];
+	        this.checks = this.convertValues(source["checks"], CheckRun);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CloneOptions {
+	    url: string;
+	    path: string;
+	    branch: string;
+	    depth: number;
+	    recurseSubmodules: boolean;
+	    postClone: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CloneOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.path = source["path"];
+	        this.branch = source["branch"];
+	        this.depth = source["depth"];
+	        this.recurseSubmodules = source["recurseSubmodules"];
+	        this.postClone = source["postClone"];
+	    }
+	}
+	export class CloneSettings {
+	    root: string;
+	    grouping: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloneSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root = source["root"];
+	        this.grouping = source["grouping"];
+	    }
+	}
+	export class Command {
+	    id: string;
+	    name: string;
+	    description: string;
+	    command: string;
+	    category: string;
+	    createdAt: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Command(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.command = source["command"];
+	        this.category = source["category"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class CommitInfo {
+	    hash: string;
+	    shortHash: string;
+	    message: string;
+	    author: string;
+	    email: string;
+	    date: string;
+	    authorDate: string;
+	    authorTimestamp: number;
+	    commitDate: string;
+	    commitTimestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.message = source["message"];
+	        this.author = source["author"];
+	        this.email = source["email"];
+	        this.date = source["date"];
+	        this.authorDate = source["authorDate"];
+	        this.authorTimestamp = source["authorTimestamp"];
+	        this.commitDate = source["commitDate"];
+	        this.commitTimestamp = source["commitTimestamp"];
+	    }
+	}
+	export class CommitPatch {
+	    hash: string;
+	    message: string;
+	    author: string;
+	    email: string;
+	    date: string;
+	    patch: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitPatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.message = source["message"];
+	        this.author = source["author"];
+	        this.email = source["email"];
+	        this.date = source["date"];
+	        this.patch = source["patch"];
+	    }
+	}
+	export class HookResult {
+	    name: string;
+	    passed: boolean;
+	    output: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.passed = source["passed"];
+	        this.output = source["output"];
+	    }
+	}
+	export class SubmoduleChange {
+	    oldCommit: string;
+	    newCommit: string;
+	    commitChanged: boolean;
+	    hasModifications: boolean;
+	    hasUntracked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubmoduleChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oldCommit = source["oldCommit"];
+	        this.newCommit = source["newCommit"];
+	        this.commitChanged = source["commitChanged"];
+	        this.hasModifications = source["hasModifications"];
+	        this.hasUntracked = source["hasUntracked"];
+	    }
+	}
+	export class FileChange {
+	    path: string;
+	    oldPath: string;
+	    similarity: number;
+	    status: string;
+	    additions: number;
+	    deletions: number;
+	    entryType: string;
+	    submodule?: SubmoduleChange;
+	    hunkStaging: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.oldPath = source["oldPath"];
+	        this.similarity = source["similarity"];
+	        this.status = source["status"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.entryType = source["entryType"];
+	        this.submodule = this.convertValues(source["submodule"], SubmoduleChange);
+	        this.hunkStaging = source["hunkStaging"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitPreview {
+	    files: FileChange[];
+	    checks: HookResult[];
+	    canCommit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = this.convertValues(source["files"], FileChange);
+	        this.checks = this.convertValues(source["checks"], HookResult);
+	        this.canCommit = source["canCommit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitQuality {
+	    hash: string;
+	    shortHash: string;
+	    author: string;
+	    email: string;
+	    subject: string;
+	    score: number;
+	    aiScore: number;
+	    typed: boolean;
+	    problems: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitQuality(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.author = source["author"];
+	        this.email = source["email"];
+	        this.subject = source["subject"];
+	        this.score = source["score"];
+	        this.aiScore = source["aiScore"];
+	        this.typed = source["typed"];
+	        this.problems = source["problems"];
+	    }
+	}
+	export class CommitQualityReport {
+	    commits: CommitQuality[];
+	    authors: AuthorQuality[];
+	    averageScore: number;
+	    aiUsed: boolean;
+	    aiError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitQualityReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.commits = this.convertValues(source["commits"], CommitQuality);
+	        this.authors = this.convertValues(source["authors"], AuthorQuality);
+	        this.averageScore = source["averageScore"];
+	        this.aiUsed = source["aiUsed"];
+	        this.aiError = source["aiError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitTrailer {
+	    key: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitTrailer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.value = source["value"];
+	    }
+	}
+	export class LogEntry {
+	    time: string;
+	    level: string;
+	    message: string;
+	    id: string;
+	    fields: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.level = source["level"];
+	        this.message = source["message"];
+	        this.id = source["id"];
+	        this.fields = source["fields"];
+	    }
+	}
+	export class CrashReport {
+	    id: string;
+	    time: string;
+	    version: string;
+	    os: string;
+	    arch: string;
+	    summary: string;
+	    stack: string;
+	    logs: LogEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CrashReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time = source["time"];
+	        this.version = source["version"];
+	        this.os = source["os"];
+	        this.arch = source["arch"];
+	        this.summary = source["summary"];
+	        this.stack = source["stack"];
+	        this.logs = this.convertValues(source["logs"], LogEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DeleteBranchPreview {
+	    branch: string;
+	    isCurrent: boolean;
+	    upstream: string;
+	    onRemotes: string[];
+	    unmergedCommits: number;
+	    unpushedCommits: number;
+	    lostCommits: string[];
+	    safe: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeleteBranchPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.isCurrent = source["isCurrent"];
+	        this.upstream = source["upstream"];
+	        this.onRemotes = source["onRemotes"];
+	        this.unmergedCommits = source["unmergedCommits"];
+	        this.unpushedCommits = source["unpushedCommits"];
+	        this.lostCommits = source["lostCommits"];
+	        this.safe = source["safe"];
+	    }
+	}
+	export class DiffLine {
+	    type: string;
+	    content: string;
+	    oldLine: number;
+	    newLine: number;
+	    noNewline: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.content = source["content"];
+	        this.oldLine = source["oldLine"];
+	        this.newLine = source["newLine"];
+	        this.noNewline = source["noNewline"];
+	    }
+	}
+	export class DiffHunk {
+	    header: string;
+	    section: string;
+	    oldStart: number;
+	    oldLines: number;
+	    newStart: number;
+	    newLines: number;
+	    lines: DiffLine[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffHunk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.header = source["header"];
+	        this.section = source["section"];
+	        this.oldStart = source["oldStart"];
+	        this.oldLines = source["oldLines"];
+	        this.newStart = source["newStart"];
+	        this.newLines = source["newLines"];
+	        this.lines = this.convertValues(source["lines"], DiffLine);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffFile {
+	    oldPath: string;
+	    newPath: string;
+	    status: string;
+	    binary: boolean;
+	    hunks: DiffHunk[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oldPath = source["oldPath"];
+	        this.newPath = source["newPath"];
+	        this.status = source["status"];
+	        this.binary = source["binary"];
+	        this.hunks = this.convertValues(source["hunks"], DiffHunk);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class DiscardedEntry {
+	    id: string;
+	    kind: string;
+	    repo: string;
+	    paths: string[];
+	    hasPatch: boolean;
+	    files: string[];
+	    skipped: string[];
+	    size: number;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscardedEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.repo = source["repo"];
+	        this.paths = source["paths"];
+	        this.hasPatch = source["hasPatch"];
+	        this.files = source["files"];
+	        this.skipped = source["skipped"];
+	        this.size = source["size"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class RepositoryDefaults {
+	    defaultRemote: string;
+	    defaultBranch: string;
+	    pushBehavior: string;
+	    forgeAccount: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepositoryDefaults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.defaultRemote = source["defaultRemote"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.pushBehavior = source["pushBehavior"];
+	        this.forgeAccount = source["forgeAccount"];
+	    }
+	}
+	export class Repository {
+	    id: string;
+	    path: string;
+	    alias: string;
+	    description: string;
+	    pathScope: string;
+	    defaults: RepositoryDefaults;
+	    lastBackup: string;
+	    createdAt: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Repository(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.alias = source["alias"];
+	        this.description = source["description"];
+	        this.pathScope = source["pathScope"];
+	        this.defaults = this.convertValues(source["defaults"], RepositoryDefaults);
+	        this.lastBackup = source["lastBackup"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DroppedPathResult {
+	    kind: string;
+	    path: string;
+	    repository?: Repository;
+	
+	    static createFrom(source: any = {}) {
+	        return new DroppedPathResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.path = source["path"];
+	        this.repository = this.convertValues(source["repository"], Repository);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OperationStats {
+	    kind: string;
+	    command: string;
+	    count: number;
+	    failures: number;
+	    avgMs: number;
+	    maxMs: number;
+	    totalMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperationStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.command = source["command"];
+	        this.count = source["count"];
+	        this.failures = source["failures"];
+	        this.avgMs = source["avgMs"];
+	        this.maxMs = source["maxMs"];
+	        this.totalMs = source["totalMs"];
+	    }
+	}
+	export class FSMonitorStatus {
+	    supported: boolean;
+	    enabled: boolean;
+	    mode: string;
+	    daemonRunning: boolean;
+	    untrackedCache: boolean;
+	    trackedFiles: number;
+	    recommended: boolean;
+	    statusLatency?: OperationStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new FSMonitorStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.supported = source["supported"];
+	        this.enabled = source["enabled"];
+	        this.mode = source["mode"];
+	        this.daemonRunning = source["daemonRunning"];
+	        this.untrackedCache = source["untrackedCache"];
+	        this.trackedFiles = source["trackedFiles"];
+	        this.recommended = source["recommended"];
+	        this.statusLatency = this.convertValues(source["statusLatency"], OperationStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FetchPolicy {
+	    autoFetch: boolean;
+	    staleMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.autoFetch = source["autoFetch"];
+	        this.staleMinutes = source["staleMinutes"];
+	    }
+	}
+	export class RefUpdate {
+	    ref: string;
+	    oldHash: string;
+	    newHash: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ref = source["ref"];
+	        this.oldHash = source["oldHash"];
+	        this.newHash = source["newHash"];
+	    }
+	}
+	export class FetchResult {
+	    remote: string;
+	    updated: RefUpdate[];
+	    pruned: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.updated = this.convertValues(source["updated"], RefUpdate);
+	        this.pruned = source["pruned"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class FileIssue {
+	    path: string;
+	    kind: string;
+	    detail: string;
+	    attributes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.kind = source["kind"];
+	        this.detail = source["detail"];
+	        this.attributes = source["attributes"];
+	    }
+	}
+	
+	export class ForgeConfig {
+	    id: string;
+	    name: string;
+	    provider: string;
+	    token: string;
+	    hasToken: boolean;
+	    baseUrl: string;
+	    username: string;
+	    scopes: string[];
+	    expiresAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForgeConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.provider = source["provider"];
+	        this.token = source["token"];
+	        this.hasToken = source["hasToken"];
+	        this.baseUrl = source["baseUrl"];
+	        this.username = source["username"];
+	        this.scopes = source["scopes"];
+	        this.expiresAt = source["expiresAt"];
+	    }
+	}
+	export class GitExecutable {
+	    path: string;
+	    args: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GitExecutable(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.args = source["args"];
+	    }
+	}
+	export class UntrackedDir {
+	    path: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UntrackedDir(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.count = source["count"];
+	    }
+	}
+	export class OperationInfo {
+	    state: string;
+	    head: string;
+	    branch: string;
+	    onto: string;
+	    step: number;
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperationInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.state = source["state"];
+	        this.head = source["head"];
+	        this.branch = source["branch"];
+	        this.onto = source["onto"];
+	        this.step = source["step"];
+	        this.total = source["total"];
+	    }
+	}
+	export class GitStatus {
+	    branch: string;
+	    upstream: string;
+	    ahead: number;
+	    behind: number;
+	    staged: FileChange[];
+	    unstaged: FileChange[];
+	    untracked: string[];
+	    isRepo: boolean;
+	    hasChanges: boolean;
+	    scope: string;
+	    hasCommits: boolean;
+	    operation: OperationInfo;
+	    untrackedDirs: UntrackedDir[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GitStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.upstream = source["upstream"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	        this.staged = this.convertValues(source["staged"], FileChange);
+	        this.unstaged = this.convertValues(source["unstaged"], FileChange);
+	        this.untracked = source["untracked"];
+	        this.isRepo = source["isRepo"];
+	        this.hasChanges = source["hasChanges"];
+	        this.scope = source["scope"];
+	        this.hasCommits = source["hasCommits"];
+	        this.operation = this.convertValues(source["operation"], OperationInfo);
+	        this.untrackedDirs = this.convertValues(source["untrackedDirs"], UntrackedDir);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GraphEdge {
+	    from: number;
+	    to: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphEdge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class GraphRef {
+	    name: string;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphRef(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	    }
+	}
+	export class GraphCommit {
+	    commit: CommitInfo;
+	    parents: string[];
+	    refs: GraphRef[];
+	    lane: number;
+	    edges: GraphEdge[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.commit = this.convertValues(source["commit"], CommitInfo);
+	        this.parents = source["parents"];
+	        this.refs = this.convertValues(source["refs"], GraphRef);
+	        this.lane = source["lane"];
+	        this.edges = this.convertValues(source["edges"], GraphEdge);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class HealthWarning {
+	    code: string;
+	    message: string;
+	    action: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HealthWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.message = source["message"];
+	        this.action = source["action"];
+	    }
+	}
+	
+	export class Issue {
+	    number: number;
+	    key: string;
+	    title: string;
+	    state: string;
+	    author: string;
+	    labels: string[];
+	    assignees: string[];
+	    url: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Issue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.key = source["key"];
+	        this.title = source["title"];
+	        this.state = source["state"];
+	        this.author = source["author"];
+	        this.labels = source["labels"];
+	        this.assignees = source["assignees"];
+	        this.url = source["url"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class IssueFilter {
+	    state: string;
+	    query: string;
+	    assignee: string;
+	    labels: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.state = source["state"];
+	        this.query = source["query"];
+	        this.assignee = source["assignee"];
+	        this.labels = source["labels"];
+	    }
+	}
+	export class LibraryPrompt {
+	    id: string;
+	    name: string;
+	    description: string;
+	    category: string;
+	    version: number;
+	    template: string;
+	    installed: boolean;
+	    promptId: string;
+	    installedVersion: number;
+	    updateAvailable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LibraryPrompt(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.category = source["category"];
+	        this.version = source["version"];
+	        this.template = source["template"];
+	        this.installed = source["installed"];
+	        this.promptId = source["promptId"];
+	        this.installedVersion = source["installedVersion"];
+	        this.updateAvailable = source["updateAvailable"];
+	    }
+	}
+	export class LineRange {
+	    start: number;
+	    end: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LineRange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	    }
+	}
+	export class Location {
+	    kind: string;
+	    name: string;
+	    path: string;
+	    commit?: CommitInfo;
+	
+	    static createFrom(source: any = {}) {
+	        return new Location(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.commit = this.convertValues(source["commit"], CommitInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LogOptions {
+	    limit: number;
+	    skip: number;
+	    path: string;
+	    author: string;
+	    grep: string;
+	    ignoreCase: boolean;
+	    firstParent: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.limit = source["limit"];
+	        this.skip = source["skip"];
+	        this.path = source["path"];
+	        this.author = source["author"];
+	        this.grep = source["grep"];
+	        this.ignoreCase = source["ignoreCase"];
+	        this.firstParent = source["firstParent"];
+	    }
+	}
+	export class MergeConflict {
+	    path: string;
+	    type: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeConflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.type = source["type"];
+	        this.message = source["message"];
+	    }
+	}
+	export class MergePrediction {
+	    source: string;
+	    target: string;
+	    mergeBase: string;
+	    upToDate: boolean;
+	    fastForward: boolean;
+	    clean: boolean;
+	    conflicts: MergeConflict[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MergePrediction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.mergeBase = source["mergeBase"];
+	        this.upToDate = source["upToDate"];
+	        this.fastForward = source["fastForward"];
+	        this.clean = source["clean"];
+	        this.conflicts = this.convertValues(source["conflicts"], MergeConflict);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MessageStyle {
+	    enabled: boolean;
+	    maxSubjectLength: number;
+	    bodyWrapWidth: number;
+	    requireType: boolean;
+	    defaultType: string;
+	    emoji: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MessageStyle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.maxSubjectLength = source["maxSubjectLength"];
+	        this.bodyWrapWidth = source["bodyWrapWidth"];
+	        this.requireType = source["requireType"];
+	        this.defaultType = source["defaultType"];
+	        this.emoji = source["emoji"];
+	    }
+	}
+	
+	
+	export class OperationTrace {
+	    operation?: string;
+	    kind: string;
+	    command: string;
+	    repo: string;
+	    durationMs: number;
+	    success: boolean;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperationTrace(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = source["operation"];
+	        this.kind = source["kind"];
+	        this.command = source["command"];
+	        this.repo = source["repo"];
+	        this.durationMs = source["durationMs"];
+	        this.success = source["success"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class OutgoingCommit {
+	    hash: string;
+	    shortHash: string;
+	    subject: string;
+	    signature: string;
+	    merge: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutgoingCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.subject = source["subject"];
+	        this.signature = source["signature"];
+	        this.merge = source["merge"];
+	    }
+	}
+	export class OutgoingPush {
+	    remote: string;
+	    branch: string;
+	    remoteBranch: string;
+	    newBranch: boolean;
+	    fastForward: boolean;
+	    commits: OutgoingCommit[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OutgoingPush(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.branch = source["branch"];
+	        this.remoteBranch = source["remoteBranch"];
+	        this.newBranch = source["newBranch"];
+	        this.fastForward = source["fastForward"];
+	        this.commits = this.convertValues(source["commits"], OutgoingCommit);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PatchFile {
+	    name: string;
+	    subject: string;
+	    content: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PatchFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.subject = source["subject"];
+	        this.content = source["content"];
+	    }
+	}
+	export class PathResult {
+	    path: string;
+	    success: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PathResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.success = source["success"];
+	        this.error = source["error"];
+	    }
+	}
+	export class PendingOperation {
+	    id: string;
+	    kind: string;
+	    repo: string;
+	    url: string;
+	    remote: string;
+	    branch: string;
+	    createdDir: boolean;
+	    startedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PendingOperation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.repo = source["repo"];
+	        this.url = source["url"];
+	        this.remote = source["remote"];
+	        this.branch = source["branch"];
+	        this.createdDir = source["createdDir"];
+	        this.startedAt = source["startedAt"];
+	    }
+	}
+	export class PendingOperationResult {
+	    operation: PendingOperation;
+	    action: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PendingOperationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = this.convertValues(source["operation"], PendingOperation);
+	        this.action = source["action"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PerformanceReport {
+	    enabled: boolean;
+	    since: string;
+	    operations: OperationStats[];
+	    slowest: OperationTrace[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PerformanceReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.since = source["since"];
+	        this.operations = this.convertValues(source["operations"], OperationStats);
+	        this.slowest = this.convertValues(source["slowest"], OperationTrace);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Prompt {
+	    id: string;
+	    name: string;
+	    description: string;
+	    template: string;
+	    isDefault: boolean;
+	    libraryId: string;
+	    libraryVersion: number;
+	    createdAt: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Prompt(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.template = source["template"];
+	        this.isDefault = source["isDefault"];
+	        this.libraryId = source["libraryId"];
+	        this.libraryVersion = source["libraryVersion"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class ProtectedPaths {
+	    patterns: string[];
+	    block: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProtectedPaths(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.patterns = source["patterns"];
+	        this.block = source["block"];
+	    }
+	}
+	export class ReviewComment {
+	    id: string;
+	    path: string;
+	    line: number;
+	    side: string;
+	    body: string;
+	    author: string;
+	    url: string;
+	    createdAt: string;
+	    inReplyTo: string;
+	    outdated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewComment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.side = source["side"];
+	        this.body = source["body"];
+	        this.author = source["author"];
+	        this.url = source["url"];
+	        this.createdAt = source["createdAt"];
+	        this.inReplyTo = source["inReplyTo"];
+	        this.outdated = source["outdated"];
+	    }
+	}
+	export class PullRequestComments {
+	    number: number;
+	    title: string;
+	    url: string;
+	    comments: ReviewComment[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PullRequestComments(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.url = source["url"];
+	        this.comments = this.convertValues(source["comments"], ReviewComment);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PullRequestDescription {
+	    title: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PullRequestDescription(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.description = source["description"];
+	    }
+	}
+	export class PushOptions {
+	    remote: string;
+	    branch: string;
+	    refspec: string;
+	    forceWithLease: boolean;
+	    tags: boolean;
+	    setUpstream: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.branch = source["branch"];
+	        this.refspec = source["refspec"];
+	        this.forceWithLease = source["forceWithLease"];
+	        this.tags = source["tags"];
+	        this.setUpstream = source["setUpstream"];
+	    }
+	}
+	export class PushWarning {
+	    kind: string;
+	    message: string;
+	    commits: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PushWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.commits = source["commits"];
+	    }
+	}
+	export class PushPreflight {
+	    outgoing: OutgoingPush;
+	    protection?: BranchProtection;
+	    warnings: PushWarning[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PushPreflight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.outgoing = this.convertValues(source["outgoing"], OutgoingPush);
+	        this.protection = this.convertValues(source["protection"], BranchProtection);
+	        this.warnings = this.convertValues(source["warnings"], PushWarning);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RebaseOntoPreview {
+	    newBase: string;
+	    oldBase: string;
+	    branch: string;
+	    commits: CommitInfo[];
+	    skipped: string[];
+	    droppedMerges: number;
+	    pushed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RebaseOntoPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.newBase = source["newBase"];
+	        this.oldBase = source["oldBase"];
+	        this.branch = source["branch"];
+	        this.commits = this.convertValues(source["commits"], CommitInfo);
+	        this.skipped = source["skipped"];
+	        this.droppedMerges = source["droppedMerges"];
+	        this.pushed = source["pushed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class RemoteRepository {
+	    name: string;
+	    fullName: string;
+	    description: string;
+	    cloneUrl: string;
+	    sshUrl: string;
+	    webUrl: string;
+	    private: boolean;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteRepository(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.fullName = source["fullName"];
+	        this.description = source["description"];
+	        this.cloneUrl = source["cloneUrl"];
+	        this.sshUrl = source["sshUrl"];
+	        this.webUrl = source["webUrl"];
+	        this.private = source["private"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class RemoteStaleness {
+	    lastFetch: string;
+	    ageMinutes: number;
+	    stale: boolean;
+	    fetched: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteStaleness(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lastFetch = source["lastFetch"];
+	        this.ageMinutes = source["ageMinutes"];
+	        this.stale = source["stale"];
+	        this.fetched = source["fetched"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	
+	export class RepositoryHealth {
+	    looseObjects: number;
+	    packCount: number;
+	    packSizeKB: number;
+	    garbageCount: number;
+	    indexSize: number;
+	    warnings: HealthWarning[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RepositoryHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.looseObjects = source["looseObjects"];
+	        this.packCount = source["packCount"];
+	        this.packSizeKB = source["packSizeKB"];
+	        this.garbageCount = source["garbageCount"];
+	        this.indexSize = source["indexSize"];
+	        this.warnings = this.convertValues(source["warnings"], HealthWarning);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RepositoryInfo {
+	    path: string;
+	    branch: string;
+	    upstream: string;
+	    ahead: number;
+	    behind: number;
+	    stashCount: number;
+	    lastFetch: string;
+	    operation: OperationInfo;
+	    detached: boolean;
+	    bare: boolean;
+	    hasChanges: boolean;
+	    isRepo: boolean;
+	    scope: string;
+	    hasCommits: boolean;
+	    health?: RepositoryHealth;
+	    remoteView: RemoteStaleness;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepositoryInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.branch = source["branch"];
+	        this.upstream = source["upstream"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	        this.stashCount = source["stashCount"];
+	        this.lastFetch = source["lastFetch"];
+	        this.operation = this.convertValues(source["operation"], OperationInfo);
+	        this.detached = source["detached"];
+	        this.bare = source["bare"];
+	        this.hasChanges = source["hasChanges"];
+	        this.isRepo = source["isRepo"];
+	        this.scope = source["scope"];
+	        this.hasCommits = source["hasCommits"];
+	        this.health = this.convertValues(source["health"], RepositoryHealth);
+	        this.remoteView = this.convertValues(source["remoteView"], RemoteStaleness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Tag {
+	    name: string;
+	    commitHash: string;
+	    message: string;
+	    isAnnotated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.commitHash = source["commitHash"];
+	        this.message = source["message"];
+	        this.isAnnotated = source["isAnnotated"];
+	    }
+	}
+	export class RepositoryOverview {
+	    repository?: Repository;
+	    info?: RepositoryInfo;
+	    status?: GitStatus;
+	    branches: Branch[];
+	    tags: Tag[];
+	    remotes: Remote[];
+	    log: CommitInfo[];
+	    errors: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepositoryOverview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repository = this.convertValues(source["repository"], Repository);
+	        this.info = this.convertValues(source["info"], RepositoryInfo);
+	        this.status = this.convertValues(source["status"], GitStatus);
+	        this.branches = this.convertValues(source["branches"], Branch);
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.remotes = this.convertValues(source["remotes"], Remote);
+	        this.log = this.convertValues(source["log"], CommitInfo);
+	        this.errors = source["errors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ReviewRequest {
+	    provider: string;
+	    host: string;
+	    project: string;
+	    number: number;
+	    title: string;
+	    author: string;
+	    url: string;
+	    draft: boolean;
+	    updatedAt: string;
+	    headRef: string;
+	    repositoryId: string;
+	    repositoryPath: string;
+	    remote: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.host = source["host"];
+	        this.project = source["project"];
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.url = source["url"];
+	        this.draft = source["draft"];
+	        this.updatedAt = source["updatedAt"];
+	        this.headRef = source["headRef"];
+	        this.repositoryId = source["repositoryId"];
+	        this.repositoryPath = source["repositoryPath"];
+	        this.remote = source["remote"];
+	    }
+	}
+	export class ReviewQueue {
+	    requests: ReviewRequest[];
+	    errors: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewQueue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.requests = this.convertValues(source["requests"], ReviewRequest);
+	        this.errors = source["errors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RewrittenCommit {
+	    hash: string;
+	    shortHash: string;
+	    subject: string;
+	    refs: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RewrittenCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.subject = source["subject"];
+	        this.refs = source["refs"];
+	    }
+	}
+	export class RewrittenCommits {
+	    base: string;
+	    tip: string;
+	    commits: RewrittenCommit[];
+	    refs: string[];
+	    remotes: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RewrittenCommits(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.base = source["base"];
+	        this.tip = source["tip"];
+	        this.commits = this.convertValues(source["commits"], RewrittenCommit);
+	        this.refs = source["refs"];
+	        this.remotes = source["remotes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SMTPConfig {
+	    host: string;
+	    port: number;
+	    username: string;
+	    password: string;
+	    hasPassword: boolean;
+	    from: string;
+	    useTls: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SMTPConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.hasPassword = source["hasPassword"];
+	        this.from = source["from"];
+	        this.useTls = source["useTls"];
+	    }
+	}
+	export class SemanticMatch {
+	    hash: string;
+	    shortHash: string;
+	    message: string;
+	    author: string;
+	    date: string;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SemanticMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.message = source["message"];
+	        this.author = source["author"];
+	        this.date = source["date"];
+	        this.score = source["score"];
+	    }
+	}
+	export class SendPatchOptions {
+	    range: string;
+	    coverLetter: boolean;
+	    coverSubject: string;
+	    coverBody: string;
+	    to: string[];
+	    cc: string[];
+	    useGit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SendPatchOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.range = source["range"];
+	        this.coverLetter = source["coverLetter"];
+	        this.coverSubject = source["coverSubject"];
+	        this.coverBody = source["coverBody"];
+	        this.to = source["to"];
+	        this.cc = source["cc"];
+	        this.useGit = source["useGit"];
+	    }
+	}
+	export class ShareInfo {
+	    active: boolean;
+	    repo: string;
+	    port: number;
+	    readOnly: boolean;
+	    url: string;
+	    pushUrl?: string;
+	    token?: string;
+	    startedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShareInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.active = source["active"];
+	        this.repo = source["repo"];
+	        this.port = source["port"];
+	        this.readOnly = source["readOnly"];
+	        this.url = source["url"];
+	        this.pushUrl = source["pushUrl"];
+	        this.token = source["token"];
+	        this.startedAt = source["startedAt"];
+	    }
+	}
+	export class Snapshot {
+	    id: string;
+	    hash: string;
+	    shortHash: string;
+	    message: string;
+	    automatic: boolean;
+	    head: string;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Snapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.message = source["message"];
+	        this.automatic = source["automatic"];
+	        this.head = source["head"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class StatusOptions {
+	    untrackedMode: string;
+	    ignoreSubmodules: boolean;
+	    collapseThreshold: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.untrackedMode = source["untrackedMode"];
+	        this.ignoreSubmodules = source["ignoreSubmodules"];
+	        this.collapseThreshold = source["collapseThreshold"];
+	    }
+	}
+	export class StructuredDiff {
+	    files: DiffFile[];
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StructuredDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = this.convertValues(source["files"], DiffFile);
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SuggestedPatchResult {
+	    filePath: string;
+	    created: boolean;
+	    hunks: AppliedHunk[];
+	    snapshotId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SuggestedPatchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.created = source["created"];
+	        this.hunks = this.convertValues(source["hunks"], AppliedHunk);
+	        this.snapshotId = source["snapshotId"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TeamCommitStyle {
+	    maxSubjectLength?: number;
+	    bodyWrapWidth?: number;
+	    requireType?: boolean;
+	    defaultType?: string;
+	    emoji?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeamCommitStyle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxSubjectLength = source["maxSubjectLength"];
+	        this.bodyWrapWidth = source["bodyWrapWidth"];
+	        this.requireType = source["requireType"];
+	        this.defaultType = source["defaultType"];
+	        this.emoji = source["emoji"];
+	    }
+	}
+	export class TeamConfig {
+	    commit?: TeamCommitStyle;
+	    protectedBranches: string[];
+	    scopes: Record<string, string>;
+	    prompt: string;
+	    setup: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TeamConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.commit = this.convertValues(source["commit"], TeamCommitStyle);
+	        this.protectedBranches = source["protectedBranches"];
+	        this.scopes = source["scopes"];
+	        this.prompt = source["prompt"];
+	        this.setup = source["setup"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TemplateRevision {
+	    id: string;
+	    itemId: string;
+	    kind: string;
+	    name: string;
+	    description: string;
+	    content: string;
+	    category: string;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TemplateRevision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.itemId = source["itemId"];
+	        this.kind = source["kind"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.content = source["content"];
+	        this.category = source["category"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class TestTarget {
+	    path: string;
+	    sources: string[];
+	    staged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TestTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.sources = source["sources"];
+	        this.staged = source["staged"];
+	    }
+	}
+	export class TestImpact {
+	    tests: TestTarget[];
+	    untested: string[];
+	    suggestions: string;
+	    aiUsed: boolean;
+	    aiError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TestImpact(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tests = this.convertValues(source["tests"], TestTarget);
+	        this.untested = source["untested"];
+	        this.suggestions = source["suggestions"];
+	        this.aiUsed = source["aiUsed"];
+	        this.aiError = source["aiError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TokenInfo {
+	    username: string;
+	    scopes: string[];
+	    missingScopes: string[];
+	    expiresAt: string;
+	    expired: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TokenInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.username = source["username"];
+	        this.scopes = source["scopes"];
+	        this.missingScopes = source["missingScopes"];
+	        this.expiresAt = source["expiresAt"];
+	        this.expired = source["expired"];
+	    }
+	}
+	export class TryState {
+	    active: boolean;
+	    repoPath: string;
+	    files: string[];
+	    snapshotId: string;
+	    startedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TryState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.active = source["active"];
+	        this.repoPath = source["repoPath"];
+	        this.files = source["files"];
+	        this.snapshotId = source["snapshotId"];
+	        this.startedAt = source["startedAt"];
+	    }
+	}
+	
+	export class UntrackedListing {
+	    files: string[];
+	    dirs: UntrackedDir[];
+	
+	    static createFrom(source: any = {}) {
+	        return new UntrackedListing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = source["files"];
+	        this.dirs = this.convertValues(source["dirs"], UntrackedDir);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateInfo {
+	    currentVersion: string;
+	    latestVersion: string;
+	    available: boolean;
+	    prerelease: boolean;
+	    notes: string;
+	    releaseUrl: string;
+	    publishedAt: string;
+	    assetName: string;
+	    assetUrl: string;
+	    size: number;
+	    downloaded: boolean;
+	    installPending: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currentVersion = source["currentVersion"];
+	        this.latestVersion = source["latestVersion"];
+	        this.available = source["available"];
+	        this.prerelease = source["prerelease"];
+	        this.notes = source["notes"];
+	        this.releaseUrl = source["releaseUrl"];
+	        this.publishedAt = source["publishedAt"];
+	        this.assetName = source["assetName"];
+	        this.assetUrl = source["assetUrl"];
+	        this.size = source["size"];
+	        this.downloaded = source["downloaded"];
+	        this.installPending = source["installPending"];
+	    }
+	}
+	export class WebURL {
+	    remote: string;
+	    flavor: string;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebURL(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.flavor = source["flavor"];
+	        this.url = source["url"];
 	    }
 	}
 
@@ -3,18 +3,27 @@ package ai
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"text/template"
 
 	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/trace"
 )
 
+// ErrOffline is returned by every AI request while offline mode is on
+var ErrOffline = errors.New("AI is unavailable in offline mode")
+
 // AIService handles AI operations for generating commit messages
 type AIService struct {
-	config models.AIConfig
-	client *http.Client
+	config  models.AIConfig
+	client  *http.Client
+	offline atomic.Bool
+	stream  *streamConfig
 }
 
 // NewAIService creates a new AIService instance
@@ -34,35 +43,140 @@ func (a *AIService) SetConfig(config models.AIConfig) {
 	a.config = config
 }
 
+// SetOffline turns offline mode on or off; while on, requests fail with ErrOffline
+func (a *AIService) SetOffline(offline bool) {
+	a.offline.Store(offline)
+}
+
+// IsOffline reports whether offline mode is on
+func (a *AIService) IsOffline() bool {
+	return a.offline.Load()
+}
+
 // GetConfig returns the current AI configuration
 func (a *AIService) GetConfig() models.AIConfig {
 	return a.config
 }
 
+// commitSystemPrompt instructs the model to write a commit message for a diff
+const commitSystemPrompt = `你是一个专业的 git 提交信息助手，擅长生成简洁清晰的提交信息，遵循 Conventional Commits 规范。
+
+分析 git diff 并生成提交信息，要求：
+1. 使用中文编写提交信息
+2. 以类型开头（feat, fix, docs, style, refactor, test, chore 等）
+3. 后面跟简短的描述（不超过 50 字）
+4. 如有必要，添加更详细的正文说明
+5. 使用祈使句（用"添加"而非"已添加"）
+6. 明确具体地说明变更内容
+
+只返回提交信息本身，不要有其他解释。`
+
+// prompt is a single request to the configured provider
+type prompt struct {
+	system    string
+	user      string
+	maxTokens int
+}
+
 // GenerateCommitMessage generates a commit message based on git diff
 func (a *AIService) GenerateCommitMessage(diff string) (string, error) {
 	if strings.TrimSpace(diff) == "" {
 		return "", fmt.Errorf("diff is empty")
 	}
+	diff, err := a.CondenseDiff(diff)
+	if err != nil {
+		return "", err
+	}
+	return a.complete(prompt{
+		system:    commitSystemPrompt,
+		user:      fmt.Sprintf("请为以下 diff 生成一个中文的 git 提交信息：\n\n%s", diff),
+		maxTokens: 200,
+	})
+}
 
+// GenerateCommitMessageWithTemplate generates a commit message from a prompt template,
+// in which {{.Diff}} is replaced by the diff
+func (a *AIService) GenerateCommitMessageWithTemplate(diff, promptTemplate string) (string, error) {
+	if strings.TrimSpace(diff) == "" {
+		return "", fmt.Errorf("diff is empty")
+	}
+	tmpl, err := template.New("prompt").Parse(promptTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	if diff, err = a.CondenseDiff(diff); err != nil {
+		return "", err
+	}
+	var user strings.Builder
+	if err := tmpl.Execute(&user, struct{ Diff string }{diff}); err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	return a.complete(prompt{system: commitSystemPrompt, user: user.String(), maxTokens: 200})
+}
+
+// RefineCommitMessage revises a previously suggested commit message following the user's
+// feedback, such as "shorter" or "mention the API rename"
+func (a *AIService) RefineCommitMessage(diff, previous, feedback string) (string, error) {
+	if strings.TrimSpace(diff) == "" {
+		return "", fmt.Errorf("diff is empty")
+	}
+	if strings.TrimSpace(feedback) == "" {
+		return a.GenerateCommitMessage(diff)
+	}
+	diff, err := a.CondenseDiff(diff)
+	if err != nil {
+		return "", err
+	}
+	return a.complete(prompt{
+		system: commitSystemPrompt + "\n\n用户会给出上一次生成的提交信息和修改意见，请在保持与 diff 一致的前提下按意见修改，而不是从头重写。",
+		user: fmt.Sprintf("diff：\n\n%s\n\n上一次生成的提交信息：\n%s\n\n修改意见：%s",
+			diff, strings.TrimSpace(previous), strings.TrimSpace(feedback)),
+		maxTokens: 200,
+	})
+}
+
+// GenerateCommitMessageFromSeed has the model complete a rules-based draft, keeping its
+// type when it fits the diff
+func (a *AIService) GenerateCommitMessageFromSeed(diff, seed string) (string, error) {
+	return a.RefineCommitMessage(diff, seed, "这是根据文件列表自动生成的草稿，请结合 diff 具体说明改动内容；类型正确时保留")
+}
+
+// complete sends a prompt to the configured provider and returns the reply
+func (a *AIService) complete(p prompt) (string, error) {
+	if a.IsOffline() {
+		return "", ErrOffline
+	}
 	if a.config.APIKey == "" && a.config.Provider != models.ProviderOllama {
 		return "", fmt.Errorf("API key is required for %s", a.config.Provider)
 	}
 
+	span := trace.StartContext(a.context(), trace.KindAI, string(a.config.Provider)+" "+a.getModel(), "")
+	message, err := a.generate(p)
+	span.End(err)
+	return message, err
+}
+
+// generate sends the prompt to the configured provider
+func (a *AIService) generate(p prompt) (string, error) {
+	if a.stream != nil {
+		return a.generateStream(p)
+	}
 	switch a.config.Provider {
 	case models.ProviderOpenAI:
-		return a.generateWithOpenAI(diff)
+		return a.generateWithOpenAI(p)
 	case models.ProviderClaude:
-		return a.generateWithClaude(diff)
+		return a.generateWithClaude(p)
 	case models.ProviderOllama:
-		return a.generateWithOllama(diff)
+		return a.generateWithOllama(p)
+	case models.ProviderGemini:
+		return a.generateWithGemini(p)
 	default:
 		return "", fmt.Errorf("unsupported AI provider: %s", a.config.Provider)
 	}
 }
 
-// generateWithOpenAI generates commit message using OpenAI API
-func (a *AIService) generateWithOpenAI(diff string) (string, error) {
+// generateWithOpenAI completes a prompt using OpenAI API
+func (a *AIService) generateWithOpenAI(p prompt) (string, error) {
 	baseURL := a.config.BaseURL
 	if baseURL == "" {
 		baseURL = "https://api.openai.com/v1"
@@ -72,26 +186,16 @@ func (a *AIService) generateWithOpenAI(diff string) (string, error) {
 		"model": a.getModel(),
 		"messages": []map[string]string{
 			{
-				"role": "system",
-				"content": `你是一个专业的 git 提交信息助手，擅长生成简洁清晰的提交信息，遵循 Conventional Commits 规范。
-
-分析 git diff 并生成提交信息，要求：
-1. 使用中文编写提交信息
-2. 以类型开头（feat, fix, docs, style, refactor, test, chore 等）
-3. 后面跟简短的描述（不超过 50 字）
-4. 如有必要，添加更详细的正文说明
-5. 使用祈使句（用"添加"而非"已添加"）
-6. 明确具体地说明变更内容
-
-只返回提交信息本身，不要有其他解释。`,
+				"role":    "system",
+				"content": p.system,
 			},
 			{
 				"role":    "user",
-				"content": fmt.Sprintf("请为以下 diff 生成一个中文的 git 提交信息：\n\n%s", diff),
+				"content": p.user,
 			},
 		},
 		"temperature": 0.3,
-		"max_tokens":  200,
+		"max_tokens":  p.maxTokens,
 	}
 
 	jsonData, err := json.Marshal(requestBody)
@@ -139,31 +243,21 @@ func (a *AIService) generateWithOpenAI(diff string) (string, error) {
 	return strings.TrimSpace(content), nil
 }
 
-// generateWithClaude generates commit message using Claude API
-func (a *AIService) generateWithClaude(diff string) (string, error) {
+// generateWithClaude completes a prompt using Claude API
+func (a *AIService) generateWithClaude(p prompt) (string, error) {
 	baseURL := a.config.BaseURL
 	if baseURL == "" {
 		baseURL = "https://api.anthropic.com/v1"
 	}
 
 	requestBody := map[string]interface{}{
-		"model":     a.getModel(),
-		"max_tokens": 200,
-		"system": `你是一个专业的 git 提交信息助手，擅长生成简洁清晰的提交信息，遵循 Conventional Commits 规范。
-
-分析 git diff 并生成提交信息，要求：
-1. 使用中文编写提交信息
-2. 以类型开头（feat, fix, docs, style, refactor, test, chore 等）
-3. 后面跟简短的描述（不超过 50 字）
-4. 如有必要，添加更详细的正文说明
-5. 使用祈使句（用"添加"而非"已添加"）
-6. 明确具体地说明变更内容
-
-只返回提交信息本身，不要有其他解释。`,
+		"model":      a.getModel(),
+		"max_tokens": p.maxTokens,
+		"system":     p.system,
 		"messages": []map[string]string{
 			{
-				"role": "user",
-				"content": fmt.Sprintf("请为以下 diff 生成一个中文的 git 提交信息：\n\n%s", diff),
+				"role":    "user",
+				"content": p.user,
 			},
 		},
 	}
@@ -211,8 +305,8 @@ func (a *AIService) generateWithClaude(diff string) (string, error) {
 	return strings.TrimSpace(text), nil
 }
 
-// generateWithOllama generates commit message using local Ollama
-func (a *AIService) generateWithOllama(diff string) (string, error) {
+// generateWithOllama completes a prompt using local Ollama
+func (a *AIService) generateWithOllama(p prompt) (string, error) {
 	baseURL := a.config.BaseURL
 	if baseURL == "" {
 		baseURL = "http://localhost:11434"
@@ -224,20 +318,13 @@ func (a *AIService) generateWithOllama(diff string) (string, error) {
 	}
 
 	requestBody := map[string]interface{}{
-		"model": model,
-		"prompt": fmt.Sprintf(`你是一个专业的 git 提交信息助手，擅长生成简洁清晰的提交信息，遵循 Conventional Commits 规范。
-
-分析 git diff 并生成中文提交信息。要求：
-1. 以类型开头（feat, fix, docs, style, refactor, test, chore 等）
-2. 后面跟简短的描述（不超过 50 字）
-3. 使用祈使句（用"添加"而非"已添加"）
-4. 只返回提交信息本身，不要有其他解释
-
-Diff:
-%s
-
-提交信息：`, diff),
+		"model":  model,
+		"system": p.system,
+		"prompt": p.user,
 		"stream": false,
+		"options": map[string]interface{}{
+			"num_predict": p.maxTokens,
+		},
 	}
 
 	jsonData, err := json.Marshal(requestBody)
@@ -280,6 +367,97 @@ Diff:
 	return strings.TrimSpace(respContent), nil
 }
 
+// generateWithGemini completes a prompt using the Gemini Generative Language API
+func (a *AIService) generateWithGemini(p prompt) (string, error) {
+	jsonData, err := json.Marshal(geminiRequest(p))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", a.geminiURL("generateContent"), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", a.config.APIKey)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var response geminiResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(response.Candidates) == 0 {
+		return "", fmt.Errorf("no candidates in response")
+	}
+	return strings.TrimSpace(response.text()), nil
+}
+
+// geminiResponse is a reply of generateContent, or one chunk of streamGenerateContent
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"content"`
+	} `json:"candidates"`
+}
+
+// text joins the parts of the first candidate
+func (r geminiResponse) text() string {
+	if len(r.Candidates) == 0 {
+		return ""
+	}
+	var text strings.Builder
+	for _, part := range r.Candidates[0].Content.Parts {
+		text.WriteString(part.Text)
+	}
+	return text.String()
+}
+
+// geminiRequest builds the body of a Gemini request
+func geminiRequest(p prompt) map[string]interface{} {
+	return map[string]interface{}{
+		"systemInstruction": map[string]interface{}{
+			"parts": []map[string]string{{"text": p.system}},
+		},
+		"contents": []map[string]interface{}{
+			{
+				"role":  "user",
+				"parts": []map[string]string{{"text": p.user}},
+			},
+		},
+		"generationConfig": map[string]interface{}{
+			"temperature":     0.3,
+			"maxOutputTokens": p.maxTokens,
+		},
+	}
+}
+
+// geminiURL returns the URL of a method of the configured Gemini model
+func (a *AIService) geminiURL(method string) string {
+	baseURL := a.config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://generativelanguage.googleapis.com/v1beta"
+	}
+	return fmt.Sprintf("%s/models/%s:%s", baseURL, a.getModel(), method)
+}
+
 // getModel returns the model to use, with defaults for each provider
 func (a *AIService) getModel() string {
 	if a.config.Model != "" {
@@ -293,6 +471,8 @@ func (a *AIService) getModel() string {
 		return "claude-3-sonnet-20240229"
 	case models.ProviderOllama:
 		return "llama2"
+	case models.ProviderGemini:
+		return "gemini-2.5-flash"
 	default:
 		return "gpt-4"
 	}
@@ -301,7 +481,7 @@ func (a *AIService) getModel() string {
 // ValidateConfig checks if the current configuration is valid
 func (a *AIService) ValidateConfig() error {
 	switch a.config.Provider {
-	case models.ProviderOpenAI, models.ProviderClaude:
+	case models.ProviderOpenAI, models.ProviderClaude, models.ProviderGemini:
 		if a.config.APIKey == "" {
 			return fmt.Errorf("API key is required for %s", a.config.Provider)
 		}
@@ -312,6 +492,9 @@ func (a *AIService) ValidateConfig() error {
 	if a.config.Provider == "" {
 		return fmt.Errorf("provider must be specified")
 	}
+	if a.config.TokenBudget != 0 && a.config.TokenBudget < minTokenBudget {
+		return fmt.Errorf("token budget must be at least %d", minTokenBudget)
+	}
 
 	return nil
 }
@@ -319,7 +502,7 @@ func (a *AIService) ValidateConfig() error {
 // ValidateConfigParam validates the given AI configuration without modifying internal state
 func (a *AIService) ValidateConfigParam(config models.AIConfig) error {
 	switch config.Provider {
-	case models.ProviderOpenAI, models.ProviderClaude:
+	case models.ProviderOpenAI, models.ProviderClaude, models.ProviderGemini:
 		if config.APIKey == "" {
 			return fmt.Errorf("API key is required for %s", config.Provider)
 		}
@@ -330,6 +513,9 @@ func (a *AIService) ValidateConfigParam(config models.AIConfig) error {
 	if config.Provider == "" {
 		return fmt.Errorf("provider must be specified")
 	}
+	if config.TokenBudget != 0 && config.TokenBudget < minTokenBudget {
+		return fmt.Errorf("token budget must be at least %d", minTokenBudget)
+	}
 
 	return nil
 }
@@ -0,0 +1,289 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// defaultTokenBudget is the diff size, in estimated tokens, sent when AIConfig sets none
+	defaultTokenBudget = 12000
+	// minTokenBudget keeps the budget large enough for a useful part of a file
+	minTokenBudget = 1000
+	// summaryWorkers bounds the file summaries requested at once
+	summaryWorkers = 4
+	// maxSummaryRequests caps the summary requests of one diff; files beyond it are only listed
+	maxSummaryRequests = 8
+)
+
+// sectionHeader matches the "=== path ===" line starting each file of a joined diff
+var sectionHeader = regexp.MustCompile(`^=== (.+) ===$`)
+
+// diffSection is the diff of one file
+type diffSection struct {
+	name string
+	text string
+}
+
+// TokenBudget returns the number of diff tokens sent in one request
+func (a *AIService) TokenBudget() int {
+	if a.config.TokenBudget > 0 {
+		return a.config.TokenBudget
+	}
+	return defaultTokenBudget
+}
+
+// EstimateTokens approximates the tokens of text for budgeting: about four ASCII
+// characters per token, and a token for each other character such as Chinese
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// CondenseDiff returns diff unchanged when it fits the token budget. Otherwise the smallest
+// files are kept verbatim in half of the budget, and the rest are packed into batches of up
+// to the budget that are summarized, several at a time; files past maxSummaryRequests are
+// only listed by name. diff is either joined "=== path ===" sections or plain git diff output
+func (a *AIService) CondenseDiff(diff string) (string, error) {
+	budget := a.TokenBudget()
+	if EstimateTokens(diff) <= budget {
+		return diff, nil
+	}
+
+	sections := splitSections(diff)
+	verbatim, rest := keepSmallest(sections, budget/2)
+	batches := packBatches(rest, budget)
+	var skipped []diffSection
+	if len(batches) > maxSummaryRequests {
+		for _, batch := range batches[maxSummaryRequests:] {
+			skipped = append(skipped, batch...)
+		}
+		batches = batches[:maxSummaryRequests]
+	}
+
+	// The summaries share the half of the budget the verbatim files leave
+	replyTokens := 100
+	if len(batches) > 0 {
+		replyTokens = max(replyTokens, budget/2/len(batches))
+	}
+	summaries := make([]string, len(batches))
+	errs := make([]error, len(batches))
+	quiet := a.quiet()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, summaryWorkers)
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []diffSection) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			summaries[i], errs[i] = quiet.summarizeBatch(batch, replyTokens)
+		}(i, batch)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString("（diff 过大，较大的文件以摘要代替）\n")
+	for _, section := range verbatim {
+		fmt.Fprintf(&out, "\n=== %s ===\n%s\n", section.name, strings.TrimRight(section.text, "\n"))
+	}
+	if len(summaries) > 0 {
+		out.WriteString("\n=== 其余文件的改动摘要 ===\n")
+		for _, summary := range summaries {
+			fmt.Fprintf(&out, "%s\n", strings.TrimSpace(summary))
+		}
+	}
+	if len(skipped) > 0 {
+		out.WriteString("\n=== 未摘要的文件 ===\n")
+		for _, section := range skipped {
+			fmt.Fprintf(&out, "- %s\n", section.name)
+		}
+	}
+
+	// Long summaries or file lists can still exceed the budget
+	condensed := out.String()
+	if EstimateTokens(condensed) > budget {
+		condensed = splitByTokens(condensed, budget-20)[0] + "\n（以下内容已省略）\n"
+	}
+	return condensed, nil
+}
+
+// keepSmallest picks the smallest sections whose total fits budget to be sent verbatim,
+// both groups keeping the order of the diff
+func keepSmallest(sections []diffSection, budget int) (verbatim, rest []diffSection) {
+	order := make([]int, len(sections))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(sections[order[i]].text) < len(sections[order[j]].text)
+	})
+
+	keep := make([]bool, len(sections))
+	used := 0
+	for _, i := range order {
+		tokens := EstimateTokens(sections[i].text) + EstimateTokens(sections[i].name) + 4
+		if used+tokens > budget {
+			break
+		}
+		keep[i] = true
+		used += tokens
+	}
+	for i, section := range sections {
+		if keep[i] {
+			verbatim = append(verbatim, section)
+		} else {
+			rest = append(rest, section)
+		}
+	}
+	return verbatim, rest
+}
+
+// packBatches groups sections into batches of at most budget tokens, each one summary
+// request; a section larger than budget is cut into parts that each fill a batch
+func packBatches(sections []diffSection, budget int) [][]diffSection {
+	var batches [][]diffSection
+	var current []diffSection
+	size := 0
+	for _, section := range sections {
+		parts := splitByTokens(section.text, budget)
+		for i, part := range parts {
+			name := section.name
+			if len(parts) > 1 {
+				name = fmt.Sprintf("%s（第 %d/%d 部分）", section.name, i+1, len(parts))
+			}
+			tokens := EstimateTokens(part)
+			if size+tokens > budget && len(current) > 0 {
+				batches = append(batches, current)
+				current, size = nil, 0
+			}
+			current = append(current, diffSection{name: name, text: part})
+			size += tokens
+		}
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// summarizeBatch asks for a short summary of each file in one batch of a large diff, in at
+// most maxTokens
+func (a *AIService) summarizeBatch(batch []diffSection, maxTokens int) (string, error) {
+	var user strings.Builder
+	user.WriteString("以下是一个较大 diff 中的部分文件：\n")
+	for _, section := range batch {
+		fmt.Fprintf(&user, "\n=== %s ===\n%s\n", section.name, strings.TrimRight(section.text, "\n"))
+	}
+	return a.complete(prompt{
+		system:    `你是一个代码审查助手。用中文要点列表概括每个文件 diff 的改动内容和目的，每个文件一到两条，以文件名开头，说明新增、删除或修改了什么，不要复述代码，不要有其他解释。`,
+		user:      user.String(),
+		maxTokens: min(maxTokens, 100+80*len(batch)),
+	})
+}
+
+// splitSections splits a diff into files, at "=== path ===" headers when it has them and
+// at "diff --git" lines otherwise
+func splitSections(diff string) []diffSection {
+	lines := strings.Split(diff, "\n")
+	joined := false
+	for _, line := range lines {
+		if sectionHeader.MatchString(line) {
+			joined = true
+			break
+		}
+	}
+
+	// Each file runs from its first line to the next file's
+	var sections []diffSection
+	var starts []int
+	for i, line := range lines {
+		name := ""
+		if joined {
+			if m := sectionHeader.FindStringSubmatch(line); m != nil {
+				name = m[1]
+			}
+		} else if rest, ok := strings.CutPrefix(line, "diff --git "); ok {
+			name = rest
+			if _, b, ok := strings.Cut(rest, " b/"); ok {
+				name = b
+			}
+		}
+		if name != "" {
+			sections = append(sections, diffSection{name: name})
+			starts = append(starts, i)
+		}
+	}
+	// Text before the first file, such as a dependency summary
+	first := len(lines)
+	if len(starts) > 0 {
+		first = starts[0]
+	}
+	if lead := strings.TrimSpace(strings.Join(lines[:first], "\n")); lead != "" {
+		sections = append([]diffSection{{name: "概览", text: lead + "\n"}}, sections...)
+	}
+
+	offset := len(sections) - len(starts)
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if joined {
+			// The header is the section's name, not part of its diff
+			start++
+		}
+		sections[offset+i].text = strings.Join(lines[start:end], "\n")
+	}
+	return sections
+}
+
+// splitByTokens cuts text at line boundaries into parts of at most budget estimated tokens;
+// a single longer line is cut by characters
+func splitByTokens(text string, budget int) []string {
+	if EstimateTokens(text) <= budget {
+		return []string{text}
+	}
+
+	var parts []string
+	var current strings.Builder
+	size := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		tokens := EstimateTokens(line)
+		if size+tokens > budget && current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+			size = 0
+		}
+		for tokens > budget {
+			runes := []rune(line)
+			cut := min(len(runes), budget)
+			parts = append(parts, string(runes[:cut]))
+			line = string(runes[cut:])
+			tokens = EstimateTokens(line)
+		}
+		current.WriteString(line)
+		size += tokens
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
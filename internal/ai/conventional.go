@@ -0,0 +1,34 @@
+package ai
+
+import "regexp"
+
+// conventionalPattern matches a Conventional Commits prefix such as "feat(ui)!: "
+var conventionalPattern = regexp.MustCompile(`^([a-zA-Z]+)(\(([^)]*)\))?(!)?[:：]\s*`)
+
+// ConventionalSubject is a commit subject split into its Conventional Commits parts
+type ConventionalSubject struct {
+	// Type is the type as written, such as "feat" or "Fix"
+	Type string
+	// Scope is the text between the parentheses; HasScope is also true for "feat():"
+	Scope    string
+	HasScope bool
+	// Breaking is set by a "!" before the colon
+	Breaking    bool
+	Description string
+}
+
+// ParseConventional splits a subject such as "feat(ui)!: add x" into its parts
+// ok is false when the subject has no type prefix
+func ParseConventional(subject string) (ConventionalSubject, bool) {
+	m := conventionalPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return ConventionalSubject{}, false
+	}
+	return ConventionalSubject{
+		Type:        m[1],
+		Scope:       m[3],
+		HasScope:    m[2] != "",
+		Breaking:    m[4] != "",
+		Description: subject[len(m[0]):],
+	}, true
+}
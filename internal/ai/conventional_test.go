@@ -0,0 +1,26 @@
+package ai
+
+import "testing"
+
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    ConventionalSubject
+		ok      bool
+	}{
+		{"feat: add x", ConventionalSubject{Type: "feat", Description: "add x"}, true},
+		{"Fix(ui)!: crash", ConventionalSubject{Type: "Fix", Scope: "ui", HasScope: true, Breaking: true, Description: "crash"}, true},
+		{"chore(): empty scope", ConventionalSubject{Type: "chore", HasScope: true, Description: "empty scope"}, true},
+		{"docs：全角冒号", ConventionalSubject{Type: "docs", Description: "全角冒号"}, true},
+		{"refactor:", ConventionalSubject{Type: "refactor"}, true},
+		{"add x", ConventionalSubject{}, false},
+		{"feat add: x", ConventionalSubject{}, false},
+		{"v2: release", ConventionalSubject{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseConventional(tt.subject)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseConventional(%q) = %+v, %v; want %+v, %v", tt.subject, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/trace"
+)
+
+// EmbeddingModel returns the model used to compute embeddings
+func (a *AIService) EmbeddingModel() string {
+	if a.config.EmbeddingModel != "" {
+		return a.config.EmbeddingModel
+	}
+
+	switch a.config.Provider {
+	case models.ProviderOllama:
+		return "nomic-embed-text"
+	default:
+		return "text-embedding-3-small"
+	}
+}
+
+// Embed computes an embedding vector for each text, in order
+// Claude has no embeddings API, so OpenAI-compatible endpoints or Ollama are required
+func (a *AIService) Embed(texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	if a.IsOffline() {
+		return nil, ErrOffline
+	}
+
+	var path string
+	baseURL := a.config.BaseURL
+	switch a.config.Provider {
+	case models.ProviderOpenAI:
+		if a.config.APIKey == "" {
+			return nil, fmt.Errorf("API key is required for %s", a.config.Provider)
+		}
+		if baseURL == "" {
+			baseURL = "https://api.openai.com/v1"
+		}
+		path = "/embeddings"
+	case models.ProviderOllama:
+		if baseURL == "" {
+			baseURL = "http://localhost:11434"
+		}
+		path = "/api/embed"
+	default:
+		return nil, fmt.Errorf("embeddings are not supported by %s", a.config.Provider)
+	}
+
+	span := trace.StartContext(a.context(), trace.KindAI, string(a.config.Provider)+" "+a.EmbeddingModel(), "")
+	vectors, err := a.requestEmbeddings(baseURL+path, texts)
+	span.End(err)
+	return vectors, err
+}
+
+// requestEmbeddings posts the texts to an embeddings endpoint
+// OpenAI replies with data[].embedding, Ollama with embeddings[]
+func (a *AIService) requestEmbeddings(url string, texts []string) ([][]float32, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"model": a.EmbeddingModel(),
+		"input": texts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var response struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	vectors := response.Embeddings
+	if a.config.Provider != models.ProviderOllama {
+		vectors = make([][]float32, len(response.Data))
+		for i, d := range response.Data {
+			vectors[i] = d.Embedding
+		}
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(vectors))
+	}
+	return vectors, nil
+}
@@ -0,0 +1,199 @@
+package ai
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"git-ai-tools/internal/deps"
+	"git-ai-tools/internal/git"
+	"git-ai-tools/internal/models"
+)
+
+// maxHeuristicBodyFiles caps the files listed in the body of a heuristic message
+const maxHeuristicBodyFiles = 20
+
+// Kinds of staged change, with the verb describing each
+const (
+	changeAdd    = "add"
+	changeDelete = "delete"
+	changeRename = "rename"
+	changeModify = "modify"
+)
+
+var changeVerbs = map[string]string{
+	changeAdd:    "添加",
+	changeDelete: "删除",
+	changeRename: "重命名",
+	changeModify: "更新",
+}
+
+// changeKind classifies a staged change by its status description
+func changeKind(status string) string {
+	switch {
+	case strings.HasPrefix(status, "Added"), strings.HasPrefix(status, "Copied"):
+		return changeAdd
+	case strings.HasPrefix(status, "Deleted"):
+		return changeDelete
+	case strings.HasPrefix(status, "Renamed"):
+		return changeRename
+	default:
+		return changeModify
+	}
+}
+
+// HeuristicCommitMessage writes a commit message from the staged files alone, for when AI
+// is unavailable or as a free default: changes touching only tests, docs, CI or dependency
+// manifests get their own type, otherwise the type and verb follow the kinds of change
+// diffs holds each file's diff, in the order of changes, and may be nil; it is only read
+// to describe dependency bumps
+func HeuristicCommitMessage(changes []models.FileChange, diffs []string) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	kinds := map[string]int{}
+	paths := make([]string, len(changes))
+	for i, c := range changes {
+		kinds[changeKind(c.Status)]++
+		paths[i] = c.Path
+	}
+
+	if allFiles(changes, deps.IsManifest) {
+		bumps, _ := deps.Summarize(paths, diffs)
+		return dependencyMessage(bumps)
+	}
+
+	typ, verb := "chore", changeVerbs[changeModify]
+	if len(kinds) == 1 {
+		for kind := range kinds {
+			verb = changeVerbs[kind]
+			switch kind {
+			case changeAdd:
+				typ = "feat"
+			case changeRename:
+				typ = "refactor"
+			}
+		}
+	}
+	switch {
+	case allFiles(changes, git.IsTestFile):
+		typ = "test"
+	case allFiles(changes, isDocFile):
+		typ = "docs"
+	case allFiles(changes, isCIFile):
+		typ = "ci"
+	}
+
+	message := fmt.Sprintf("%s: %s%s", typ, verb, describeFiles(paths))
+	if len(changes) == 1 {
+		return message
+	}
+
+	var body strings.Builder
+	for i, c := range changes {
+		if i == maxHeuristicBodyFiles {
+			fmt.Fprintf(&body, "- 以及其他 %d 个文件\n", len(changes)-i)
+			break
+		}
+		if c.OldPath != "" {
+			fmt.Fprintf(&body, "- %s %s -> %s\n", changeVerbs[changeKind(c.Status)], c.OldPath, c.Path)
+		} else {
+			fmt.Fprintf(&body, "- %s %s\n", changeVerbs[changeKind(c.Status)], c.Path)
+		}
+	}
+	return message + "\n\n" + strings.TrimRight(body.String(), "\n")
+}
+
+// dependencyMessage describes dependency bumps, naming the package when there is only one
+func dependencyMessage(bumps []models.DependencyBump) string {
+	if len(bumps) == 0 {
+		return "chore(deps): 更新依赖"
+	}
+	if len(bumps) == 1 {
+		return "chore(deps): " + describeBump(bumps[0])
+	}
+
+	var body strings.Builder
+	for _, b := range bumps {
+		fmt.Fprintf(&body, "- %s\n", describeBump(b))
+	}
+	return fmt.Sprintf("chore(deps): 更新 %d 个依赖\n\n%s", len(bumps), strings.TrimRight(body.String(), "\n"))
+}
+
+// describeBump phrases a single dependency change
+func describeBump(b models.DependencyBump) string {
+	switch {
+	case b.From == "":
+		return fmt.Sprintf("添加依赖 %s %s", b.Name, b.To)
+	case b.To == "":
+		return fmt.Sprintf("移除依赖 %s", b.Name)
+	default:
+		return fmt.Sprintf("升级 %s 从 %s 到 %s", b.Name, b.From, b.To)
+	}
+}
+
+// allFiles reports whether every changed path matches
+func allFiles(changes []models.FileChange, match func(string) bool) bool {
+	for _, c := range changes {
+		if !match(c.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDocFile reports whether a path is documentation
+func isDocFile(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".md", ".markdown", ".rst", ".adoc", ".txt":
+		return true
+	}
+	base := strings.ToUpper(path.Base(p))
+	for _, name := range []string{"README", "CHANGELOG", "LICENSE", "CONTRIBUTING", "AUTHORS"} {
+		if strings.HasPrefix(base, name) {
+			return true
+		}
+	}
+	return strings.HasPrefix(p, "docs/") || strings.HasPrefix(p, "doc/")
+}
+
+// isCIFile reports whether a path configures continuous integration
+func isCIFile(p string) bool {
+	switch path.Base(p) {
+	case ".gitlab-ci.yml", ".travis.yml", "Jenkinsfile", "azure-pipelines.yml", "bitbucket-pipelines.yml":
+		return true
+	}
+	return strings.HasPrefix(p, ".github/workflows/") || strings.HasPrefix(p, ".circleci/")
+}
+
+// describeFiles names a few files by their base names, or counts them under their
+// common directory
+func describeFiles(paths []string) string {
+	if len(paths) <= 3 {
+		names := make([]string, len(paths))
+		for i, p := range paths {
+			names[i] = path.Base(p)
+		}
+		return " " + strings.Join(names, "、")
+	}
+
+	if dir := commonDir(paths); dir != "" {
+		return fmt.Sprintf(" %s 下的 %d 个文件", dir, len(paths))
+	}
+	return fmt.Sprintf(" %d 个文件", len(paths))
+}
+
+// commonDir returns the deepest directory containing all paths, "" for the repository root
+func commonDir(paths []string) string {
+	dir := path.Dir(paths[0])
+	for _, p := range paths[1:] {
+		for dir != "." && !strings.HasPrefix(p, dir+"/") {
+			dir = path.Dir(dir)
+		}
+	}
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
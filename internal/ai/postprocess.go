@@ -0,0 +1,227 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"git-ai-tools/internal/models"
+)
+
+// fencePattern matches a markdown code fence line such as "```text"
+var fencePattern = regexp.MustCompile("^```[a-zA-Z]*$")
+
+// typeEmoji maps commit types to the emoji inserted when emoji mapping is enabled
+var typeEmoji = map[string]string{
+	"feat":     "✨",
+	"fix":      "🐛",
+	"docs":     "📝",
+	"style":    "💄",
+	"refactor": "♻️",
+	"perf":     "⚡",
+	"test":     "✅",
+	"build":    "📦",
+	"ci":       "👷",
+	"chore":    "🔧",
+	"revert":   "⏪",
+}
+
+// PostProcessMessage cleans up an AI generated commit message according to the style
+func PostProcessMessage(message string, style models.MessageStyle) string {
+	if !style.Enabled {
+		return strings.TrimSpace(message)
+	}
+
+	message = stripDecorations(message)
+	if message == "" {
+		return ""
+	}
+
+	lines := strings.Split(message, "\n")
+	subject := strings.TrimSpace(lines[0])
+	var body []string
+	if len(lines) > 1 {
+		body = lines[1:]
+	}
+
+	subject = normalizeSubject(subject, style)
+
+	bodyText := strings.TrimSpace(strings.Join(body, "\n"))
+	if bodyText == "" {
+		return subject
+	}
+	if style.BodyWrapWidth > 0 {
+		bodyText = wrapBody(bodyText, style.BodyWrapWidth)
+	}
+	return subject + "\n\n" + bodyText
+}
+
+// stripDecorations removes markdown fences and quotes wrapped around the message
+func stripDecorations(message string) string {
+	message = strings.TrimSpace(message)
+
+	var kept []string
+	for _, line := range strings.Split(message, "\n") {
+		if fencePattern.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t\r"))
+	}
+	message = strings.TrimSpace(strings.Join(kept, "\n"))
+
+	pairs := [][2]string{{`"`, `"`}, {"'", "'"}, {"`", "`"}, {"“", "”"}, {"「", "」"}}
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, p := range pairs {
+			if len(message) > len(p[0])+len(p[1]) && strings.HasPrefix(message, p[0]) && strings.HasSuffix(message, p[1]) {
+				message = strings.TrimSpace(message[len(p[0]) : len(message)-len(p[1])])
+				trimmed = true
+			}
+		}
+	}
+	return message
+}
+
+// normalizeSubject enforces the type prefix, emoji and length of the subject line
+func normalizeSubject(subject string, style models.MessageStyle) string {
+	subject = strings.TrimLeft(subject, "#*- ")
+	subject = strings.TrimRight(subject, "。.")
+
+	prefix := ""
+	text := subject
+	if c, ok := ParseConventional(subject); ok {
+		prefix = strings.ToLower(c.Type)
+		if c.HasScope {
+			prefix += "(" + c.Scope + ")"
+		}
+		if c.Breaking {
+			prefix += "!"
+		}
+		text = c.Description
+	} else if style.DefaultType != "" {
+		prefix = style.DefaultType
+	}
+
+	if style.Emoji && prefix != "" {
+		typeName := prefix
+		if idx := strings.IndexAny(typeName, "(!"); idx >= 0 {
+			typeName = typeName[:idx]
+		}
+		if emoji, ok := typeEmoji[typeName]; ok && !strings.HasPrefix(text, emoji) {
+			text = emoji + " " + text
+		}
+	}
+
+	keep := 0
+	if prefix != "" {
+		subject = prefix + ": " + text
+		keep = utf8.RuneCountInString(prefix) + 2
+	} else {
+		subject = text
+	}
+
+	if style.MaxSubjectLength > 0 {
+		subject = truncateSubject(subject, style.MaxSubjectLength, keep)
+	}
+	return subject
+}
+
+// truncateSubject shortens subject to at most limit characters, cutting at the last space
+// after the first keep characters; text without such a space, like Chinese, is cut at limit
+func truncateSubject(subject string, limit, keep int) string {
+	runes := []rune(subject)
+	if len(runes) <= limit {
+		return subject
+	}
+	cut := limit
+	for i := limit; i > keep; i-- {
+		if runes[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimSpace(string(runes[:cut]))
+}
+
+// wrapBody wraps every paragraph line of the body at the given width
+// List markers keep their hanging indent on continuation lines
+func wrapBody(body string, width int) string {
+	var out []string
+	for _, line := range strings.Split(body, "\n") {
+		out = append(out, wrapLine(line, width)...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// wrapLine splits a single line at word boundaries, or at the width for text without spaces
+func wrapLine(line string, width int) []string {
+	if utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+
+	indent := ""
+	trimmed := strings.TrimLeft(line, " ")
+	if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
+		indent = strings.Repeat(" ", len(line)-len(trimmed)+2)
+	}
+
+	if utf8.RuneCountInString(indent) >= width {
+		indent = ""
+	}
+
+	// Never break inside the leading indent, otherwise the line would not shrink
+	lead := len(line) - len(trimmed)
+	if indent != "" {
+		lead = len(indent)
+	}
+
+	var lines []string
+	runes := []rune(line)
+	for len(runes) > width {
+		cut := width
+		for i := width; i > lead; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(indent + strings.TrimLeft(string(runes[cut:]), " "))
+		lead = len(indent)
+	}
+	if len(runes) > 0 {
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// CheckMessage reports the ways a commit message violates the style
+func CheckMessage(message string, style models.MessageStyle) []string {
+	var problems []string
+	if !style.Enabled {
+		return problems
+	}
+
+	subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0])
+	if subject == "" {
+		return problems
+	}
+	if style.MaxSubjectLength > 0 && utf8.RuneCountInString(subject) > style.MaxSubjectLength {
+		problems = append(problems, fmt.Sprintf("subject is longer than %d characters", style.MaxSubjectLength))
+	}
+	if _, ok := ParseConventional(subject); style.RequireType && !ok {
+		problems = append(problems, "subject is missing a type prefix such as feat: or fix:")
+	}
+	return problems
+}
+
+// InsertScope adds scope to a typed subject without one, turning "feat: x" into
+// "feat(ui): x"; other messages are returned unchanged
+func InsertScope(message, scope string) string {
+	c, ok := ParseConventional(message)
+	if scope == "" || !ok || c.HasScope {
+		return message
+	}
+	return c.Type + "(" + scope + ")" + message[len(c.Type):]
+}
@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+
+	"git-ai-tools/internal/models"
+)
+
+func TestPostProcessMessage(t *testing.T) {
+	style := models.MessageStyle{Enabled: true}
+	typed := models.MessageStyle{Enabled: true, RequireType: true}
+
+	tests := []struct {
+		name    string
+		message string
+		style   models.MessageStyle
+		want    string
+	}{
+		{"disabled only trims", "  ```\nfeat: x\n```  ", models.MessageStyle{}, "```\nfeat: x\n```"},
+		{"code fence", "```text\nfeat: add login\n```", style, "feat: add login"},
+		{"fence around body", "```\nfix: crash\n\ndetails here\n```", style, "fix: crash\n\ndetails here"},
+		{"double quotes", `"feat: add login"`, style, "feat: add login"},
+		{"nested quotes", "\"`fix: typo`\"", style, "fix: typo"},
+		{"chinese quotes", "“feat: 添加登录”", style, "feat: 添加登录"},
+		{"corner brackets", "「fix: 修复崩溃」", style, "fix: 修复崩溃"},
+		{"markdown bullet and period", "- feat: add login.", style, "feat: add login"},
+		{"heading marker", "## fix: crash", style, "fix: crash"},
+		{"type is lowercased", "FEAT: add login", style, "feat: add login"},
+		{"scope and breaking kept", "Feat(api)!: drop v1", style, "feat(api)!: drop v1"},
+		{"full-width colon", "fix：修复空指针", style, "fix: 修复空指针"},
+		{"chinese full stop", "feat: 添加登录功能。", style, "feat: 添加登录功能"},
+		{"missing type gets default", "add login", models.MessageStyle{Enabled: true, RequireType: true, DefaultType: "feat"}, "feat: add login"},
+		{"missing type without a default is kept", "更新依赖", typed, "更新依赖"},
+		{"missing type allowed", "update deps", style, "update deps"},
+		{"emoji", "feat(ui): add button", models.MessageStyle{Enabled: true, Emoji: true}, "feat(ui): ✨ add button"},
+		{"emoji not repeated", "fix: 🐛 crash", models.MessageStyle{Enabled: true, Emoji: true}, "fix: 🐛 crash"},
+		{"subject truncated", "feat: add a very long subject", models.MessageStyle{Enabled: true, MaxSubjectLength: 12}, "feat: add a"},
+		{"subject truncated at a word boundary", "feat: add a very long subject", models.MessageStyle{Enabled: true, MaxSubjectLength: 15}, "feat: add a"},
+		{"cut falling on a space", "feat: add a very long subject", models.MessageStyle{Enabled: true, MaxSubjectLength: 16}, "feat: add a very"},
+		{"type prefix kept whole", "feat: internationalization", models.MessageStyle{Enabled: true, MaxSubjectLength: 12}, "feat: intern"},
+		{"cjk subject truncated by runes", "feat: 添加用户登录和注册功能", models.MessageStyle{Enabled: true, MaxSubjectLength: 10}, "feat: 添加用户"},
+		{"cjk subject within limit", "feat: 添加登录", models.MessageStyle{Enabled: true, MaxSubjectLength: 10}, "feat: 添加登录"},
+		{"body wrapped", "fix: crash\n\none two three four five", models.MessageStyle{Enabled: true, BodyWrapWidth: 10}, "fix: crash\n\none two\nthree four\nfive"},
+		{"list item keeps hanging indent", "fix: crash\n\n- one two three four", models.MessageStyle{Enabled: true, BodyWrapWidth: 10}, "fix: crash\n\n- one two\n  three\n  four"},
+		{"cjk body wrapped by runes", "feat: 登录\n\n添加用户登录和注册功能", models.MessageStyle{Enabled: true, BodyWrapWidth: 4}, "feat: 登录\n\n添加用户\n登录和注\n册功能"},
+		{"empty after stripping", "```\n```", style, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PostProcessMessage(tt.message, tt.style); got != tt.want {
+				t.Errorf("PostProcessMessage(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		style   models.MessageStyle
+		want    []string
+	}{
+		{"disabled", "whatever", models.MessageStyle{RequireType: true}, nil},
+		{"valid", "feat(ui): add button\n\nbody", models.MessageStyle{Enabled: true, RequireType: true, MaxSubjectLength: 50}, nil},
+		{"empty", "  ", models.MessageStyle{Enabled: true, RequireType: true}, nil},
+		{"missing type", "add button", models.MessageStyle{Enabled: true, RequireType: true},
+			[]string{"subject is missing a type prefix such as feat: or fix:"}},
+		{"full-width colon counts as type", "fix：修复崩溃", models.MessageStyle{Enabled: true, RequireType: true}, nil},
+		{"too long", "feat: add a button", models.MessageStyle{Enabled: true, MaxSubjectLength: 10},
+			[]string{"subject is longer than 10 characters"}},
+		{"cjk counted by runes", "feat: 添加按钮", models.MessageStyle{Enabled: true, MaxSubjectLength: 10}, nil},
+		{"only subject checked", "feat: x\n\n" + "a very long body line that is over the limit", models.MessageStyle{Enabled: true, MaxSubjectLength: 10}, nil},
+		{"both problems", "添加一个很长很长的按钮", models.MessageStyle{Enabled: true, RequireType: true, MaxSubjectLength: 5},
+			[]string{"subject is longer than 5 characters", "subject is missing a type prefix such as feat: or fix:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckMessage(tt.message, tt.style); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckMessage(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertScope(t *testing.T) {
+	tests := []struct {
+		message, scope, want string
+	}{
+		{"feat: add button", "ui", "feat(ui): add button"},
+		{"fix!: drop v1", "api", "fix(api)!: drop v1"},
+		{"fix：修复崩溃", "core", "fix(core)：修复崩溃"},
+		{"feat(api): keep scope", "ui", "feat(api): keep scope"},
+		{"no type here", "ui", "no type here"},
+		{"feat: no scope given", "", "feat: no scope given"},
+		{"feat: x\n\nbody", "ui", "feat(ui): x\n\nbody"},
+	}
+	for _, tt := range tests {
+		if got := InsertScope(tt.message, tt.scope); got != tt.want {
+			t.Errorf("InsertScope(%q, %q) = %q, want %q", tt.message, tt.scope, got, tt.want)
+		}
+	}
+}
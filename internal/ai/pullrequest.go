@@ -0,0 +1,75 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// jsonObjectPattern finds the outermost JSON object in a model reply
+var jsonObjectPattern = regexp.MustCompile(`(?s)\{.*\}`)
+
+// pullRequestSystemPrompt instructs the model to describe a branch as a pull request
+const pullRequestSystemPrompt = `你是一个代码审查助手，负责为拉取请求撰写标题和描述。
+根据提交列表和代码改动：
+1. 标题简洁概括整个分支的目的（不超过 70 字），不要以句号结尾
+2. 描述使用 Markdown，包含"## 概述"（改动目的）、"## 主要改动"（要点列表）和"## 注意事项"（破坏性变更、迁移或需要重点审查的地方，没有则省略该节）
+3. 使用中文编写
+
+只返回一个 JSON 对象：{"title": "...", "description": "..."}，不要有其他解释。`
+
+// GeneratePullRequestDescription writes a pull request title and Markdown description from
+// the commits of a branch and its cumulative diff, summarizing a diff over the token budget
+func (a *AIService) GeneratePullRequestDescription(commits []models.BranchCommit, diff string) (*models.PullRequestDescription, error) {
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits to describe")
+	}
+
+	var user strings.Builder
+	user.WriteString("提交列表（从旧到新）：\n")
+	for _, c := range commits {
+		fmt.Fprintf(&user, "- %s %s\n", c.ShortHash, c.Subject)
+		if c.Body != "" {
+			for _, line := range strings.Split(c.Body, "\n") {
+				fmt.Fprintf(&user, "  %s\n", line)
+			}
+		}
+	}
+
+	diff, err := a.CondenseDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&user, "\n代码改动：\n\n%s", diff)
+
+	reply, err := a.complete(prompt{
+		system:    pullRequestSystemPrompt,
+		user:      user.String(),
+		maxTokens: 1200,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePullRequestReply(reply), nil
+}
+
+// parsePullRequestReply reads the JSON reply of the model, falling back to taking the first
+// line as the title when the model answered in plain text
+func parsePullRequestReply(reply string) *models.PullRequestDescription {
+	var result models.PullRequestDescription
+	if err := json.Unmarshal([]byte(jsonObjectPattern.FindString(reply)), &result); err == nil && result.Title != "" {
+		result.Title = strings.TrimSpace(result.Title)
+		result.Description = strings.TrimSpace(result.Description)
+		return &result
+	}
+
+	reply = strings.TrimSpace(reply)
+	title, description, _ := strings.Cut(reply, "\n")
+	return &models.PullRequestDescription{
+		Title:       strings.TrimSpace(strings.TrimLeft(title, "# ")),
+		Description: strings.TrimSpace(description),
+	}
+}
@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"git-ai-tools/internal/models"
+)
+
+// defaultMaxSubjectLength is the subject length scored against when the style sets none
+const defaultMaxSubjectLength = 72
+
+// vagueSubjects are whole subjects that say nothing about the change
+var vagueSubjects = map[string]bool{
+	"update": true, "updates": true, "fix": true, "fixes": true, "wip": true, "misc": true,
+	"changes": true, "change": true, "stuff": true, "tmp": true, "test": true, "commit": true,
+	"修改": true, "更新": true, "修复": true, "提交": true, "临时": true,
+}
+
+// jsonArrayPattern finds the first JSON array in a model reply
+var jsonArrayPattern = regexp.MustCompile(`(?s)\[.*?\]`)
+
+// ScoreMessage rates a commit subject from 0 to 100 against the Conventional Commits
+// convention and the style's subject length, returning the problems found
+// The type prefix is always checked, since it is what the score measures
+func ScoreMessage(subject string, style models.MessageStyle) (int, []string) {
+	subject = strings.TrimSpace(subject)
+	problems := []string{}
+	if subject == "" {
+		return 0, []string{"subject is empty"}
+	}
+
+	score := 100
+	maxLength := style.MaxSubjectLength
+	if maxLength <= 0 {
+		maxLength = defaultMaxSubjectLength
+	}
+	if utf8.RuneCountInString(subject) > maxLength {
+		problems = append(problems, fmt.Sprintf("subject is longer than %d characters", maxLength))
+		score -= 20
+	}
+
+	description := subject
+	if c, ok := ParseConventional(subject); ok {
+		description = strings.TrimSpace(c.Description)
+	} else {
+		problems = append(problems, "subject is missing a type prefix such as feat: or fix:")
+		score -= 30
+	}
+
+	switch {
+	case vagueSubjects[strings.ToLower(strings.Trim(description, ". "))]:
+		problems = append(problems, "subject does not describe the change")
+		score -= 40
+	case utf8.RuneCountInString(description) < 8:
+		problems = append(problems, "subject is too short to describe the change")
+		score -= 20
+	}
+	if strings.HasSuffix(subject, ".") || strings.HasSuffix(subject, "。") {
+		problems = append(problems, "subject ends with a period")
+		score -= 10
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	return score, problems
+}
+
+// HasTypePrefix reports whether a subject starts with a Conventional Commits type
+func HasTypePrefix(subject string) bool {
+	_, ok := ParseConventional(strings.TrimSpace(subject))
+	return ok
+}
+
+// RateDescriptiveness asks the model how well each subject describes its change, from 1
+// (meaningless) to 5 (clear and specific); subjects are rated in a single request
+func (a *AIService) RateDescriptiveness(subjects []string) ([]int, error) {
+	if len(subjects) == 0 {
+		return []int{}, nil
+	}
+
+	var list strings.Builder
+	for i, subject := range subjects {
+		fmt.Fprintf(&list, "%d. %s\n", i+1, subject)
+	}
+	reply, err := a.complete(prompt{
+		system: `你是一个代码审查助手，负责评估 git 提交信息的描述质量。
+为每条提交标题打分（1-5 分）：1 表示毫无信息量（如 "update"、"fix"），5 表示清晰具体地说明了改动内容和原因。
+只返回一个 JSON 整数数组，顺序与输入一致，不要有其他解释。`,
+		user:      "请为以下提交标题打分：\n\n" + list.String(),
+		maxTokens: 16 + 4*len(subjects),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var scores []int
+	if err := json.Unmarshal([]byte(jsonArrayPattern.FindString(reply)), &scores); err != nil {
+		return nil, fmt.Errorf("failed to parse ratings: %w", err)
+	}
+	if len(scores) != len(subjects) {
+		return nil, fmt.Errorf("expected %d ratings, got %d", len(subjects), len(scores))
+	}
+	for i, score := range scores {
+		scores[i] = min(max(score, 1), 5)
+	}
+	return scores, nil
+}
@@ -0,0 +1,31 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateReleaseNotes rewrites a changelog grouped by commit type into release notes for
+// users, returning Markdown
+func (a *AIService) GenerateReleaseNotes(changelog string) (string, error) {
+	if strings.TrimSpace(changelog) == "" {
+		return "", fmt.Errorf("changelog is empty")
+	}
+	notes, err := a.complete(prompt{
+		system: `你是一个发布说明撰写助手，负责把按提交类型分组的变更日志整理成面向用户的发布说明。
+要求：
+1. 使用 Markdown，保留原有的版本标题和分组结构，破坏性变更放在最前面并说明迁移方式
+2. 合并重复或相关的条目，用用户能理解的语言描述改动带来的效果，而不是实现细节
+3. 省略对用户没有影响的条目（如测试、CI、代码格式），除非没有其他改动
+4. 保留条目末尾的提交哈希
+5. 使用中文编写
+
+只返回发布说明本身，不要有其他解释。`,
+		user:      "请整理以下变更日志：\n\n" + changelog,
+		maxTokens: 1500,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(notes), nil
+}
@@ -0,0 +1,291 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// maxStreamLine bounds a single line of a streamed reply
+const maxStreamLine = 1 << 20
+
+// streamConfig directs the reply of each request to a callback as it is generated
+type streamConfig struct {
+	ctx     context.Context
+	onToken func(string)
+}
+
+// streamParser reads one line of a streamed reply, returning its text and whether the
+// reply is complete
+type streamParser func(line string) (text string, done bool, err error)
+
+// Streaming returns a copy of the service whose requests stream their reply to onToken
+// and are abandoned when ctx is cancelled
+func (a *AIService) Streaming(ctx context.Context, onToken func(string)) *AIService {
+	s := &AIService{
+		config: a.config,
+		client: a.client,
+		stream: &streamConfig{ctx: ctx, onToken: onToken},
+	}
+	s.offline.Store(a.IsOffline())
+	return s
+}
+
+// quiet returns a copy of the service that does not pass on streamed replies, for
+// intermediate requests whose text is not shown; cancellation still applies
+func (a *AIService) quiet() *AIService {
+	if a.stream == nil {
+		return a
+	}
+	return a.Streaming(a.stream.ctx, func(string) {})
+}
+
+// context returns the context of the streamed request, which carries the user action
+// it belongs to, or the background context when not streaming
+func (a *AIService) context() context.Context {
+	if a.stream == nil {
+		return context.Background()
+	}
+	return a.stream.ctx
+}
+
+// generateStream sends the prompt with streaming on and passes each piece of the reply
+// to the stream's callback, returning the whole reply
+func (a *AIService) generateStream(p prompt) (string, error) {
+	req, parse, err := a.streamRequest(p)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		if a.stream.ctx.Err() != nil {
+			return "", a.stream.ctx.Err()
+		}
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLine)
+	for scanner.Scan() {
+		text, done, err := parse(scanner.Text())
+		if err != nil {
+			return "", err
+		}
+		if text != "" {
+			reply.WriteString(text)
+			a.stream.onToken(text)
+		}
+		if done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if a.stream.ctx.Err() != nil {
+			return "", a.stream.ctx.Err()
+		}
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return strings.TrimSpace(reply.String()), nil
+}
+
+// streamRequest builds the streaming request for the configured provider and the parser
+// of its reply: server-sent events for OpenAI, Claude and Gemini, JSON lines for Ollama
+func (a *AIService) streamRequest(p prompt) (*http.Request, streamParser, error) {
+	var url string
+	var body map[string]interface{}
+	var parse streamParser
+	baseURL := a.config.BaseURL
+
+	switch a.config.Provider {
+	case models.ProviderOpenAI:
+		if baseURL == "" {
+			baseURL = "https://api.openai.com/v1"
+		}
+		url = baseURL + "/chat/completions"
+		body = map[string]interface{}{
+			"model": a.getModel(),
+			"messages": []map[string]string{
+				{"role": "system", "content": p.system},
+				{"role": "user", "content": p.user},
+			},
+			"temperature": 0.3,
+			"max_tokens":  p.maxTokens,
+			"stream":      true,
+		}
+		parse = parseOpenAIEvent
+	case models.ProviderClaude:
+		if baseURL == "" {
+			baseURL = "https://api.anthropic.com/v1"
+		}
+		url = baseURL + "/messages"
+		body = map[string]interface{}{
+			"model":      a.getModel(),
+			"max_tokens": p.maxTokens,
+			"system":     p.system,
+			"messages": []map[string]string{
+				{"role": "user", "content": p.user},
+			},
+			"stream": true,
+		}
+		parse = parseClaudeEvent
+	case models.ProviderOllama:
+		if baseURL == "" {
+			baseURL = "http://localhost:11434"
+		}
+		url = baseURL + "/api/generate"
+		body = map[string]interface{}{
+			"model":  a.getModel(),
+			"system": p.system,
+			"prompt": p.user,
+			"stream": true,
+			"options": map[string]interface{}{
+				"num_predict": p.maxTokens,
+			},
+		}
+		parse = parseOllamaLine
+	case models.ProviderGemini:
+		url = a.geminiURL("streamGenerateContent") + "?alt=sse"
+		body = geminiRequest(p)
+		parse = parseGeminiEvent
+	default:
+		return nil, nil, fmt.Errorf("unsupported AI provider: %s", a.config.Provider)
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(a.stream.ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	switch a.config.Provider {
+	case models.ProviderOpenAI:
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	case models.ProviderClaude:
+		req.Header.Set("x-api-key", a.config.APIKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+	case models.ProviderGemini:
+		req.Header.Set("x-goog-api-key", a.config.APIKey)
+	}
+	return req, parse, nil
+}
+
+// eventData returns the payload of a server-sent "data:" line
+func eventData(line string) (string, bool) {
+	data, ok := strings.CutPrefix(line, "data:")
+	return strings.TrimSpace(data), ok
+}
+
+// parseOpenAIEvent reads a chat completion chunk
+func parseOpenAIEvent(line string) (string, bool, error) {
+	data, ok := eventData(line)
+	if !ok || data == "" {
+		return "", false, nil
+	}
+	if data == "[DONE]" {
+		return "", true, nil
+	}
+
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return "", false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if chunk.Error != nil {
+		return "", false, fmt.Errorf("API error: %s", chunk.Error.Message)
+	}
+	if len(chunk.Choices) == 0 {
+		return "", false, nil
+	}
+	return chunk.Choices[0].Delta.Content, false, nil
+}
+
+// parseClaudeEvent reads a message stream event
+func parseClaudeEvent(line string) (string, bool, error) {
+	data, ok := eventData(line)
+	if !ok || data == "" {
+		return "", false, nil
+	}
+
+	var event struct {
+		Type  string `json:"type"`
+		Delta struct {
+			Text string `json:"text"`
+		} `json:"delta"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return "", false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	switch event.Type {
+	case "content_block_delta":
+		return event.Delta.Text, false, nil
+	case "message_stop":
+		return "", true, nil
+	case "error":
+		return "", false, fmt.Errorf("API error: %s", event.Error.Message)
+	}
+	return "", false, nil
+}
+
+// parseGeminiEvent reads a streamed generateContent chunk; the stream ends with the response
+func parseGeminiEvent(line string) (string, bool, error) {
+	data, ok := eventData(line)
+	if !ok || data == "" {
+		return "", false, nil
+	}
+
+	var chunk geminiResponse
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return "", false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return chunk.text(), false, nil
+}
+
+// parseOllamaLine reads a line of a streamed generation
+func parseOllamaLine(line string) (string, bool, error) {
+	if strings.TrimSpace(line) == "" {
+		return "", false, nil
+	}
+
+	var chunk struct {
+		Response string `json:"response"`
+		Done     bool   `json:"done"`
+		Error    string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+		return "", false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if chunk.Error != "" {
+		return "", false, fmt.Errorf("API error: %s", chunk.Error)
+	}
+	return chunk.Response, chunk.Done, nil
+}
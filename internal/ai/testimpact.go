@@ -0,0 +1,36 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SuggestTests asks the model which of the related tests matter for the staged diff and
+// what coverage is missing, particularly for the untested files
+func (a *AIService) SuggestTests(diff string, tests, untested []string) (string, error) {
+	var user strings.Builder
+	user.WriteString("暂存的更改：\n")
+	user.WriteString(diff)
+	if len(tests) > 0 {
+		user.WriteString("\n\n相关的现有测试文件：\n")
+		for _, t := range tests {
+			fmt.Fprintf(&user, "- %s\n", t)
+		}
+	}
+	if len(untested) > 0 {
+		user.WriteString("\n没有找到对应测试的文件：\n")
+		for _, u := range untested {
+			fmt.Fprintf(&user, "- %s\n", u)
+		}
+	}
+
+	return a.complete(prompt{
+		system: `你是一个测试助手，负责在提交前评估改动的测试影响。
+根据 diff 和相关测试文件：
+1. 指出最应该运行的测试文件以及原因
+2. 针对没有测试覆盖的改动，给出应补充的测试用例（说明要验证的行为和边界情况）
+回答简洁，使用列表，不要重复 diff 内容。`,
+		user:      user.String(),
+		maxTokens: 600,
+	})
+}
@@ -0,0 +1,187 @@
+package avatar
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/database"
+	"git-ai-tools/internal/forge"
+	"git-ai-tools/internal/models"
+)
+
+const (
+	// cacheTTL is how long a resolved avatar is reused before asking the network again
+	cacheTTL = 7 * 24 * time.Hour
+	// maxLookupWorkers bounds the avatar lookups running at once
+	maxLookupWorkers = 8
+)
+
+// noreplyPattern matches GitHub noreply addresses such as 123+login@users.noreply.github.com
+var noreplyPattern = regexp.MustCompile(`^(?:(\d+)\+)?([^@]+)@users\.noreply\.github\.com$`)
+
+// AvatarService resolves author avatars and caches them in the database
+type AvatarService struct {
+	client *http.Client
+}
+
+// NewAvatarService creates a new AvatarService instance
+func NewAvatarService() *AvatarService {
+	return &AvatarService{
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetAvatars returns an avatar URL for every email, using the cache where possible
+// A GitHub token, when given, is used to look up accounts by their public email
+// Offline, only cached avatars are returned, however old, and nothing is looked up
+func (s *AvatarService) GetAvatars(emails []string, github *models.ForgeConfig, offline bool) map[string]string {
+	result := make(map[string]string, len(emails))
+
+	var cached []models.AvatarDB
+	normalized := make([]string, 0, len(emails))
+	for _, email := range emails {
+		normalized = append(normalized, normalizeEmail(email))
+	}
+	database.GetDB().Where("email IN ?", normalized).Find(&cached)
+
+	fresh := make(map[string]string)
+	for _, a := range cached {
+		if offline || time.Since(a.UpdatedAt) < cacheTTL {
+			fresh[a.Email] = a.URL
+		}
+	}
+
+	var missing []string
+	for _, key := range normalized {
+		if _, ok := fresh[key]; !ok && key != "" && !offline {
+			fresh[key] = ""
+			missing = append(missing, key)
+		}
+	}
+	for key, avatarURL := range s.resolveAll(missing, github) {
+		fresh[key] = avatarURL
+	}
+
+	for i, email := range emails {
+		if key := normalized[i]; key != "" {
+			result[email] = fresh[key]
+		}
+	}
+	return result
+}
+
+// resolveAll resolves the emails in parallel and caches the results
+// Lookups that failed fall back to Gravatar without being cached, so they are retried
+func (s *AvatarService) resolveAll(emails []string, github *models.ForgeConfig) map[string]string {
+	urls := make([]string, len(emails))
+	workers := min(maxLookupWorkers, len(emails))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				avatarURL, source, err := s.resolve(emails[i], github)
+				urls[i] = avatarURL
+				if err != nil {
+					continue
+				}
+				database.GetDB().Save(&models.AvatarDB{
+					Email:     emails[i],
+					URL:       avatarURL,
+					Source:    source,
+					UpdatedAt: time.Now(),
+				})
+			}
+		}()
+	}
+	for i := range emails {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	result := make(map[string]string, len(emails))
+	for i, email := range emails {
+		result[email] = urls[i]
+	}
+	return result
+}
+
+// resolve finds the best avatar for an email: GitHub noreply, GitHub account, then Gravatar
+// The error reports a failed GitHub lookup; the Gravatar fallback is still returned
+func (s *AvatarService) resolve(email string, github *models.ForgeConfig) (string, string, error) {
+	if m := noreplyPattern.FindStringSubmatch(email); m != nil {
+		if m[1] != "" {
+			return "https://avatars.githubusercontent.com/u/" + m[1] + "?s=64", "github", nil
+		}
+		return "https://github.com/" + m[2] + ".png?size=64", "github", nil
+	}
+
+	if github != nil && github.Token != "" {
+		avatarURL, err := s.lookupGitHub(email, github)
+		if err != nil {
+			return gravatarURL(email), "gravatar", err
+		}
+		if avatarURL != "" {
+			return avatarURL, "github", nil
+		}
+	}
+
+	return gravatarURL(email), "gravatar", nil
+}
+
+// lookupGitHub searches GitHub users by public email
+func (s *AvatarService) lookupGitHub(email string, github *models.ForgeConfig) (string, error) {
+	config := *github
+	config.Provider = models.ForgeGitHub
+	req, err := http.NewRequest("GET", forge.APIBaseURL(config)+"/search/users?q="+url.QueryEscape(email+" in:email"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+github.Token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API error (status %d)", resp.StatusCode)
+	}
+
+	var response struct {
+		Items []struct {
+			AvatarURL string `json:"avatar_url"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+	if len(response.Items) == 0 {
+		return "", nil
+	}
+	return response.Items[0].AvatarURL, nil
+}
+
+// gravatarURL returns the Gravatar image for an email, falling back to a generated identicon
+func gravatarURL(email string) string {
+	sum := md5.Sum([]byte(email))
+	return "https://www.gravatar.com/avatar/" + hex.EncodeToString(sum[:]) + "?s=64&d=identicon"
+}
+
+// normalizeEmail lowercases and trims an email as Gravatar expects
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
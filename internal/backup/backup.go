@@ -0,0 +1,246 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git-ai-tools/internal/database"
+	"git-ai-tools/internal/git"
+	"git-ai-tools/internal/logging"
+	"git-ai-tools/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// checkInterval is how often the scheduler looks for due backups
+const checkInterval = time.Minute
+
+// BackupService pushes repositories to backup remotes on a schedule or after commits
+type BackupService struct {
+	git     *git.GitService
+	mu      sync.Mutex
+	running map[string]bool
+	stop    chan struct{}
+	paused  atomic.Bool
+}
+
+// NewBackupService creates a new BackupService instance
+// It uses its own GitService so backups never change the selected repository
+func NewBackupService() *BackupService {
+	return &BackupService{
+		git:     git.NewGitService(),
+		running: map[string]bool{},
+	}
+}
+
+// Start runs the scheduler until Stop is called
+func (b *BackupService) Start() {
+	b.mu.Lock()
+	if b.stop != nil {
+		b.mu.Unlock()
+		return
+	}
+	b.stop = make(chan struct{})
+	stop := b.stop
+	b.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				b.runDue()
+			}
+		}
+	}()
+}
+
+// Stop ends the scheduler
+func (b *BackupService) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stop != nil {
+		close(b.stop)
+		b.stop = nil
+	}
+}
+
+// SetPaused suspends scheduled and after-commit backups, as in offline mode
+// Backups started by hand still run
+func (b *BackupService) SetPaused(paused bool) {
+	b.paused.Store(paused)
+}
+
+// GetTargets returns the backup targets of a repository
+func (b *BackupService) GetTargets(repoPath string) []models.BackupTarget {
+	var rows []models.BackupTargetDB
+	database.GetDB().Where("repo_path = ?", repoPath).Order("created_at ASC").Find(&rows)
+
+	result := make([]models.BackupTarget, len(rows))
+	for i, r := range rows {
+		result[i] = toTarget(r)
+	}
+	return result
+}
+
+// SaveTarget creates a backup target, or updates it when its ID is set
+func (b *BackupService) SaveTarget(target models.BackupTarget) (*models.BackupTarget, error) {
+	if target.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if target.Remote == "" {
+		return nil, fmt.Errorf("backup remote cannot be empty")
+	}
+	if !target.Mirror && len(target.Branches) == 0 {
+		return nil, fmt.Errorf("select branches or enable mirror")
+	}
+
+	var row models.BackupTargetDB
+	if target.ID != "" {
+		if err := database.GetDB().First(&row, "id = ?", target.ID).Error; err != nil {
+			return nil, err
+		}
+	} else {
+		row.ID = uuid.New().String()
+		row.CreatedAt = time.Now()
+	}
+	row.RepoPath = target.RepoPath
+	row.Remote = target.Remote
+	row.Mirror = target.Mirror
+	row.Branches = strings.Join(target.Branches, ",")
+	row.IntervalMinutes = target.IntervalMinutes
+	row.OnCommit = target.OnCommit
+	row.UpdatedAt = time.Now()
+
+	if err := database.GetDB().Save(&row).Error; err != nil {
+		return nil, err
+	}
+	result := toTarget(row)
+	return &result, nil
+}
+
+// DeleteTarget removes a backup target
+func (b *BackupService) DeleteTarget(id string) error {
+	return database.GetDB().Where("id = ?", id).Delete(&models.BackupTargetDB{}).Error
+}
+
+// Run pushes a backup target now and records the outcome
+func (b *BackupService) Run(id string) (*models.BackupTarget, error) {
+	var row models.BackupTargetDB
+	if err := database.GetDB().First(&row, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	err := b.push(&row)
+	result := toTarget(row)
+	return &result, err
+}
+
+// RunAfterCommit pushes the targets of a repository that back up on every commit
+// Pushes happen in the background so committing never waits for the network
+func (b *BackupService) RunAfterCommit(repoPath string) {
+	if b.paused.Load() {
+		return
+	}
+	var rows []models.BackupTargetDB
+	database.GetDB().Where("repo_path = ? AND on_commit = ?", repoPath, true).Find(&rows)
+	for i := range rows {
+		go b.push(&rows[i])
+	}
+}
+
+// LastSuccess returns the time of the latest successful backup of a repository, empty if none
+func (b *BackupService) LastSuccess(repoPath string) string {
+	var row models.BackupTargetDB
+	err := database.GetDB().Where("repo_path = ? AND last_success_at IS NOT NULL", repoPath).
+		Order("last_success_at DESC").First(&row).Error
+	if err != nil || row.LastSuccessAt == nil {
+		return ""
+	}
+	return row.LastSuccessAt.Format(time.RFC3339)
+}
+
+// runDue pushes every scheduled target whose interval has elapsed
+func (b *BackupService) runDue() {
+	if b.paused.Load() {
+		return
+	}
+	var rows []models.BackupTargetDB
+	database.GetDB().Where("interval_minutes > 0").Find(&rows)
+
+	now := time.Now()
+	for i := range rows {
+		row := &rows[i]
+		if row.LastAttemptAt != nil && now.Sub(*row.LastAttemptAt) < time.Duration(row.IntervalMinutes)*time.Minute {
+			continue
+		}
+		b.push(row)
+	}
+}
+
+// push runs one backup, skipping it when the same target is already being pushed
+func (b *BackupService) push(row *models.BackupTargetDB) error {
+	b.mu.Lock()
+	if b.running[row.ID] {
+		b.mu.Unlock()
+		return fmt.Errorf("backup to %s is already running", row.Remote)
+	}
+	b.running[row.ID] = true
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.running, row.ID)
+		b.mu.Unlock()
+	}()
+
+	var branches []string
+	if row.Branches != "" {
+		branches = strings.Split(row.Branches, ",")
+	}
+	err := b.git.PushBackup(row.RepoPath, row.Remote, row.Mirror, branches)
+
+	now := time.Now()
+	updates := map[string]interface{}{"last_attempt_at": now, "last_error": ""}
+	row.LastAttemptAt = &now
+	row.LastError = ""
+	if err != nil {
+		updates["last_error"] = err.Error()
+		row.LastError = err.Error()
+		logging.Logger().Warn("backup failed", "repo", row.RepoPath, "remote", row.Remote, "error", err.Error())
+	} else {
+		updates["last_success_at"] = now
+		row.LastSuccessAt = &now
+	}
+	database.GetDB().Model(&models.BackupTargetDB{}).Where("id = ?", row.ID).Updates(updates)
+	return err
+}
+
+// toTarget converts a database row to the model returned to the frontend
+func toTarget(r models.BackupTargetDB) models.BackupTarget {
+	target := models.BackupTarget{
+		ID:              r.ID,
+		RepoPath:        r.RepoPath,
+		Remote:          r.Remote,
+		Mirror:          r.Mirror,
+		Branches:        []string{},
+		IntervalMinutes: r.IntervalMinutes,
+		OnCommit:        r.OnCommit,
+		LastError:       r.LastError,
+	}
+	if r.Branches != "" {
+		target.Branches = strings.Split(r.Branches, ",")
+	}
+	if r.LastAttemptAt != nil {
+		target.LastAttemptAt = r.LastAttemptAt.Format(time.RFC3339)
+	}
+	if r.LastSuccessAt != nil {
+		target.LastSuccessAt = r.LastSuccessAt.Format(time.RFC3339)
+	}
+	return target
+}
@@ -0,0 +1,100 @@
+package codeowners
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-ai-tools/internal/git"
+)
+
+// Locations are where GitHub and GitLab look for a CODEOWNERS file, in order
+var Locations = []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule assigns owners to the files matching a pattern
+type Rule struct {
+	Pattern string
+	Owners  []string
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Path  string
+	Rules []Rule
+}
+
+// Load reads the first CODEOWNERS file of the repository at repoPath
+// It returns nil without an error when the repository has none
+func Load(repoPath string) (*File, error) {
+	for _, location := range Locations {
+		data, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(location)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+		return &File{Path: location, Rules: Parse(string(data))}, nil
+	}
+	return nil, nil
+}
+
+// Parse reads CODEOWNERS rules, one pattern and its owners per line
+// GitLab section headers such as [Docs] are skipped along with comments
+func Parse(content string) []Rule {
+	var rules []Rule
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		fields := strings.Fields(line)
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:]})
+	}
+	return rules
+}
+
+// Owners returns the owners of a repository path and the pattern that assigned them
+// The last matching rule wins; a matching rule without owners leaves the file unowned
+func (f *File) Owners(file string) ([]string, string) {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if matches(f.Rules[i].Pattern, file) {
+			return f.Rules[i].Owners, f.Rules[i].Pattern
+		}
+	}
+	return nil, ""
+}
+
+// matches applies a CODEOWNERS pattern, which follows gitignore rules: a pattern without a
+// slash other than a trailing one matches at any depth, and a pattern matching a directory
+// covers everything below it, except that dir/* only matches the files directly in dir
+func matches(pattern, file string) bool {
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		pattern = "**/" + pattern
+	}
+	patterns := []string{pattern}
+	if !strings.HasSuffix(pattern, "/") && !strings.HasSuffix(pattern, "/*") {
+		patterns = append(patterns, pattern+"/")
+	}
+	return git.MatchesAnyPattern(patterns, file)
+}
+
+// SplitReviewers separates owners into users and teams as forges request them for review,
+// dropping the leading @; owners given by email are left out
+func SplitReviewers(owners []string) (users, teams []string) {
+	users, teams = []string{}, []string{}
+	for _, owner := range owners {
+		name, ok := strings.CutPrefix(owner, "@")
+		if !ok {
+			continue
+		}
+		if strings.Contains(name, "/") {
+			teams = append(teams, name)
+		} else {
+			users = append(users, name)
+		}
+	}
+	return users, teams
+}
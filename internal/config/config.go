@@ -2,16 +2,26 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"git-ai-tools/internal/database"
 	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/secret"
 
 	"github.com/google/uuid"
 )
 
 // ConfigService manages application configuration
+// mu guards the cached AI config row and serializes read-modify-write updates,
+// since bound App methods may be called concurrently by the frontend
 type ConfigService struct {
+	mu sync.RWMutex
 	db *models.AppConfigDB
 }
 
@@ -46,9 +56,13 @@ func NewConfigService() *ConfigService {
 
 // GetAIConfig returns the AI configuration
 func (c *ConfigService) GetAIConfig() models.AIConfig {
+	c.mu.RLock()
+	value := c.db.Value
+	c.mu.RUnlock()
+
 	var config models.AIConfig
-	if c.db.Value != "" {
-		if err := json.Unmarshal([]byte(c.db.Value), &config); err == nil {
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), &config); err == nil {
 			return config
 		}
 	}
@@ -66,13 +80,535 @@ func (c *ConfigService) SetAIConfig(config models.AIConfig) error {
 	if err != nil {
 		return err
 	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.db.Value = string(value)
 	c.db.UpdatedAt = time.Now()
 	return database.GetDB().Save(c.db).Error
 }
 
+// GetMessageStyle returns the commit message post-processing settings
+func (c *ConfigService) GetMessageStyle() models.MessageStyle {
+	style := models.MessageStyle{
+		Enabled:          true,
+		MaxSubjectLength: 72,
+		BodyWrapWidth:    72,
+		RequireType:      true,
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("message_style", &style)
+	return style
+}
+
+// SetMessageStyle updates the commit message post-processing settings
+func (c *ConfigService) SetMessageStyle(style models.MessageStyle) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("message_style", style)
+}
+
+// GetForgeConfigs returns the configured code hosting accounts
+func (c *ConfigService) GetForgeConfigs() []models.ForgeConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.forgeConfigs()
+}
+
+// GetForgeConfig returns the first account configured for a code hosting service
+func (c *ConfigService) GetForgeConfig(provider models.ForgeProvider) (models.ForgeConfig, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, fc := range c.forgeConfigs() {
+		if fc.Provider == provider {
+			return fc, nil
+		}
+	}
+	return models.ForgeConfig{}, fmt.Errorf("%s is not configured", provider)
+}
+
+// GetForgeAccount returns a code hosting account by ID
+func (c *ConfigService) GetForgeAccount(id string) (models.ForgeConfig, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, fc := range c.forgeConfigs() {
+		if fc.ID == id {
+			return fc, nil
+		}
+	}
+	return models.ForgeConfig{}, fmt.Errorf("forge account not found: %s", id)
+}
+
+// SetForgeConfig adds or replaces a code hosting account, assigning an ID to new accounts
+// An empty token keeps the stored one, so accounts can be edited without re-entering it,
+// unless the provider or host changed and the token would be sent somewhere new
+func (c *ConfigService) SetForgeConfig(config models.ForgeConfig) (models.ForgeConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	configs := c.forgeConfigs()
+	index := -1
+	for i, fc := range configs {
+		if config.ID != "" && fc.ID == config.ID {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 {
+		config.ID = uuid.New().String()
+		configs = append(configs, config)
+	} else {
+		if config.Token == "" {
+			if !sameForgeHost(configs[index], config) {
+				return models.ForgeConfig{}, fmt.Errorf("token is required when the forge host changes")
+			}
+			config.Token = configs[index].Token
+		}
+		configs[index] = config
+	}
+	config.HasToken = config.Token != ""
+	return config, c.saveForgeConfigs(configs)
+}
+
+// sameForgeHost reports whether two accounts talk to the same provider and host
+func sameForgeHost(a, b models.ForgeConfig) bool {
+	return a.Provider == b.Provider && forgeHost(a.BaseURL) == forgeHost(b.BaseURL)
+}
+
+// forgeHost returns the lowercase host of a base URL, or the trimmed URL when it has none
+func forgeHost(baseURL string) string {
+	baseURL = strings.TrimSpace(baseURL)
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		return strings.ToLower(u.Host)
+	}
+	return strings.ToLower(strings.TrimRight(baseURL, "/"))
+}
+
+// DeleteForgeConfig removes a code hosting account
+func (c *ConfigService) DeleteForgeConfig(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	configs := c.forgeConfigs()
+	for i, fc := range configs {
+		if fc.ID == id {
+			return c.saveForgeConfigs(append(configs[:i], configs[i+1:]...))
+		}
+	}
+	return fmt.Errorf("forge account not found: %s", id)
+}
+
+// forgeConfigs decodes the stored forge accounts and decrypts their tokens, callers must hold mu
+// Accounts saved before multiple accounts were supported are identified by their provider
+func (c *ConfigService) forgeConfigs() []models.ForgeConfig {
+	configs := []models.ForgeConfig{}
+	c.getValue("forge_config", &configs)
+	for i := range configs {
+		if configs[i].ID == "" {
+			configs[i].ID = string(configs[i].Provider)
+		}
+		token, err := secret.Decrypt(configs[i].Token)
+		if err != nil {
+			token = ""
+		}
+		configs[i].Token = token
+		configs[i].HasToken = token != ""
+	}
+	return configs
+}
+
+// saveForgeConfigs encrypts the tokens of the forge accounts and stores them, callers must hold mu
+func (c *ConfigService) saveForgeConfigs(configs []models.ForgeConfig) error {
+	stored := make([]models.ForgeConfig, len(configs))
+	for i, fc := range configs {
+		token, err := secret.Encrypt(fc.Token)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt token: %w", err)
+		}
+		fc.Token = token
+		fc.HasToken = false
+		stored[i] = fc
+	}
+	return c.setValue("forge_config", stored)
+}
+
+// GetIssueBranchPattern returns the pattern used to name branches created from issues
+func (c *ConfigService) GetIssueBranchPattern() string {
+	pattern := "issue-{number}-{title}"
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("issue_branch_pattern", &pattern)
+	return pattern
+}
+
+// SetIssueBranchPattern updates the pattern used to name branches created from issues
+func (c *ConfigService) SetIssueBranchPattern(pattern string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("issue_branch_pattern", pattern)
+}
+
+// GetBranchIssue returns the issue key linked to a branch of a repository
+func (c *ConfigService) GetBranchIssue(repoPath, branch string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	links := map[string]map[string]string{}
+	c.getValue("branch_issues", &links)
+	return links[repoPath][branch]
+}
+
+// SetBranchIssue links an issue key to a branch of a repository
+func (c *ConfigService) SetBranchIssue(repoPath, branch, issueKey string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	links := map[string]map[string]string{}
+	c.getValue("branch_issues", &links)
+	if links[repoPath] == nil {
+		links[repoPath] = map[string]string{}
+	}
+	links[repoPath][branch] = issueKey
+	return c.setValue("branch_issues", links)
+}
+
+// GetSMTPConfig returns the account used to mail patches with its password decrypted
+func (c *ConfigService) GetSMTPConfig() models.SMTPConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.smtpConfig()
+}
+
+// SetSMTPConfig updates the account used to mail patches, encrypting its password
+// An empty password keeps the stored one
+func (c *ConfigService) SetSMTPConfig(config models.SMTPConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if config.Password == "" {
+		config.Password = c.smtpConfig().Password
+	}
+	password, err := secret.Encrypt(config.Password)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt password: %w", err)
+	}
+	config.Password = password
+	config.HasPassword = false
+	return c.setValue("smtp_config", config)
+}
+
+// smtpConfig decodes the stored SMTP account and decrypts its password, callers must hold mu
+func (c *ConfigService) smtpConfig() models.SMTPConfig {
+	config := models.SMTPConfig{Port: 587}
+	c.getValue("smtp_config", &config)
+	password, err := secret.Decrypt(config.Password)
+	if err != nil {
+		password = ""
+	}
+	config.Password = password
+	config.HasPassword = password != ""
+	return config
+}
+
+// GetProtectedPaths returns the patterns of files guarded against discarding
+func (c *ConfigService) GetProtectedPaths() models.ProtectedPaths {
+	protected := models.ProtectedPaths{Patterns: []string{".env", ".env.*"}}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("protected_paths", &protected)
+	return protected
+}
+
+// SetProtectedPaths updates the patterns of files guarded against discarding
+func (c *ConfigService) SetProtectedPaths(protected models.ProtectedPaths) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("protected_paths", protected)
+}
+
+// GetMaxOutput returns the byte limit for diffs and file contents, 0 meaning the default
+func (c *ConfigService) GetMaxOutput() int64 {
+	var limit int64
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("max_output", &limit)
+	return limit
+}
+
+// SetMaxOutput updates the byte limit for diffs and file contents
+func (c *ConfigService) SetMaxOutput(limit int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("max_output", limit)
+}
+
+// GetFetchPolicy returns how remote refs are refreshed before branch operations
+func (c *ConfigService) GetFetchPolicy() models.FetchPolicy {
+	policy := models.FetchPolicy{StaleMinutes: 30}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("fetch_policy", &policy)
+	return policy
+}
+
+// SetFetchPolicy updates how remote refs are refreshed before branch operations
+func (c *ConfigService) SetFetchPolicy(policy models.FetchPolicy) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("fetch_policy", policy)
+}
+
+// GetTracingEnabled reports whether operation timings are recorded, on unless opted out
+func (c *ConfigService) GetTracingEnabled() bool {
+	enabled := true
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("performance_tracing", &enabled)
+	return enabled
+}
+
+// SetTracingEnabled turns recording of operation timings on or off
+func (c *ConfigService) SetTracingEnabled(enabled bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("performance_tracing", enabled)
+}
+
+// GetSemanticSearchEnabled reports whether commit messages are embedded for semantic
+// search, off unless opted in since it sends history to the AI provider
+func (c *ConfigService) GetSemanticSearchEnabled() bool {
+	enabled := false
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("semantic_search", &enabled)
+	return enabled
+}
+
+// SetSemanticSearchEnabled turns semantic commit search on or off
+func (c *ConfigService) SetSemanticSearchEnabled(enabled bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("semantic_search", enabled)
+}
+
+// GetAIContextFilter returns the files left out of AI requests, lockfiles, bundles and
+// snapshots by default
+func (c *ConfigService) GetAIContextFilter() models.AIContextFilter {
+	filter := models.AIContextFilter{
+		Patterns: []string{
+			"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "go.sum", "Cargo.lock", "poetry.lock",
+			"composer.lock", "Gemfile.lock", "*.min.js", "*.min.css", "*.map", "*.snap", "**/__snapshots__/",
+		},
+		Generated: true,
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("ai_context_filter", &filter)
+	return filter
+}
+
+// SetAIContextFilter updates the files left out of AI requests
+func (c *ConfigService) SetAIContextFilter(filter models.AIContextFilter) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("ai_context_filter", filter)
+}
+
+// GetCommitGenerator returns how commit messages are generated, AI unless changed
+func (c *ConfigService) GetCommitGenerator() string {
+	generator := models.GeneratorAI
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("commit_generator", &generator)
+	return generator
+}
+
+// SetCommitGenerator updates how commit messages are generated
+func (c *ConfigService) SetCommitGenerator(generator string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("commit_generator", generator)
+}
+
+// GetOfflineMode reports whether offline mode is on
+func (c *ConfigService) GetOfflineMode() bool {
+	offline := false
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("offline_mode", &offline)
+	return offline
+}
+
+// SetOfflineMode turns offline mode on or off
+func (c *ConfigService) SetOfflineMode(offline bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("offline_mode", offline)
+}
+
+// GetCloneSettings returns the default clone directory and folder layout
+// The directory defaults to "git" in the home directory
+func (c *ConfigService) GetCloneSettings() models.CloneSettings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	settings := models.CloneSettings{}
+	c.getValue("clone_settings", &settings)
+	if settings.Root == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			settings.Root = filepath.Join(home, "git")
+		}
+	}
+	return settings
+}
+
+// SetCloneSettings updates the default clone directory and folder layout
+func (c *ConfigService) SetCloneSettings(settings models.CloneSettings) error {
+	switch settings.Grouping {
+	case models.CloneGroupNone, models.CloneGroupOwner, models.CloneGroupHost:
+	default:
+		return fmt.Errorf("unknown clone grouping: %s", settings.Grouping)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("clone_settings", settings)
+}
+
+// GetPathOverride returns the PATH configured for git and other child processes,
+// "" when the system PATH is used
+func (c *ConfigService) GetPathOverride() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var path string
+	c.getValue("path_override", &path)
+	return path
+}
+
+// SetPathOverride sets the PATH used for git and other child processes
+func (c *ConfigService) SetPathOverride(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("path_override", path)
+}
+
+// GetGitExecutable returns the configured git binary and global options
+func (c *ConfigService) GetGitExecutable() models.GitExecutable {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	exe := models.GitExecutable{Args: []string{}}
+	c.getValue("git_executable", &exe)
+	return exe
+}
+
+// SetGitExecutable sets the git binary and global options
+func (c *ConfigService) SetGitExecutable(exe models.GitExecutable) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("git_executable", exe)
+}
+
+// GetUpdateChannel returns the release channel the update checker follows, stable by default
+func (c *ConfigService) GetUpdateChannel() models.UpdateChannel {
+	channel := models.UpdateStable
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("update_channel", &channel)
+	return channel
+}
+
+// SetUpdateChannel sets the release channel the update checker follows
+func (c *ConfigService) SetUpdateChannel(channel models.UpdateChannel) error {
+	if channel != models.UpdateStable && channel != models.UpdateBeta {
+		return fmt.Errorf("unknown update channel: %s", channel)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("update_channel", channel)
+}
+
+// GetCommitTrailers returns the trailers appended to commits of a repository
+func (c *ConfigService) GetCommitTrailers(repoPath string) []models.CommitTrailer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	trailers := map[string][]models.CommitTrailer{}
+	c.getValue("commit_trailers", &trailers)
+	if trailers[repoPath] == nil {
+		return []models.CommitTrailer{}
+	}
+	return trailers[repoPath]
+}
+
+// SetCommitTrailers replaces the trailers appended to commits of a repository
+func (c *ConfigService) SetCommitTrailers(repoPath string, list []models.CommitTrailer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	trailers := map[string][]models.CommitTrailer{}
+	c.getValue("commit_trailers", &trailers)
+	if len(list) == 0 {
+		delete(trailers, repoPath)
+	} else {
+		trailers[repoPath] = list
+	}
+	return c.setValue("commit_trailers", trailers)
+}
+
+// GetCrashReporting reports whether crash reports are captured, off unless the user opted in
+func (c *ConfigService) GetCrashReporting() bool {
+	enabled := false
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.getValue("crash_reporting", &enabled)
+	return enabled
+}
+
+// SetCrashReporting turns crash report capture on or off
+func (c *ConfigService) SetCrashReporting(enabled bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("crash_reporting", enabled)
+}
+
+// getValue decodes the JSON value stored under key into v, leaving v untouched when missing
+// Callers must hold mu
+func (c *ConfigService) getValue(key string, v interface{}) bool {
+	var row models.AppConfigDB
+	if err := database.GetDB().First(&row, "key = ?", key).Error; err != nil || row.Value == "" {
+		return false
+	}
+	return json.Unmarshal([]byte(row.Value), v) == nil
+}
+
+// setValue stores v as JSON under key, creating the row if needed
+// Callers must hold mu for writing
+func (c *ConfigService) setValue(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var row models.AppConfigDB
+	if err := database.GetDB().First(&row, "key = ?", key).Error; err != nil {
+		row = models.AppConfigDB{
+			ID:  uuid.New().String(),
+			Key: key,
+		}
+	}
+	row.Value = string(value)
+	row.UpdatedAt = time.Now()
+	return database.GetDB().Save(&row).Error
+}
+
 // AddRecentRepo adds a repository to recent repos list
 func (c *ConfigService) AddRecentRepo(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Check if exists
 	var existing models.RecentRepoDB
 	result := database.GetDB().First(&existing, "path = ?", path)
@@ -106,15 +642,28 @@ func (c *ConfigService) GetRecentRepos() []string {
 
 // RemoveRecentRepo removes a repository from recent repos list
 func (c *ConfigService) RemoveRecentRepo(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return database.GetDB().Where("path = ?", path).Delete(&models.RecentRepoDB{}).Error
 }
 
-// GetWindowConfig returns the window configuration
+// GetWindowConfig returns the window size and position saved when the app last closed
 func (c *ConfigService) GetWindowConfig() models.WindowConfig {
-	return models.WindowConfig{
-		Width:  1200,
-		Height: 800,
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	window := models.WindowConfig{
+		Width:  1500,
+		Height: 920,
 	}
+	c.getValue("window", &window)
+	return window
+}
+
+// SetWindowConfig saves the window size and position
+func (c *ConfigService) SetWindowConfig(window models.WindowConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setValue("window", window)
 }
 
 // GetConfigPath returns the configuration file path (legacy)
@@ -131,14 +680,7 @@ func (c *ConfigService) GetAllRepositories() []models.Repository {
 
 	result := make([]models.Repository, len(repos))
 	for i, repo := range repos {
-		result[i] = models.Repository{
-			ID:          repo.ID,
-			Path:        repo.Path,
-			Alias:       repo.Alias,
-			Description: repo.Description,
-			CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   repo.UpdatedAt.Format(time.RFC3339),
-		}
+		result[i] = *repositoryFromDB(repo)
 	}
 	return result
 }
@@ -149,14 +691,7 @@ func (c *ConfigService) GetRepository(id string) *models.Repository {
 	if err := database.GetDB().First(&repo, "id = ?", id).Error; err != nil {
 		return nil
 	}
-	return &models.Repository{
-		ID:          repo.ID,
-		Path:        repo.Path,
-		Alias:       repo.Alias,
-		Description: repo.Description,
-		CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   repo.UpdatedAt.Format(time.RFC3339),
-	}
+	return repositoryFromDB(repo)
 }
 
 // GetRepositoryByPath returns a repository by path
@@ -165,18 +700,14 @@ func (c *ConfigService) GetRepositoryByPath(path string) *models.Repository {
 	if err := database.GetDB().First(&repo, "path = ?", path).Error; err != nil {
 		return nil
 	}
-	return &models.Repository{
-		ID:          repo.ID,
-		Path:        repo.Path,
-		Alias:       repo.Alias,
-		Description: repo.Description,
-		CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   repo.UpdatedAt.Format(time.RFC3339),
-	}
+	return repositoryFromDB(repo)
 }
 
 // AddRepository adds a new repository
 func (c *ConfigService) AddRepository(path, alias, description string) (*models.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Check if already exists
 	if c.GetRepositoryByPath(path) != nil {
 		return nil, nil
@@ -196,18 +727,14 @@ func (c *ConfigService) AddRepository(path, alias, description string) (*models.
 		return nil, err
 	}
 
-	return &models.Repository{
-		ID:          repo.ID,
-		Path:        repo.Path,
-		Alias:       repo.Alias,
-		Description: repo.Description,
-		CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   repo.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return repositoryFromDB(repo), nil
 }
 
 // UpdateRepository updates an existing repository
 func (c *ConfigService) UpdateRepository(id, alias, description string) (*models.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var repo models.RepositoryDB
 	if err := database.GetDB().First(&repo, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -221,23 +748,27 @@ func (c *ConfigService) UpdateRepository(id, alias, description string) (*models
 		return nil, err
 	}
 
-	return &models.Repository{
-		ID:          repo.ID,
-		Path:        repo.Path,
-		Alias:       repo.Alias,
-		Description: repo.Description,
-		CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   repo.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return repositoryFromDB(repo), nil
 }
 
 // UpdateRepositoryAlias updates only the alias of a repository
 func (c *ConfigService) UpdateRepositoryAlias(id, alias string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return database.GetDB().Model(&models.RepositoryDB{}).Where("id = ?", id).Update("alias", alias).Error
 }
 
+// UpdateRepositoryScope updates only the path scope of a repository
+func (c *ConfigService) UpdateRepositoryScope(id, scope string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return database.GetDB().Model(&models.RepositoryDB{}).Where("id = ?", id).Update("path_scope", scope).Error
+}
+
 // DeleteRepository deletes a repository by ID
 func (c *ConfigService) DeleteRepository(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return database.GetDB().Where("id = ?", id).Delete(&models.RepositoryDB{}).Error
 }
 
@@ -255,18 +786,42 @@ func (c *ConfigService) SearchRepositories(keyword string) []models.Repository {
 
 	result := make([]models.Repository, len(repos))
 	for i, repo := range repos {
-		result[i] = models.Repository{
-			ID:          repo.ID,
-			Path:        repo.Path,
-			Alias:       repo.Alias,
-			Description: repo.Description,
-			CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   repo.UpdatedAt.Format(time.RFC3339),
-		}
+		result[i] = *repositoryFromDB(repo)
 	}
 	return result
 }
 
+// UpdateRepositoryDefaults updates the default remote, base branch and push behavior of a repository
+func (c *ConfigService) UpdateRepositoryDefaults(id string, defaults models.RepositoryDefaults) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return database.GetDB().Model(&models.RepositoryDB{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"default_remote": defaults.DefaultRemote,
+		"default_branch": defaults.DefaultBranch,
+		"push_behavior":  defaults.PushBehavior,
+		"forge_account":  defaults.ForgeAccount,
+	}).Error
+}
+
+// repositoryFromDB converts a stored repository to its API form
+func repositoryFromDB(repo models.RepositoryDB) *models.Repository {
+	return &models.Repository{
+		ID:          repo.ID,
+		Path:        repo.Path,
+		Alias:       repo.Alias,
+		Description: repo.Description,
+		PathScope:   repo.PathScope,
+		Defaults: models.RepositoryDefaults{
+			DefaultRemote: repo.DefaultRemote,
+			DefaultBranch: repo.DefaultBranch,
+			PushBehavior:  repo.PushBehavior,
+			ForgeAccount:  repo.ForgeAccount,
+		},
+		CreatedAt: repo.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: repo.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
 // GetRepositoriesPath returns the repositories config path (legacy)
 func (c *ConfigService) GetRepositoriesPath() string {
 	return ""
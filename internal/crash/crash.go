@@ -0,0 +1,238 @@
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/logging"
+	"git-ai-tools/internal/models"
+)
+
+const (
+	// outputFile receives the runtime's crash output while reporting is on
+	outputFile = "crash.out"
+	// logLines is the number of log entries kept with a report
+	logLines = 50
+	// issuesURL opens a new issue on the project's tracker
+	issuesURL = "https://github.com/issueye/git_tools/issues/new"
+	// maxIssueStack keeps the prefilled issue URL within what browsers accept
+	maxIssueStack = 4000
+)
+
+// reportID matches the IDs Collect gives reports, which are also their file names
+var reportID = regexp.MustCompile(`^[0-9]{8}-[0-9]{6}(-[0-9]+)?$`)
+
+// Enable turns crash capture on or off; while on, the runtime writes the stack traces of
+// a fatal panic to the crash directory, where Collect turns them into a report on the next start
+func Enable(enabled bool) error {
+	if !enabled {
+		return debug.SetCrashOutput(nil, debug.CrashOptions{})
+	}
+	if err := os.MkdirAll(crashDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create crash directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(crashDir(), outputFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open crash output: %w", err)
+	}
+	// The runtime keeps its own duplicate of the descriptor
+	defer f.Close()
+	return debug.SetCrashOutput(f, debug.CrashOptions{})
+}
+
+// Collect turns crash output left by the previous run into a scrubbed report together with
+// the last log entries, returning the new report or nil when the last run did not crash
+func Collect(version string, scrubber *Scrubber) (*models.CrashReport, error) {
+	path := filepath.Join(crashDir(), outputFile)
+	data, err := os.ReadFile(path)
+	if err != nil || strings.TrimSpace(string(data)) == "" {
+		return nil, nil
+	}
+
+	info, _ := os.Stat(path)
+	report := &models.CrashReport{
+		ID:      newReportID(info.ModTime()),
+		Time:    info.ModTime().Format(time.RFC3339),
+		Version: version,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		Summary: scrubber.Scrub(crashSummary(string(data))),
+		Stack:   scrubber.Scrub(string(data)),
+		Logs:    []models.LogEntry{},
+	}
+	if entries, err := logging.Recent("", logLines); err == nil {
+		for _, e := range entries {
+			e.Message = scrubber.Scrub(e.Message)
+			for k, v := range e.Fields {
+				e.Fields[k] = scrubber.scrubValue(v)
+			}
+			report.Logs = append(report.Logs, e)
+		}
+	}
+
+	if err := writeReport(report); err != nil {
+		return nil, err
+	}
+	// Only forget the crash once its report is safely stored
+	if err := os.Truncate(path, 0); err != nil {
+		return nil, fmt.Errorf("failed to clear crash output: %w", err)
+	}
+	return report, nil
+}
+
+// List returns the stored crash reports, newest first
+func List() ([]models.CrashReport, error) {
+	entries, err := os.ReadDir(reportsDir())
+	if os.IsNotExist(err) {
+		return []models.CrashReport{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crash reports: %w", err)
+	}
+
+	reports := []models.CrashReport{}
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || !reportID.MatchString(id) {
+			continue
+		}
+		if r, err := Get(id); err == nil {
+			reports = append(reports, *r)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].ID > reports[j].ID })
+	return reports, nil
+}
+
+// Get returns a stored crash report
+func Get(id string) (*models.CrashReport, error) {
+	path, err := reportPath(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("crash report not found: %s", id)
+	}
+	var report models.CrashReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse crash report: %w", err)
+	}
+	return &report, nil
+}
+
+// Export writes a crash report as indented JSON to dest
+func Export(id, dest string) error {
+	report, err := Get(id)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode crash report: %w", err)
+	}
+	if err := os.WriteFile(dest, data, 0644); err != nil {
+		return fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a stored crash report
+func Delete(id string) error {
+	path, err := reportPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete crash report: %w", err)
+	}
+	return nil
+}
+
+// IssueURL returns a link that opens a new issue prefilled with the report, for the user
+// to review before submitting; nothing is sent by the app itself
+func IssueURL(id string) (string, error) {
+	report, err := Get(id)
+	if err != nil {
+		return "", err
+	}
+	stack := report.Stack
+	if len(stack) > maxIssueStack {
+		stack = stack[:maxIssueStack] + "\n…"
+	}
+	body := fmt.Sprintf("**Version:** %s\n**Platform:** %s/%s\n**Time:** %s\n\n```\n%s\n```\n",
+		report.Version, report.OS, report.Arch, report.Time, stack)
+	q := url.Values{}
+	q.Set("title", "Crash: "+report.Summary)
+	q.Set("body", body)
+	return issuesURL + "?" + q.Encode(), nil
+}
+
+// crashSummary returns the "panic:" or "fatal error:" line of crash output
+func crashSummary(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+			return line
+		}
+	}
+	return "unknown crash"
+}
+
+// writeReport stores a report under its ID
+func writeReport(report *models.CrashReport) error {
+	if err := os.MkdirAll(reportsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create crash report directory: %w", err)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode crash report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportsDir(), report.ID+".json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return nil
+}
+
+// newReportID names a report after the time of the crash, unique among stored reports
+func newReportID(t time.Time) string {
+	base := t.Format("20060102-150405")
+	id := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(reportsDir(), id+".json")); os.IsNotExist(err) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// reportPath returns the file of a report, rejecting IDs that are not report names
+func reportPath(id string) (string, error) {
+	if !reportID.MatchString(id) {
+		return "", fmt.Errorf("invalid crash report id: %s", id)
+	}
+	return filepath.Join(reportsDir(), id+".json"), nil
+}
+
+// crashDir holds the crash output and the reports under the config directory
+func crashDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+	return filepath.Join(configDir, "git-ai-tools", "crash")
+}
+
+// reportsDir holds one JSON file per crash report
+func reportsDir() string {
+	return filepath.Join(crashDir(), "reports")
+}
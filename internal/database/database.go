@@ -1,10 +1,14 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/logging"
 	"git-ai-tools/internal/models"
 
 	"github.com/glebarez/sqlite"
@@ -12,9 +16,13 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-var db *gorm.DB
+var (
+	db     *gorm.DB
+	dbPath string
+)
 
 // Init initializes the database connection
+// A corrupt database is set aside and replaced with the last good backup
 func Init() error {
 	// Get config directory
 	configDir, err := os.UserConfigDir()
@@ -24,15 +32,15 @@ func Init() error {
 	configDir = filepath.Join(configDir, "git-ai-tools")
 	os.MkdirAll(configDir, 0755)
 
-	dbPath := filepath.Join(configDir, "data.db")
+	dbPath = filepath.Join(configDir, "data.db")
 
-	// Open database connection
-	var dbErr error
-	db, dbErr = gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	if dbErr != nil {
-		return fmt.Errorf("failed to connect to database: %w", dbErr)
+	if err := open(); err != nil {
+		if restoreErr := restoreBackup(); restoreErr != nil {
+			return fmt.Errorf("%w (restore failed: %v)", err, restoreErr)
+		}
+		if err := open(); err != nil {
+			return err
+		}
 	}
 
 	// Run migrations
@@ -40,9 +48,88 @@ func Init() error {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Keep a snapshot of the known-good database for recovery
+	backup()
+
 	return nil
 }
 
+// open connects to the database and verifies its integrity
+func open() error {
+	var dbErr error
+	// Pragmas in the DSN apply to every pooled connection: WAL with full sync
+	// makes committed transactions survive a crash, busy_timeout lets
+	// concurrent writers wait for the lock instead of failing
+	dsn := dbPath + "?_pragma=journal_mode(WAL)&_pragma=synchronous(FULL)&_pragma=busy_timeout(5000)"
+	db, dbErr = gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.New(dbLogWriter{}, logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: true,
+		}),
+	})
+	if dbErr != nil {
+		return fmt.Errorf("failed to connect to database: %w", dbErr)
+	}
+
+	var result string
+	if err := db.Raw("PRAGMA quick_check").Scan(&result).Error; err != nil || result != "ok" {
+		Close()
+		if err == nil {
+			err = errors.New(result)
+		}
+		return fmt.Errorf("database is corrupt: %w", err)
+	}
+	return nil
+}
+
+// dbLogWriter forwards slow query and error reports to the application log
+type dbLogWriter struct{}
+
+// Printf implements the gorm logger writer
+func (dbLogWriter) Printf(format string, args ...interface{}) {
+	logging.Logger().Warn(fmt.Sprintf(format, args...), "id", logging.NewID(), "kind", "db")
+}
+
+// backupPath returns the location of the database snapshot
+func backupPath() string {
+	return dbPath + ".bak"
+}
+
+// backup writes a consistent snapshot of the database next to it
+func backup() {
+	tmp := dbPath + ".bak.tmp"
+	os.Remove(tmp)
+	if err := db.Exec("VACUUM INTO ?", tmp).Error; err != nil {
+		os.Remove(tmp)
+		return
+	}
+	if err := fsutil.SyncFile(tmp); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	if err := os.Rename(tmp, backupPath()); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// restoreBackup moves the corrupt database aside and puts the snapshot in its place
+func restoreBackup() error {
+	if _, err := os.Stat(backupPath()); err != nil {
+		return fmt.Errorf("no backup available: %w", err)
+	}
+
+	corrupt := fmt.Sprintf("%s.corrupt-%s", dbPath, time.Now().Format("20060102-150405"))
+	if err := os.Rename(dbPath, corrupt); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to move corrupt database: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		os.Rename(dbPath+suffix, corrupt+suffix)
+	}
+
+	return fsutil.CopyFileAtomic(backupPath(), dbPath)
+}
+
 // migrate runs database migrations
 func migrate() error {
 	return db.AutoMigrate(
@@ -51,6 +138,12 @@ func migrate() error {
 		&models.CommandDB{},
 		&models.AppConfigDB{},
 		&models.RecentRepoDB{},
+		&models.AvatarDB{},
+		&models.TemplateRevisionDB{},
+		&models.OperationTraceDB{},
+		&models.PendingOperationDB{},
+		&models.BackupTargetDB{},
+		&models.CommitEmbeddingDB{},
 	)
 }
 
@@ -61,6 +154,9 @@ func GetDB() *gorm.DB {
 
 // Close closes the database connection
 func Close() error {
+	if db == nil {
+		return nil
+	}
 	sqlDB, err := db.DB()
 	if err != nil {
 		return err
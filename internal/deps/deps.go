@@ -0,0 +1,265 @@
+package deps
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+var (
+	// packageJSONEntry matches a "name": "version" line of package.json
+	packageJSONEntry = regexp.MustCompile(`^"(@?[^"\s]+)"\s*:\s*"([\^~<>=]*\d[^"]*)",?$`)
+	// packageLockEntry matches the line opening a package of package-lock.json
+	packageLockEntry = regexp.MustCompile(`^"([^"]+)"\s*:\s*\{$`)
+	// packageLockVersion matches the version of a package-lock.json entry
+	packageLockVersion = regexp.MustCompile(`^"version"\s*:\s*"([^"]+)",?$`)
+)
+
+// lockSections are the package-lock.json objects that are not packages
+var lockSections = map[string]bool{"": true, "packages": true, "dependencies": true, "devDependencies": true, "requires": true, "engines": true}
+
+// IsManifest reports whether a path is a dependency manifest or lockfile
+func IsManifest(p string) bool {
+	switch path.Base(p) {
+	case "go.mod", "go.sum", "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml",
+		"Cargo.toml", "Cargo.lock", "requirements.txt", "poetry.lock", "Gemfile", "Gemfile.lock", "composer.json", "composer.lock":
+		return true
+	}
+	return false
+}
+
+// ParseBumps extracts the dependency changes from the diff of a go.mod, go.sum,
+// package.json or package-lock.json; other files yield nothing
+func ParseBumps(file, diff string) []models.DependencyBump {
+	var parse func(string) (string, string, bool)
+	switch path.Base(file) {
+	case "go.mod":
+		parse = parseGoModLine
+	case "go.sum":
+		parse = parseGoSumLine
+	case "package.json":
+		parse = parsePackageJSONLine
+	case "package-lock.json":
+		return parsePackageLock(file, diff)
+	default:
+		return nil
+	}
+
+	removed := map[string]string{}
+	added := map[string]string{}
+	for _, line := range strings.Split(diff, "\n") {
+		if line == "" || strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
+			continue
+		}
+		var target map[string]string
+		switch line[0] {
+		case '-':
+			target = removed
+		case '+':
+			target = added
+		default:
+			continue
+		}
+		if name, version, ok := parse(strings.TrimSpace(line[1:])); ok {
+			target[name] = version
+		}
+	}
+	return pairBumps(file, removed, added)
+}
+
+// Summarize merges the dependency changes found in the diffs of the given files and
+// returns them with what is left of each diff once they are described: a lockfile whose
+// changes were summarized is left out entirely, while a manifest keeps its other edits,
+// such as scripts, the go directive or replace lines. Other diffs are returned unchanged
+// A package is reported once, manifests (go.mod, package.json) taking precedence over
+// their lockfiles
+func Summarize(files, diffs []string) ([]models.DependencyBump, []string) {
+	bumps := []models.DependencyBump{}
+	rest := append([]string(nil), diffs...)
+	seen := map[string]bool{}
+	for _, lockfile := range []bool{false, true} {
+		for i, file := range files {
+			if i >= len(diffs) || isLockfile(file) != lockfile {
+				continue
+			}
+			found := ParseBumps(file, diffs[i])
+			if len(found) == 0 {
+				continue
+			}
+			if lockfile {
+				rest[i] = ""
+			} else {
+				rest[i] = stripDependencyLines(file, diffs[i])
+			}
+			for _, b := range found {
+				key := path.Dir(file) + "\x00" + b.Name
+				if !seen[key] {
+					seen[key] = true
+					bumps = append(bumps, b)
+				}
+			}
+		}
+	}
+	return bumps, rest
+}
+
+// stripDependencyLines removes the changed dependency entries from the diff of a manifest,
+// dropping hunks left without changes; "" is returned when no other change remains
+// The hunk headers keep their original line counts, the result is only meant to be read
+func stripDependencyLines(file, diff string) string {
+	var parse func(string) (string, string, bool)
+	switch path.Base(file) {
+	case "go.mod":
+		parse = parseGoModLine
+	case "package.json":
+		parse = parsePackageJSONLine
+	default:
+		return diff
+	}
+
+	var header, out []string
+	var hunk []string
+	changed, inHunk := false, false
+	flush := func() {
+		if changed {
+			out = append(out, hunk...)
+		}
+		hunk, changed = nil, false
+	}
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			inHunk = true
+			hunk = append(hunk, line)
+		case !inHunk:
+			header = append(header, line)
+		case line != "" && (line[0] == '+' || line[0] == '-'):
+			if _, _, ok := parse(strings.TrimSpace(line[1:])); ok {
+				continue
+			}
+			changed = true
+			hunk = append(hunk, line)
+		default:
+			hunk = append(hunk, line)
+		}
+	}
+	flush()
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(append(header, out...), "\n")
+}
+
+// FormatBumps describes dependency changes one per line, for use in place of the diffs
+func FormatBumps(bumps []models.DependencyBump) string {
+	var b strings.Builder
+	for _, bump := range bumps {
+		switch {
+		case bump.From == "":
+			fmt.Fprintf(&b, "- 添加 %s %s (%s)\n", bump.Name, bump.To, bump.File)
+		case bump.To == "":
+			fmt.Fprintf(&b, "- 移除 %s %s (%s)\n", bump.Name, bump.From, bump.File)
+		default:
+			fmt.Fprintf(&b, "- %s %s -> %s (%s)\n", bump.Name, bump.From, bump.To, bump.File)
+		}
+	}
+	return b.String()
+}
+
+// isLockfile reports whether a path is a lockfile generated from a manifest
+func isLockfile(p string) bool {
+	switch path.Base(p) {
+	case "go.sum", "package-lock.json":
+		return true
+	}
+	return false
+}
+
+// pairBumps matches removed and added versions by package name
+func pairBumps(file string, removed, added map[string]string) []models.DependencyBump {
+	var bumps []models.DependencyBump
+	for name, to := range added {
+		if from := removed[name]; from != to {
+			bumps = append(bumps, models.DependencyBump{Name: name, From: from, To: to, File: file})
+		}
+	}
+	for name, from := range removed {
+		if _, ok := added[name]; !ok {
+			bumps = append(bumps, models.DependencyBump{Name: name, From: from, File: file})
+		}
+	}
+	sort.Slice(bumps, func(i, j int) bool { return bumps[i].Name < bumps[j].Name })
+	return bumps
+}
+
+// parsePackageLock reads version changes from a package-lock.json diff, attributing each
+// "version" line to the package entry opened before it; the entry is usually a context line
+func parsePackageLock(file, diff string) []models.DependencyBump {
+	removed := map[string]string{}
+	added := map[string]string{}
+	current := ""
+	for _, line := range strings.Split(diff, "\n") {
+		if line == "" || strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
+			continue
+		}
+		if strings.HasPrefix(line, "@@") {
+			current = ""
+			continue
+		}
+		content := strings.TrimSpace(line[1:])
+		if match := packageLockEntry.FindStringSubmatch(content); match != nil {
+			current = match[1]
+			if i := strings.LastIndex(current, "node_modules/"); i >= 0 {
+				current = current[i+len("node_modules/"):]
+			}
+			if lockSections[current] {
+				current = ""
+			}
+			continue
+		}
+		match := packageLockVersion.FindStringSubmatch(content)
+		if match == nil || current == "" {
+			continue
+		}
+		switch line[0] {
+		case '-':
+			removed[current] = match[1]
+		case '+':
+			added[current] = match[1]
+		}
+	}
+	return pairBumps(file, removed, added)
+}
+
+// parseGoModLine reads a requirement, "require path version" or "path version" in a block
+func parseGoModLine(line string) (string, string, bool) {
+	line, _, _ = strings.Cut(line, "//")
+	fields := strings.Fields(strings.TrimPrefix(line, "require "))
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "v") || !strings.ContainsAny(fields[0], "./") {
+		return "", "", false
+	}
+	return fields[0], fields[1], true
+}
+
+// parsePackageJSONLine reads a dependency entry, skipping the package's own version
+func parsePackageJSONLine(line string) (string, string, bool) {
+	match := packageJSONEntry.FindStringSubmatch(line)
+	if match == nil || match[1] == "version" {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// parseGoSumLine reads a checksum line, the module's go.mod checksum counting as the module
+func parseGoSumLine(line string) (string, string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 || !strings.HasPrefix(fields[1], "v") {
+		return "", "", false
+	}
+	return fields[0], strings.TrimSuffix(fields[1], "/go.mod"), true
+}
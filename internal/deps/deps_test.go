@@ -0,0 +1,80 @@
+package deps
+
+import (
+	"reflect"
+	"testing"
+
+	"git-ai-tools/internal/models"
+)
+
+func TestSummarizeKeepsOtherManifestEdits(t *testing.T) {
+	goMod := `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -1,6 +1,6 @@
+ module example.com/app
+
+-go 1.22
++go 1.23
+
+ require (
+@@ -8,4 +8,4 @@ require (
+-	github.com/google/uuid v1.5.0
++	github.com/google/uuid v1.6.0
+ )
+@@ -20,2 +20,3 @@
+ replace example.com/old => ./old
++replace example.com/lib => ../lib`
+	goSum := `diff --git a/go.sum b/go.sum
+--- a/go.sum
++++ b/go.sum
+@@ -1,2 +1,2 @@
+-github.com/google/uuid v1.5.0 h1:abc=
++github.com/google/uuid v1.6.0 h1:def=`
+	packageJSON := `diff --git a/package.json b/package.json
+--- a/package.json
++++ b/package.json
+@@ -3,3 +3,3 @@
+   "dependencies": {
+-    "vue": "^3.3.0"
++    "vue": "^3.4.0"
+   }`
+	readme := "diff --git a/README.md b/README.md\n@@ -1 +1 @@\n-old\n+new"
+
+	files := []string{"go.mod", "go.sum", "package.json", "README.md"}
+	bumps, rest := Summarize(files, []string{goMod, goSum, packageJSON, readme})
+
+	wantBumps := []models.DependencyBump{
+		{Name: "github.com/google/uuid", From: "v1.5.0", To: "v1.6.0", File: "go.mod"},
+		{Name: "vue", From: "^3.3.0", To: "^3.4.0", File: "package.json"},
+	}
+	if !reflect.DeepEqual(bumps, wantBumps) {
+		t.Errorf("bumps = %+v, want %+v", bumps, wantBumps)
+	}
+
+	wantGoMod := `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -1,6 +1,6 @@
+ module example.com/app
+
+-go 1.22
++go 1.23
+
+ require (
+@@ -20,2 +20,3 @@
+ replace example.com/old => ./old
++replace example.com/lib => ../lib`
+	if rest[0] != wantGoMod {
+		t.Errorf("go.mod rest =\n%s\nwant\n%s", rest[0], wantGoMod)
+	}
+	if rest[1] != "" {
+		t.Errorf("summarized lockfile should be left out, got\n%s", rest[1])
+	}
+	if rest[2] != "" {
+		t.Errorf("package.json with only version changes should be left out, got\n%s", rest[2])
+	}
+	if rest[3] != readme {
+		t.Errorf("other files must be unchanged, got\n%s", rest[3])
+	}
+}
@@ -0,0 +1,142 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+
+	"git-ai-tools/internal/models"
+)
+
+// GetChecksStatus returns the CI check runs or pipeline jobs for a commit
+func (f *ForgeService) GetChecksStatus(config models.ForgeConfig, project, commit string) (*models.ChecksStatus, error) {
+	var checks []models.CheckRun
+	var err error
+
+	switch config.Provider {
+	case models.ForgeGitHub:
+		checks, err = f.getGitHubChecks(config, project, commit)
+	case models.ForgeGitLab:
+		checks, err = f.getGitLabChecks(config, project, commit)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ChecksStatus{
+		Commit: commit,
+		State:  summarizeChecks(checks),
+		Checks: checks,
+	}, nil
+}
+
+// getGitHubChecks combines check runs and legacy commit statuses
+func (f *ForgeService) getGitHubChecks(config models.ForgeConfig, project, commit string) ([]models.CheckRun, error) {
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+		} `json:"check_runs"`
+	}
+	if err := f.getJSON(config, fmt.Sprintf("/repos/%s/commits/%s/check-runs?per_page=100", project, commit), &runs); err != nil {
+		return nil, err
+	}
+
+	checks := []models.CheckRun{}
+	for _, r := range runs.CheckRuns {
+		checks = append(checks, models.CheckRun{
+			Name:       r.Name,
+			Status:     r.Status,
+			Conclusion: r.Conclusion,
+			URL:        r.HTMLURL,
+		})
+	}
+
+	var combined struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			State     string `json:"state"`
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+	if err := f.getJSON(config, fmt.Sprintf("/repos/%s/commits/%s/status", project, commit), &combined); err == nil {
+		for _, s := range combined.Statuses {
+			check := models.CheckRun{Name: s.Context, Status: "completed", URL: s.TargetURL}
+			switch s.State {
+			case "pending":
+				check.Status = "in_progress"
+			case "error":
+				check.Conclusion = "failure"
+			default:
+				check.Conclusion = s.State
+			}
+			checks = append(checks, check)
+		}
+	}
+
+	return checks, nil
+}
+
+// getGitLabChecks returns the jobs of the latest pipeline for the commit
+func (f *ForgeService) getGitLabChecks(config models.ForgeConfig, project, commit string) ([]models.CheckRun, error) {
+	projectID := url.PathEscape(project)
+
+	var pipelines []struct {
+		ID int `json:"id"`
+	}
+	if err := f.getJSON(config, fmt.Sprintf("/projects/%s/pipelines?sha=%s&per_page=1", projectID, commit), &pipelines); err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return []models.CheckRun{}, nil
+	}
+
+	var jobs []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+		WebURL string `json:"web_url"`
+	}
+	if err := f.getJSON(config, fmt.Sprintf("/projects/%s/pipelines/%d/jobs?per_page=100", projectID, pipelines[0].ID), &jobs); err != nil {
+		return nil, err
+	}
+
+	checks := []models.CheckRun{}
+	for _, j := range jobs {
+		check := models.CheckRun{Name: j.Name, URL: j.WebURL}
+		switch j.Status {
+		case "success", "failed", "canceled", "skipped":
+			check.Status = "completed"
+			check.Conclusion = j.Status
+			if j.Status == "failed" {
+				check.Conclusion = "failure"
+			}
+		default:
+			check.Status = "in_progress"
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// summarizeChecks reduces the checks to a single indicator state
+func summarizeChecks(checks []models.CheckRun) string {
+	if len(checks) == 0 {
+		return "none"
+	}
+
+	state := "success"
+	for _, c := range checks {
+		switch {
+		case c.Status != "completed":
+			if state != "failure" {
+				state = "pending"
+			}
+		case c.Conclusion == "failure" || c.Conclusion == "timed_out" || c.Conclusion == "action_required":
+			return "failure"
+		}
+	}
+	return state
+}
@@ -0,0 +1,76 @@
+package forge
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// invalidPathChars cannot appear in folder names on Windows
+const invalidPathChars = `<>:"|?*`
+
+// SuggestClonePath proposes where to clone remoteURL under root
+// The folder is the repository name, optionally below its owner path or host and owner path;
+// "-2", "-3" and so on are appended while the folder exists and is not empty
+func SuggestClonePath(root string, grouping models.CloneGrouping, remoteURL string) (string, error) {
+	if strings.TrimSpace(root) == "" {
+		return "", fmt.Errorf("no clone directory configured")
+	}
+
+	var segments []string
+	if remote, err := ParseRemoteURL(remoteURL); err == nil {
+		parts := strings.Split(remote.Project, "/")
+		switch grouping {
+		case models.CloneGroupHost:
+			segments = append([]string{remote.Host}, parts...)
+		case models.CloneGroupOwner:
+			segments = parts
+		default:
+			segments = parts[len(parts)-1:]
+		}
+	} else {
+		// Local paths and URLs without an owner only have a repository name
+		name := strings.TrimSuffix(path.Base(strings.TrimRight(filepath.ToSlash(strings.TrimSpace(remoteURL)), "/")), ".git")
+		if name == "" || name == "." || name == "/" {
+			return "", fmt.Errorf("cannot derive a folder name from %q", remoteURL)
+		}
+		segments = []string{name}
+	}
+
+	for i, s := range segments {
+		// Each segment must be a single folder name, never a way out of root
+		if s == "" || s == "." || s == ".." || strings.ContainsAny(s, `/\`) {
+			return "", fmt.Errorf("cannot derive a folder name from %q", remoteURL)
+		}
+		segments[i] = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(invalidPathChars, r) {
+				return '-'
+			}
+			return r
+		}, s)
+	}
+	base := filepath.Join(append([]string{root}, segments...)...)
+	if rel, err := filepath.Rel(root, base); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("cannot derive a folder name from %q", remoteURL)
+	}
+
+	suggestion := base
+	for n := 2; !isFreeDestination(suggestion); n++ {
+		suggestion = fmt.Sprintf("%s-%d", base, n)
+	}
+	return suggestion, nil
+}
+
+// isFreeDestination reports whether git clone can use dir, which it can when dir is
+// missing or empty
+func isFreeDestination(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true
+	}
+	return err == nil && len(entries) == 0
+}
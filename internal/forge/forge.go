@@ -0,0 +1,226 @@
+package forge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// ForgeService talks to the GitHub and GitLab APIs
+type ForgeService struct {
+	client *http.Client
+}
+
+// NewForgeService creates a new ForgeService instance
+func NewForgeService() *ForgeService {
+	return &ForgeService{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ListRepositories returns the repositories the token owner can access, filtered by query
+func (f *ForgeService) ListRepositories(config models.ForgeConfig, query string) ([]models.RemoteRepository, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		return f.listGitHubRepositories(config, query)
+	case models.ForgeGitLab:
+		return f.listGitLabRepositories(config, query)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// maxRepositoryPages bounds the pages of 100 repositories fetched from GitHub
+const maxRepositoryPages = 10
+
+// githubRepository is a repository as returned by the GitHub API
+type githubRepository struct {
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	CloneURL    string `json:"clone_url"`
+	SSHURL      string `json:"ssh_url"`
+	HTMLURL     string `json:"html_url"`
+	Private     bool   `json:"private"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// listGitHubRepositories lists repositories owned by the user or their organizations
+// Pages are followed through the Link header, most recently updated first
+func (f *ForgeService) listGitHubRepositories(config models.ForgeConfig, query string) ([]models.RemoteRepository, error) {
+	var repos []githubRepository
+	path := "/user/repos?per_page=100&sort=updated&affiliation=owner,collaborator,organization_member"
+	for page := 0; path != "" && page < maxRepositoryPages; page++ {
+		var batch []githubRepository
+		header, err := f.getJSONWithHeader(config, path, &batch)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, batch...)
+		path = nextPage(config, header)
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	result := []models.RemoteRepository{}
+	for _, r := range repos {
+		if query != "" && !strings.Contains(strings.ToLower(r.FullName+" "+r.Description), query) {
+			continue
+		}
+		result = append(result, models.RemoteRepository{
+			Name:        r.Name,
+			FullName:    r.FullName,
+			Description: r.Description,
+			CloneURL:    r.CloneURL,
+			SSHURL:      r.SSHURL,
+			WebURL:      r.HTMLURL,
+			Private:     r.Private,
+			UpdatedAt:   r.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// listGitLabRepositories lists projects the user is a member of
+func (f *ForgeService) listGitLabRepositories(config models.ForgeConfig, query string) ([]models.RemoteRepository, error) {
+	var projects []struct {
+		Name              string `json:"name"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		Description       string `json:"description"`
+		HTTPURLToRepo     string `json:"http_url_to_repo"`
+		SSHURLToRepo      string `json:"ssh_url_to_repo"`
+		WebURL            string `json:"web_url"`
+		Visibility        string `json:"visibility"`
+		LastActivityAt    string `json:"last_activity_at"`
+	}
+	path := "/projects?membership=true&simple=true&order_by=last_activity_at&per_page=100"
+	if query = strings.TrimSpace(query); query != "" {
+		path += "&search=" + url.QueryEscape(query)
+	}
+	if err := f.getJSON(config, path, &projects); err != nil {
+		return nil, err
+	}
+
+	result := []models.RemoteRepository{}
+	for _, p := range projects {
+		result = append(result, models.RemoteRepository{
+			Name:        p.Name,
+			FullName:    p.PathWithNamespace,
+			Description: p.Description,
+			CloneURL:    p.HTTPURLToRepo,
+			SSHURL:      p.SSHURLToRepo,
+			WebURL:      p.WebURL,
+			Private:     p.Visibility != "public",
+			UpdatedAt:   p.LastActivityAt,
+		})
+	}
+	return result, nil
+}
+
+// linkNext matches the next page in a Link header
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPage returns the API path of the next page named in the Link header, or ""
+// when there is none; links to other hosts are not followed so the token stays put
+func nextPage(config models.ForgeConfig, header http.Header) string {
+	m := linkNext.FindStringSubmatch(header.Get("Link"))
+	if m == nil {
+		return ""
+	}
+	path, ok := strings.CutPrefix(m[1], APIBaseURL(config))
+	if !ok || !strings.HasPrefix(path, "/") {
+		return ""
+	}
+	return path
+}
+
+// APIBaseURL returns the API root for the provider, honouring self-hosted instances
+func APIBaseURL(config models.ForgeConfig) string {
+	baseURL := strings.TrimRight(config.BaseURL, "/")
+	switch config.Provider {
+	case models.ForgeGitHub:
+		if baseURL == "" {
+			return "https://api.github.com"
+		}
+		if !strings.Contains(baseURL, "api.github.com") && !strings.HasSuffix(baseURL, "/api/v3") {
+			return baseURL + "/api/v3"
+		}
+	case models.ForgeGitLab:
+		if baseURL == "" {
+			return "https://gitlab.com/api/v4"
+		}
+		if !strings.HasSuffix(baseURL, "/api/v4") {
+			return baseURL + "/api/v4"
+		}
+	}
+	return baseURL
+}
+
+// getJSON performs an authenticated GET request and decodes the JSON response into v
+func (f *ForgeService) getJSON(config models.ForgeConfig, path string, v interface{}) error {
+	_, err := f.getJSONWithHeader(config, path, v)
+	return err
+}
+
+// getJSONWithHeader is getJSON that also returns the response headers
+func (f *ForgeService) getJSONWithHeader(config models.ForgeConfig, path string, v interface{}) (http.Header, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("access token is required for %s", config.Provider)
+	}
+
+	req, err := http.NewRequest("GET", APIBaseURL(config)+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	switch config.Provider {
+	case models.ForgeGitHub:
+		req.Header.Set("Authorization", "Bearer "+config.Token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+	case models.ForgeGitLab:
+		req.Header.Set("PRIVATE-TOKEN", config.Token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &apiError{status: resp.StatusCode, body: string(body)}
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// apiError is a forge API response with a status other than 200
+type apiError struct {
+	status int
+	body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.status, e.body)
+}
+
+// isNotFound reports whether err is a 404 from the forge API
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound
+}
@@ -0,0 +1,230 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// ListIssues returns the issues of a project matching the filter
+func (f *ForgeService) ListIssues(config models.ForgeConfig, project string, filter models.IssueFilter) ([]models.Issue, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		return f.listGitHubIssues(config, project, filter)
+	case models.ForgeGitLab:
+		return f.listGitLabIssues(config, project, filter)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// GetIssue returns a single issue by number
+func (f *ForgeService) GetIssue(config models.ForgeConfig, project string, number int) (*models.Issue, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		var issue githubIssue
+		if err := f.getJSON(config, fmt.Sprintf("/repos/%s/issues/%d", project, number), &issue); err != nil {
+			return nil, err
+		}
+		result := issue.toModel()
+		return &result, nil
+	case models.ForgeGitLab:
+		var issue gitlabIssue
+		if err := f.getJSON(config, fmt.Sprintf("/projects/%s/issues/%d", url.PathEscape(project), number), &issue); err != nil {
+			return nil, err
+		}
+		result := issue.toModel()
+		return &result, nil
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// githubIssue is the subset of the GitHub issue payload we use
+type githubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	User   struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	HTMLURL     string      `json:"html_url"`
+	UpdatedAt   string      `json:"updated_at"`
+	PullRequest interface{} `json:"pull_request"`
+}
+
+func (i githubIssue) toModel() models.Issue {
+	issue := models.Issue{
+		Number:    i.Number,
+		Key:       "#" + strconv.Itoa(i.Number),
+		Title:     i.Title,
+		State:     i.State,
+		Author:    i.User.Login,
+		Labels:    []string{},
+		Assignees: []string{},
+		URL:       i.HTMLURL,
+		UpdatedAt: i.UpdatedAt,
+	}
+	for _, l := range i.Labels {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	for _, a := range i.Assignees {
+		issue.Assignees = append(issue.Assignees, a.Login)
+	}
+	return issue
+}
+
+// listGitHubIssues lists issues, skipping pull requests which GitHub returns from the same endpoint
+func (f *ForgeService) listGitHubIssues(config models.ForgeConfig, project string, filter models.IssueFilter) ([]models.Issue, error) {
+	params := url.Values{}
+	params.Set("per_page", "100")
+	params.Set("state", "open")
+	if filter.State == "closed" || filter.State == "all" {
+		params.Set("state", filter.State)
+	}
+	if len(filter.Labels) > 0 {
+		params.Set("labels", strings.Join(filter.Labels, ","))
+	}
+	if filter.Assignee != "" {
+		assignee := filter.Assignee
+		if assignee == "me" {
+			var user struct {
+				Login string `json:"login"`
+			}
+			if err := f.getJSON(config, "/user", &user); err != nil {
+				return nil, err
+			}
+			assignee = user.Login
+		}
+		params.Set("assignee", assignee)
+	}
+
+	var issues []githubIssue
+	if err := f.getJSON(config, fmt.Sprintf("/repos/%s/issues?%s", project, params.Encode()), &issues); err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	result := []models.Issue{}
+	for _, i := range issues {
+		if i.PullRequest != nil {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(i.Title), query) && strconv.Itoa(i.Number) != strings.TrimPrefix(query, "#") {
+			continue
+		}
+		result = append(result, i.toModel())
+	}
+	return result, nil
+}
+
+// gitlabIssue is the subset of the GitLab issue payload we use
+type gitlabIssue struct {
+	IID    int      `json:"iid"`
+	Title  string   `json:"title"`
+	State  string   `json:"state"`
+	Labels []string `json:"labels"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Assignees []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	WebURL    string `json:"web_url"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (i gitlabIssue) toModel() models.Issue {
+	issue := models.Issue{
+		Number:    i.IID,
+		Key:       "#" + strconv.Itoa(i.IID),
+		Title:     i.Title,
+		State:     i.State,
+		Author:    i.Author.Username,
+		Labels:    i.Labels,
+		Assignees: []string{},
+		URL:       i.WebURL,
+		UpdatedAt: i.UpdatedAt,
+	}
+	if issue.Labels == nil {
+		issue.Labels = []string{}
+	}
+	for _, a := range i.Assignees {
+		issue.Assignees = append(issue.Assignees, a.Username)
+	}
+	return issue
+}
+
+// listGitLabIssues lists the issues of a GitLab project
+func (f *ForgeService) listGitLabIssues(config models.ForgeConfig, project string, filter models.IssueFilter) ([]models.Issue, error) {
+	params := url.Values{}
+	params.Set("per_page", "100")
+	switch filter.State {
+	case "closed":
+		params.Set("state", "closed")
+	case "all":
+	default:
+		params.Set("state", "opened")
+	}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		params.Set("search", q)
+	}
+	if len(filter.Labels) > 0 {
+		params.Set("labels", strings.Join(filter.Labels, ","))
+	}
+	switch filter.Assignee {
+	case "":
+	case "me":
+		params.Set("scope", "assigned_to_me")
+	default:
+		params.Set("assignee_username", filter.Assignee)
+	}
+
+	var issues []gitlabIssue
+	if err := f.getJSON(config, fmt.Sprintf("/projects/%s/issues?%s", url.PathEscape(project), params.Encode()), &issues); err != nil {
+		return nil, err
+	}
+
+	result := []models.Issue{}
+	for _, i := range issues {
+		result = append(result, i.toModel())
+	}
+	return result, nil
+}
+
+// slugPattern matches runs of characters that are not allowed in a branch slug
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// dashesPattern matches repeated dashes left behind by empty placeholders
+var dashesPattern = regexp.MustCompile(`-{2,}`)
+
+// IssueBranchName builds a branch name from a pattern using {number} and {title} placeholders
+func IssueBranchName(pattern string, issue *models.Issue) string {
+	if pattern == "" {
+		pattern = "issue-{number}-{title}"
+	}
+
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(issue.Title), "-"), "-")
+	if len(slug) > 40 {
+		slug = strings.TrimRight(slug[:40], "-")
+	}
+
+	name := strings.ReplaceAll(pattern, "{number}", strconv.Itoa(issue.Number))
+	name = strings.ReplaceAll(name, "{title}", slug)
+
+	// Titles without latin characters leave empty placeholders behind
+	name = dashesPattern.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-/")
+	name = strings.ReplaceAll(name, "/-", "/")
+	return strings.ReplaceAll(name, "-/", "/")
+}
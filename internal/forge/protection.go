@@ -0,0 +1,187 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// GetBranchProtection returns the push rules the forge enforces on a branch
+func (f *ForgeService) GetBranchProtection(config models.ForgeConfig, project, branch string) (*models.BranchProtection, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		return f.getGitHubProtection(config, project, branch)
+	case models.ForgeGitLab:
+		return f.getGitLabProtection(config, project, branch)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// getGitHubProtection combines classic branch protection with repository rulesets
+// Classic settings beyond required checks are only visible to admins, rulesets to everyone
+func (f *ForgeService) getGitHubProtection(config models.ForgeConfig, project, branch string) (*models.BranchProtection, error) {
+	p := &models.BranchProtection{Branch: branch, RequiredChecks: []string{}}
+	escaped := url.PathEscape(branch)
+
+	var info struct {
+		Protected  bool `json:"protected"`
+		Protection struct {
+			RequiredStatusChecks struct {
+				Contexts []string `json:"contexts"`
+			} `json:"required_status_checks"`
+		} `json:"protection"`
+	}
+	// A branch that does not exist yet has no classic protection, rulesets may still match it
+	if err := f.getJSON(config, fmt.Sprintf("/repos/%s/branches/%s", project, escaped), &info); err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	p.Protected = info.Protected
+	p.RequiredChecks = append(p.RequiredChecks, info.Protection.RequiredStatusChecks.Contexts...)
+
+	if info.Protected {
+		var classic struct {
+			RequiredSignatures struct {
+				Enabled bool `json:"enabled"`
+			} `json:"required_signatures"`
+			RequiredLinearHistory struct {
+				Enabled bool `json:"enabled"`
+			} `json:"required_linear_history"`
+			AllowForcePushes struct {
+				Enabled bool `json:"enabled"`
+			} `json:"allow_force_pushes"`
+			RequiredPullRequestReviews *struct{} `json:"required_pull_request_reviews"`
+			Restrictions               *struct{} `json:"restrictions"`
+		}
+		if err := f.getJSON(config, fmt.Sprintf("/repos/%s/branches/%s/protection", project, escaped), &classic); err == nil {
+			p.Detailed = true
+			p.RequireSignatures = classic.RequiredSignatures.Enabled
+			p.RequireLinearHistory = classic.RequiredLinearHistory.Enabled
+			p.AllowForcePush = classic.AllowForcePushes.Enabled
+			p.RequirePullRequest = classic.RequiredPullRequestReviews != nil
+			p.PushRestricted = classic.Restrictions != nil
+		}
+	} else {
+		p.Detailed = true
+		p.AllowForcePush = true
+	}
+
+	var rules []struct {
+		Type       string `json:"type"`
+		Parameters struct {
+			RequiredStatusChecks []struct {
+				Context string `json:"context"`
+			} `json:"required_status_checks"`
+		} `json:"parameters"`
+	}
+	if err := f.getJSON(config, fmt.Sprintf("/repos/%s/rules/branches/%s", project, escaped), &rules); err == nil {
+		for _, r := range rules {
+			p.Protected = true
+			switch r.Type {
+			case "required_signatures":
+				p.RequireSignatures = true
+			case "required_linear_history":
+				p.RequireLinearHistory = true
+			case "non_fast_forward":
+				p.AllowForcePush = false
+			case "pull_request":
+				p.RequirePullRequest = true
+			case "update":
+				p.PushRestricted = true
+			case "required_status_checks":
+				for _, c := range r.Parameters.RequiredStatusChecks {
+					p.RequiredChecks = append(p.RequiredChecks, c.Context)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// getGitLabProtection reads the protected branch entry and the project's push rules
+// Push rules need a paid tier, so failing to read them is not an error
+func (f *ForgeService) getGitLabProtection(config models.ForgeConfig, project, branch string) (*models.BranchProtection, error) {
+	p := &models.BranchProtection{Branch: branch, Detailed: true, AllowForcePush: true, RequiredChecks: []string{}}
+	projectID := url.PathEscape(project)
+
+	var protected struct {
+		AllowForcePush   bool `json:"allow_force_push"`
+		PushAccessLevels []struct {
+			AccessLevel int `json:"access_level"`
+		} `json:"push_access_levels"`
+	}
+	err := f.getJSON(config, fmt.Sprintf("/projects/%s/protected_branches/%s", projectID, url.PathEscape(branch)), &protected)
+	switch {
+	case err == nil:
+		p.Protected = true
+		p.AllowForcePush = protected.AllowForcePush
+		// Access level 0 means no one may push, changes arrive through merge requests
+		p.PushRestricted = true
+		for _, level := range protected.PushAccessLevels {
+			if level.AccessLevel != 0 {
+				p.PushRestricted = false
+			}
+		}
+		p.RequirePullRequest = p.PushRestricted
+	case !isNotFound(err):
+		return nil, err
+	}
+
+	var rules struct {
+		RejectUnsignedCommits bool `json:"reject_unsigned_commits"`
+	}
+	if err := f.getJSON(config, fmt.Sprintf("/projects/%s/push_rule", projectID), &rules); err == nil {
+		p.RequireSignatures = rules.RejectUnsignedCommits
+		if rules.RejectUnsignedCommits {
+			p.Protected = true
+		}
+	}
+	return p, nil
+}
+
+// PushWarnings lists the rules of p that pushing out would likely break
+func PushWarnings(out models.OutgoingPush, p *models.BranchProtection) []models.PushWarning {
+	warnings := []models.PushWarning{}
+	if p == nil || !p.Protected {
+		return warnings
+	}
+	add := func(kind, message string, commits []string) {
+		if commits == nil {
+			commits = []string{}
+		}
+		warnings = append(warnings, models.PushWarning{Kind: kind, Message: message, Commits: commits})
+	}
+
+	if p.PushRestricted {
+		add(models.PushWarnRestricted, fmt.Sprintf("direct pushes to %s are restricted", p.Branch), nil)
+	}
+	if p.RequirePullRequest {
+		add(models.PushWarnPullRequest, fmt.Sprintf("changes to %s must go through a pull request", p.Branch), nil)
+	}
+	if !out.FastForward && !p.AllowForcePush {
+		add(models.PushWarnForce, fmt.Sprintf("%s has commits the local branch lacks and force pushes are not allowed", p.Branch), nil)
+	}
+
+	var unsigned, merges []string
+	for _, c := range out.Commits {
+		if c.Signature == "N" {
+			unsigned = append(unsigned, c.ShortHash)
+		}
+		if c.Merge {
+			merges = append(merges, c.ShortHash)
+		}
+	}
+	if p.RequireSignatures && len(unsigned) > 0 {
+		add(models.PushWarnSignatures, fmt.Sprintf("%s requires signed commits, %d commits are unsigned", p.Branch, len(unsigned)), unsigned)
+	}
+	if p.RequireLinearHistory && len(merges) > 0 {
+		add(models.PushWarnLinear, fmt.Sprintf("%s requires linear history, %d merge commits would be pushed", p.Branch, len(merges)), merges)
+	}
+	if len(p.RequiredChecks) > 0 && len(out.Commits) > 0 {
+		add(models.PushWarnChecks, fmt.Sprintf("%s requires passing checks (%s) that new commits have not run yet",
+			p.Branch, strings.Join(p.RequiredChecks, ", ")), nil)
+	}
+	return warnings
+}
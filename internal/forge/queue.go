@@ -0,0 +1,158 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// ListReviewRequests returns the open pull or merge requests where the account's user
+// is a requested reviewer, across all projects on the forge
+func (f *ForgeService) ListReviewRequests(config models.ForgeConfig) ([]models.ReviewRequest, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		return f.listGitHubReviewRequests(config)
+	case models.ForgeGitLab:
+		return f.listGitLabReviewRequests(config)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// PullRequestRef returns the ref a forge publishes the head of a pull or merge request under
+func PullRequestRef(provider models.ForgeProvider, number int) string {
+	if provider == models.ForgeGitLab {
+		return fmt.Sprintf("refs/merge-requests/%d/head", number)
+	}
+	return fmt.Sprintf("refs/pull/%d/head", number)
+}
+
+// pullRequestBranch and pullRequestRef match the local branch CheckoutPullRequest creates
+// and the refs PullRequestRef returns
+var (
+	pullRequestBranch = regexp.MustCompile(`^pr/(\d+)$`)
+	pullRequestRef    = regexp.MustCompile(`^refs/(?:pull|merge-requests)/(\d+)/head$`)
+)
+
+// PullRequestNumber returns the pull or merge request a branch was checked out from, read
+// from a pr/<number> name or from an upstream ref such as refs/pull/<number>/head; 0 when
+// neither names one
+func PullRequestNumber(branch, upstreamRef string) int {
+	m := pullRequestBranch.FindStringSubmatch(branch)
+	if m == nil {
+		m = pullRequestRef.FindStringSubmatch(upstreamRef)
+	}
+	if m == nil {
+		return 0
+	}
+	number, _ := strconv.Atoi(m[1])
+	return number
+}
+
+// listGitHubReviewRequests searches the pull requests requesting the token owner's review
+func (f *ForgeService) listGitHubReviewRequests(config models.ForgeConfig) ([]models.ReviewRequest, error) {
+	var result struct {
+		Items []struct {
+			Number        int    `json:"number"`
+			Title         string `json:"title"`
+			HTMLURL       string `json:"html_url"`
+			RepositoryURL string `json:"repository_url"`
+			Draft         bool   `json:"draft"`
+			UpdatedAt     string `json:"updated_at"`
+			User          struct {
+				Login string `json:"login"`
+			} `json:"user"`
+		} `json:"items"`
+	}
+	query := url.QueryEscape("is:pr is:open archived:false review-requested:@me")
+	if err := f.getJSON(config, "/search/issues?per_page=100&q="+query, &result); err != nil {
+		return nil, err
+	}
+
+	requests := []models.ReviewRequest{}
+	for _, item := range result.Items {
+		_, project, ok := strings.Cut(item.RepositoryURL, "/repos/")
+		if !ok {
+			continue
+		}
+		requests = append(requests, models.ReviewRequest{
+			Provider:  models.ForgeGitHub,
+			Host:      hostOf(item.HTMLURL),
+			Project:   project,
+			Number:    item.Number,
+			Title:     item.Title,
+			Author:    item.User.Login,
+			URL:       item.HTMLURL,
+			Draft:     item.Draft,
+			UpdatedAt: item.UpdatedAt,
+			HeadRef:   PullRequestRef(models.ForgeGitHub, item.Number),
+		})
+	}
+	return requests, nil
+}
+
+// listGitLabReviewRequests lists the open merge requests with the account's user as reviewer
+func (f *ForgeService) listGitLabReviewRequests(config models.ForgeConfig) ([]models.ReviewRequest, error) {
+	username := config.Username
+	if username == "" {
+		var user struct {
+			Username string `json:"username"`
+		}
+		if err := f.getJSON(config, "/user", &user); err != nil {
+			return nil, err
+		}
+		username = user.Username
+	}
+
+	var mrs []struct {
+		IID        int    `json:"iid"`
+		Title      string `json:"title"`
+		WebURL     string `json:"web_url"`
+		Draft      bool   `json:"draft"`
+		UpdatedAt  string `json:"updated_at"`
+		References struct {
+			Full string `json:"full"`
+		} `json:"references"`
+		Author struct {
+			Username string `json:"username"`
+		} `json:"author"`
+	}
+	path := "/merge_requests?scope=all&state=opened&per_page=100&reviewer_username=" + url.QueryEscape(username)
+	if err := f.getJSON(config, path, &mrs); err != nil {
+		return nil, err
+	}
+
+	requests := []models.ReviewRequest{}
+	for _, mr := range mrs {
+		project, _, ok := strings.Cut(mr.References.Full, "!")
+		if !ok {
+			continue
+		}
+		requests = append(requests, models.ReviewRequest{
+			Provider:  models.ForgeGitLab,
+			Host:      hostOf(mr.WebURL),
+			Project:   project,
+			Number:    mr.IID,
+			Title:     mr.Title,
+			Author:    mr.Author.Username,
+			URL:       mr.WebURL,
+			Draft:     mr.Draft,
+			UpdatedAt: mr.UpdatedAt,
+			HeadRef:   PullRequestRef(models.ForgeGitLab, mr.IID),
+		})
+	}
+	return requests, nil
+}
+
+// hostOf returns the lower-cased host of a web URL
+func hostOf(webURL string) string {
+	u, err := url.Parse(webURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
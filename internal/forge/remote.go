@@ -0,0 +1,75 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// RemoteInfo identifies a repository on a code hosting service
+type RemoteInfo struct {
+	Host    string
+	Project string
+}
+
+// ParseRemoteURL extracts the host and owner/repo path from a git remote URL
+// Both URL forms (https://host/owner/repo.git) and scp-like forms (git@host:owner/repo.git) are supported
+func ParseRemoteURL(remoteURL string) (*RemoteInfo, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	var host, path string
+
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote URL: %w", err)
+		}
+		host = u.Hostname()
+		path = u.Path
+	} else if idx := strings.Index(remoteURL, ":"); idx > 0 {
+		host = remoteURL[:idx]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		path = remoteURL[idx+1:]
+	} else {
+		return nil, fmt.Errorf("unsupported remote URL: %s", remoteURL)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return nil, fmt.Errorf("unsupported remote URL: %s", remoteURL)
+	}
+	return &RemoteInfo{Host: strings.ToLower(host), Project: path}, nil
+}
+
+// MatchConfig picks the configured forge that hosts the remote
+// Self-hosted instances are matched by the host of their base URL
+func MatchConfig(remote *RemoteInfo, configs []models.ForgeConfig) (models.ForgeConfig, error) {
+	for _, config := range configs {
+		if config.BaseURL == "" {
+			continue
+		}
+		if u, err := url.Parse(config.BaseURL); err == nil && strings.EqualFold(u.Hostname(), remote.Host) {
+			return config, nil
+		}
+	}
+
+	var provider models.ForgeProvider
+	switch {
+	case remote.Host == "github.com":
+		provider = models.ForgeGitHub
+	case remote.Host == "gitlab.com":
+		provider = models.ForgeGitLab
+	default:
+		return models.ForgeConfig{}, fmt.Errorf("no forge configured for %s", remote.Host)
+	}
+
+	for _, config := range configs {
+		if config.Provider == provider && config.BaseURL == "" {
+			return config, nil
+		}
+	}
+	return models.ForgeConfig{}, fmt.Errorf("%s is not configured", provider)
+}
@@ -0,0 +1,196 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// GetPullRequestComments returns the review comments of a pull request: number when it is
+// known, otherwise the open pull request whose head is branch
+func (f *ForgeService) GetPullRequestComments(config models.ForgeConfig, project, branch string, number int) (*models.PullRequestComments, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		return f.getGitHubReviewComments(config, project, branch, number)
+	case models.ForgeGitLab:
+		return f.getGitLabReviewComments(config, project, branch, number)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// githubPull is the part of a GitHub pull request the review comments need
+type githubPull struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// getGitHubReviewComments maps the review comments of pull request number, or of the
+// pull request whose head is the branch in the project's owner when number is 0
+func (f *ForgeService) getGitHubReviewComments(config models.ForgeConfig, project, branch string, number int) (*models.PullRequestComments, error) {
+	var pulls []githubPull
+	if number > 0 {
+		var pull githubPull
+		if err := f.getJSON(config, fmt.Sprintf("/repos/%s/pulls/%d", project, number), &pull); err != nil {
+			return nil, err
+		}
+		pulls = append(pulls, pull)
+	} else {
+		owner := strings.SplitN(project, "/", 2)[0]
+		path := fmt.Sprintf("/repos/%s/pulls?state=open&head=%s", project, url.QueryEscape(owner+":"+branch))
+		if err := f.getJSON(config, path, &pulls); err != nil {
+			return nil, err
+		}
+		if len(pulls) == 0 {
+			return nil, fmt.Errorf("no open pull request for branch %s", branch)
+		}
+	}
+
+	var comments []struct {
+		ID           int64  `json:"id"`
+		Path         string `json:"path"`
+		Line         *int   `json:"line"`
+		OriginalLine *int   `json:"original_line"`
+		Side         string `json:"side"`
+		Body         string `json:"body"`
+		User         struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		HTMLURL     string `json:"html_url"`
+		CreatedAt   string `json:"created_at"`
+		InReplyToID int64  `json:"in_reply_to_id"`
+	}
+	path := fmt.Sprintf("/repos/%s/pulls/%d/comments?per_page=100", project, pulls[0].Number)
+	if err := f.getJSON(config, path, &comments); err != nil {
+		return nil, err
+	}
+
+	result := &models.PullRequestComments{
+		Number:   pulls[0].Number,
+		Title:    pulls[0].Title,
+		URL:      pulls[0].HTMLURL,
+		Comments: []models.ReviewComment{},
+	}
+	for _, c := range comments {
+		comment := models.ReviewComment{
+			ID:        strconv.FormatInt(c.ID, 10),
+			Path:      c.Path,
+			Side:      "new",
+			Body:      c.Body,
+			Author:    c.User.Login,
+			URL:       c.HTMLURL,
+			CreatedAt: c.CreatedAt,
+		}
+		if c.Side == "LEFT" {
+			comment.Side = "old"
+		}
+		// A comment whose line no longer exists in the latest diff has a null line
+		if c.Line != nil {
+			comment.Line = *c.Line
+		} else if c.OriginalLine != nil {
+			comment.Line = *c.OriginalLine
+			comment.Outdated = true
+		}
+		if c.InReplyToID != 0 {
+			comment.InReplyTo = strconv.FormatInt(c.InReplyToID, 10)
+		}
+		result.Comments = append(result.Comments, comment)
+	}
+	return result, nil
+}
+
+// gitlabMergeRequest is the part of a GitLab merge request the review comments need
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	WebURL string `json:"web_url"`
+}
+
+// getGitLabReviewComments maps the diff discussions of merge request number, or of the
+// merge request for the branch when number is 0
+func (f *ForgeService) getGitLabReviewComments(config models.ForgeConfig, project, branch string, number int) (*models.PullRequestComments, error) {
+	projectID := url.PathEscape(project)
+
+	var mergeRequests []gitlabMergeRequest
+	if number > 0 {
+		var mr gitlabMergeRequest
+		if err := f.getJSON(config, fmt.Sprintf("/projects/%s/merge_requests/%d", projectID, number), &mr); err != nil {
+			return nil, err
+		}
+		mergeRequests = append(mergeRequests, mr)
+	} else {
+		path := fmt.Sprintf("/projects/%s/merge_requests?state=opened&source_branch=%s", projectID, url.QueryEscape(branch))
+		if err := f.getJSON(config, path, &mergeRequests); err != nil {
+			return nil, err
+		}
+		if len(mergeRequests) == 0 {
+			return nil, fmt.Errorf("no open merge request for branch %s", branch)
+		}
+	}
+	mr := mergeRequests[0]
+
+	var discussions []struct {
+		ID    string `json:"id"`
+		Notes []struct {
+			ID     int64  `json:"id"`
+			Type   string `json:"type"`
+			Body   string `json:"body"`
+			System bool   `json:"system"`
+			Author struct {
+				Username string `json:"username"`
+			} `json:"author"`
+			CreatedAt string `json:"created_at"`
+			Position  *struct {
+				NewPath string `json:"new_path"`
+				OldPath string `json:"old_path"`
+				NewLine *int   `json:"new_line"`
+				OldLine *int   `json:"old_line"`
+			} `json:"position"`
+		} `json:"notes"`
+	}
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/discussions?per_page=100", projectID, mr.IID)
+	if err := f.getJSON(config, path, &discussions); err != nil {
+		return nil, err
+	}
+
+	result := &models.PullRequestComments{
+		Number:   mr.IID,
+		Title:    mr.Title,
+		URL:      mr.WebURL,
+		Comments: []models.ReviewComment{},
+	}
+	for _, d := range discussions {
+		var first string
+		for _, n := range d.Notes {
+			if n.System || n.Position == nil {
+				continue
+			}
+			comment := models.ReviewComment{
+				ID:        strconv.FormatInt(n.ID, 10),
+				Path:      n.Position.NewPath,
+				Side:      "new",
+				Body:      n.Body,
+				Author:    n.Author.Username,
+				URL:       fmt.Sprintf("%s#note_%d", mr.WebURL, n.ID),
+				CreatedAt: n.CreatedAt,
+				InReplyTo: first,
+			}
+			if n.Position.NewLine != nil {
+				comment.Line = *n.Position.NewLine
+			} else if n.Position.OldLine != nil {
+				comment.Line = *n.Position.OldLine
+				comment.Path = n.Position.OldPath
+				comment.Side = "old"
+			}
+			if first == "" {
+				first = comment.ID
+			}
+			result.Comments = append(result.Comments, comment)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package forge
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// ValidateToken checks an account's token against its forge, returning the user it
+// belongs to with its scopes and expiry
+func (f *ForgeService) ValidateToken(config models.ForgeConfig) (*models.TokenInfo, error) {
+	switch config.Provider {
+	case models.ForgeGitHub:
+		return f.validateGitHubToken(config)
+	case models.ForgeGitLab:
+		return f.validateGitLabToken(config)
+	default:
+		return nil, fmt.Errorf("unsupported forge provider: %s", config.Provider)
+	}
+}
+
+// validateGitHubToken reads the scopes and expiry GitHub reports in response headers
+// Fine-grained tokens report no scopes, so none are treated as missing
+func (f *ForgeService) validateGitHubToken(config models.ForgeConfig) (*models.TokenInfo, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	header, err := f.getJSONWithHeader(config, "/user", &user)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &models.TokenInfo{Username: user.Login, Scopes: []string{}, MissingScopes: []string{}}
+	if scopes := header.Get("X-OAuth-Scopes"); scopes != "" {
+		for _, scope := range strings.Split(scopes, ",") {
+			info.Scopes = append(info.Scopes, strings.TrimSpace(scope))
+		}
+		if !containsAny(info.Scopes, "repo") {
+			info.MissingScopes = append(info.MissingScopes, "repo")
+		}
+	}
+	if expiry := header.Get("GitHub-Authentication-Token-Expiration"); expiry != "" {
+		if t, err := time.Parse("2006-01-02 15:04:05 MST", expiry); err == nil {
+			info.ExpiresAt = t.UTC().Format(time.RFC3339)
+			info.Expired = time.Now().After(t)
+		}
+	}
+	return info, nil
+}
+
+// validateGitLabToken reads the scopes and expiry of the personal access token in use
+func (f *ForgeService) validateGitLabToken(config models.ForgeConfig) (*models.TokenInfo, error) {
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := f.getJSON(config, "/user", &user); err != nil {
+		return nil, err
+	}
+
+	var token struct {
+		Scopes    []string `json:"scopes"`
+		ExpiresAt string   `json:"expires_at"`
+	}
+	info := &models.TokenInfo{Username: user.Username, Scopes: []string{}, MissingScopes: []string{}}
+	if err := f.getJSON(config, "/personal_access_tokens/self", &token); err != nil {
+		// Older instances and OAuth tokens cannot describe themselves
+		return info, nil
+	}
+
+	info.Scopes = append(info.Scopes, token.Scopes...)
+	if !containsAny(info.Scopes, "api", "read_api") {
+		info.MissingScopes = append(info.MissingScopes, "read_api")
+	}
+	if token.ExpiresAt != "" {
+		if t, err := time.Parse("2006-01-02", token.ExpiresAt); err == nil {
+			// The token is valid through its expiry date
+			end := t.AddDate(0, 0, 1)
+			info.ExpiresAt = end.UTC().Format(time.RFC3339)
+			info.Expired = time.Now().After(end)
+		}
+	}
+	return info, nil
+}
+
+// containsAny reports whether list holds any of the values
+func containsAny(list []string, values ...string) bool {
+	for _, item := range list {
+		for _, value := range values {
+			if item == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// Kinds of pages GetWebURLs links to
+const (
+	WebCommit  = "commit"
+	WebFile    = "file"
+	WebBranch  = "branch"
+	WebCompare = "compare"
+)
+
+// URL layouts of the supported hosting services
+const (
+	FlavorGitHub    = "github"
+	FlavorGitLab    = "gitlab"
+	FlavorGitea     = "gitea"
+	FlavorBitbucket = "bitbucket"
+)
+
+// WebFlavor picks the URL layout for a remote, from a configured forge for its host
+// or otherwise from the host name, falling back to GitHub's layout
+func WebFlavor(remote *RemoteInfo, configs []models.ForgeConfig) string {
+	if config, err := MatchConfig(remote, configs); err == nil {
+		switch config.Provider {
+		case models.ForgeGitLab:
+			return FlavorGitLab
+		case models.ForgeGitHub:
+			return FlavorGitHub
+		}
+	}
+
+	switch {
+	case strings.Contains(remote.Host, "gitlab"):
+		return FlavorGitLab
+	case strings.Contains(remote.Host, "bitbucket"):
+		return FlavorBitbucket
+	case strings.Contains(remote.Host, "gitea"), strings.Contains(remote.Host, "codeberg"):
+		return FlavorGitea
+	}
+	return FlavorGitHub
+}
+
+// projectURL returns the browser URL of the remote's project
+func projectURL(remote *RemoteInfo) string {
+	return "https://" + remote.Host + "/" + remote.Project
+}
+
+// CommitURL returns the browser URL of a commit
+func CommitURL(remote *RemoteInfo, flavor, hash string) string {
+	switch flavor {
+	case FlavorGitLab:
+		return projectURL(remote) + "/-/commit/" + url.PathEscape(hash)
+	case FlavorBitbucket:
+		return projectURL(remote) + "/commits/" + url.PathEscape(hash)
+	}
+	return projectURL(remote) + "/commit/" + url.PathEscape(hash)
+}
+
+// FileURL returns the browser URL of a file at a revision, anchored at line when it is positive
+func FileURL(remote *RemoteInfo, flavor, rev, path string, line int) string {
+	path = escapePath(path)
+	var link, anchor string
+	switch flavor {
+	case FlavorGitLab:
+		link = projectURL(remote) + "/-/blob/" + url.PathEscape(rev) + "/" + path
+	case FlavorGitea:
+		kind := "branch"
+		if commitHashPattern.MatchString(rev) {
+			kind = "commit"
+		}
+		link = projectURL(remote) + "/src/" + kind + "/" + url.PathEscape(rev) + "/" + path
+	case FlavorBitbucket:
+		link = projectURL(remote) + "/src/" + url.PathEscape(rev) + "/" + path
+		anchor = "#lines-"
+	default:
+		link = projectURL(remote) + "/blob/" + url.PathEscape(rev) + "/" + path
+	}
+	if line > 0 {
+		if anchor == "" {
+			anchor = "#L"
+		}
+		link += anchor + strconv.Itoa(line)
+	}
+	return link
+}
+
+// BranchURL returns the browser URL of a branch
+func BranchURL(remote *RemoteInfo, flavor, branch string) string {
+	branch = escapePath(branch)
+	switch flavor {
+	case FlavorGitLab:
+		return projectURL(remote) + "/-/tree/" + branch
+	case FlavorGitea:
+		return projectURL(remote) + "/src/branch/" + branch
+	case FlavorBitbucket:
+		return projectURL(remote) + "/branch/" + branch
+	}
+	return projectURL(remote) + "/tree/" + branch
+}
+
+// CompareURL returns the browser URL comparing head against base
+func CompareURL(remote *RemoteInfo, flavor, base, head string) string {
+	switch flavor {
+	case FlavorGitLab:
+		return projectURL(remote) + "/-/compare/" + escapePath(base) + "..." + escapePath(head)
+	case FlavorBitbucket:
+		return projectURL(remote) + "/branches/compare/" + url.PathEscape(head) + "%0D" + url.PathEscape(base)
+	}
+	return projectURL(remote) + "/compare/" + escapePath(base) + "..." + escapePath(head)
+}
+
+// WebURL returns the browser URL of a page of the given kind
+// ref is the commit, the revision of a file, the branch, or "base...head" for a comparison
+func WebURL(remote *RemoteInfo, flavor, kind, ref, path string, line int) (string, error) {
+	switch kind {
+	case WebCommit:
+		return CommitURL(remote, flavor, ref), nil
+	case WebFile:
+		if path == "" {
+			return "", fmt.Errorf("path cannot be empty")
+		}
+		return FileURL(remote, flavor, ref, path, line), nil
+	case WebBranch:
+		return BranchURL(remote, flavor, ref), nil
+	case WebCompare:
+		base, head, ok := strings.Cut(ref, "...")
+		if !ok || base == "" || head == "" {
+			return "", fmt.Errorf("comparison must be given as base...head: %s", ref)
+		}
+		return CompareURL(remote, flavor, base, head), nil
+	}
+	return "", fmt.Errorf("unknown web URL kind: %s", kind)
+}
+
+var commitHashPattern = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// escapePath escapes each segment of a slash separated path, keeping the slashes
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
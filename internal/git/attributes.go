@@ -0,0 +1,38 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MatchesAnyPattern reports whether a repository path matches one of the gitignore-style
+// patterns, as used for protected paths
+func MatchesAnyPattern(patterns []string, file string) bool {
+	return matchesAny(patterns, file)
+}
+
+// GeneratedFiles returns which of the paths .gitattributes marks as linguist-generated
+func (g *GitService) GeneratedFiles(paths []string) (map[string]bool, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	generated := map[string]bool{}
+	if len(paths) == 0 {
+		return generated, nil
+	}
+
+	output, err := g.runGitCommandInput([]byte(strings.Join(paths, "\x00")+"\x00"),
+		"check-attr", "-z", "--stdin", "linguist-generated")
+	if err != nil {
+		return nil, err
+	}
+
+	// Each record is path NUL attribute NUL value NUL
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if value := fields[i+2]; value == "set" || value == "true" {
+			generated[fields[i]] = true
+		}
+	}
+	return generated, nil
+}
@@ -0,0 +1,37 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PushBackup pushes the repository at path to a backup remote, a remote name or URL
+// A mirror push copies every ref and deletes the ones gone locally, otherwise the
+// given branches are force-pushed so the backup always matches the local state
+func (g *GitService) PushBackup(path, remote string, mirror bool, branches []string) error {
+	if path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	if remote == "" || strings.HasPrefix(remote, "-") {
+		return fmt.Errorf("invalid backup remote: %s", remote)
+	}
+
+	args := []string{"push", "--porcelain"}
+	if mirror {
+		args = append(args, "--mirror", remote)
+	} else {
+		if len(branches) == 0 {
+			return fmt.Errorf("no branches selected for backup")
+		}
+		args = append(args, remote)
+		for _, b := range branches {
+			if b == "" || strings.HasPrefix(b, "-") || strings.ContainsAny(b, ": ") {
+				return fmt.Errorf("invalid branch name: %s", b)
+			}
+			args = append(args, "+refs/heads/"+b+":refs/heads/"+b)
+		}
+	}
+
+	_, err := g.runGitCommandIn(path, args...)
+	return err
+}
@@ -0,0 +1,192 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// StagePaths stages a selection of files and directories with a single git call
+// Every selected path gets its own result so partial failures can be shown
+func (g *GitService) StagePaths(paths []string) ([]models.PathResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	list := []string{"ls-files", "-z", "--cached", "--others", "--exclude-standard"}
+	return g.runBatch(paths, list, []string{"add", "-A"}, "no changes to stage")
+}
+
+// UnstagePaths unstages a selection of files and directories with a single git call
+func (g *GitService) UnstagePaths(paths []string) ([]models.PathResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	// Without a commit to reset to, unstaging means removing from the index
+	if !g.HasCommits() {
+		list := []string{"ls-files", "-z", "--cached"}
+		return g.runBatch(paths, list, []string{"rm", "--cached", "-r", "-q"}, "no staged changes")
+	}
+
+	list := []string{"diff", "--cached", "--name-only", "-z"}
+	return g.runBatch(paths, list, []string{"reset", "-q"}, "no staged changes")
+}
+
+// DiscardPaths discards the unstaged changes of a selection of files and directories with a single git call
+func (g *GitService) DiscardPaths(paths []string) ([]models.PathResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	if err := g.CheckProtectedDiscard(paths); err != nil {
+		return nil, err
+	}
+	list := []string{"ls-files", "-z", "--modified"}
+	return g.runBatch(paths, list, []string{"checkout"}, "no unstaged changes")
+}
+
+// runBatch applies run to every selected path that list reports, in one invocation
+// If that invocation fails each path is retried alone to find which ones failed
+func (g *GitService) runBatch(paths []string, list, run []string, noMatch string) ([]models.PathResult, error) {
+	results := make([]models.PathResult, len(paths))
+	var selected []string
+	for i, p := range paths {
+		results[i].Path = p
+		clean, ok := g.normalizeSelection(p)
+		if !ok {
+			results[i].Error = "path is outside the repository"
+			continue
+		}
+		selected = append(selected, clean)
+	}
+	if len(selected) == 0 {
+		return results, nil
+	}
+
+	// Literal pathspecs keep names with glob characters from matching other files
+	listArgs := append(append([]string{"--literal-pathspecs"}, list...), "--")
+	output, err := g.runGitCommandRaw(append(listArgs, selected...)...)
+	if err != nil {
+		return nil, err
+	}
+	var listed []string
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if entry != "" {
+			listed = append(listed, entry)
+		}
+	}
+
+	var matched []string
+	for i, p := range paths {
+		if results[i].Error != "" {
+			continue
+		}
+		clean, _ := g.normalizeSelection(p)
+		if !selectionMatches(clean, listed) {
+			results[i].Error = noMatch
+			continue
+		}
+		matched = append(matched, clean)
+	}
+	if len(matched) == 0 {
+		return results, nil
+	}
+
+	runArgs := append(append([]string{"--literal-pathspecs"}, run...), "--")
+	failed := map[string]string{}
+	if _, err := g.runGitCommand(append(runArgs, matched...)...); err != nil {
+		for _, p := range matched {
+			if _, err := g.runGitCommand(append(runArgs, p)...); err != nil {
+				failed[p] = err.Error()
+			}
+		}
+	}
+
+	for i, p := range paths {
+		if results[i].Error != "" {
+			continue
+		}
+		clean, _ := g.normalizeSelection(p)
+		if msg, ok := failed[clean]; ok {
+			results[i].Error = msg
+			continue
+		}
+		results[i].Success = true
+	}
+	return results, nil
+}
+
+// normalizeSelection turns a selected path into a slash-separated path relative to the repository
+func (g *GitService) normalizeSelection(p string) (string, bool) {
+	return relativePath(g.currentPath, p)
+}
+
+// relativePath turns p into a slash-separated path relative to root, failing when it leaves root
+func relativePath(root, p string) (string, bool) {
+	p = strings.TrimSpace(p)
+	if filepath.IsAbs(p) {
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return "", false
+		}
+		p = rel
+	}
+
+	p = path.Clean(filepath.ToSlash(p))
+	if p == ".." || strings.HasPrefix(p, "../") || strings.HasPrefix(p, "/") {
+		return "", false
+	}
+	return p, true
+}
+
+// selectionMatches reports whether a selected file or directory covers any listed path
+func selectionMatches(selection string, listed []string) bool {
+	for _, entry := range listed {
+		if selection == "." || entry == selection || strings.HasPrefix(entry, selection+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CommitPaths commits only the given files and directories, leaving other staged changes staged
+// Untracked files in the selection are added first
+func (g *GitService) CommitPaths(message string, paths []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message cannot be empty")
+	}
+	specs := g.selectionPathspecs(paths)
+	if len(specs) == 0 {
+		return fmt.Errorf("no paths selected")
+	}
+
+	args := append([]string{"--literal-pathspecs", "ls-files", "-z", "--others", "--exclude-standard", "--"}, specs...)
+	output, err := g.runGitCommandRaw(args...)
+	if err != nil {
+		return err
+	}
+	var untracked []string
+	for _, name := range strings.Split(string(output), "\x00") {
+		if name != "" {
+			untracked = append(untracked, name)
+		}
+	}
+	if len(untracked) > 0 {
+		addArgs := append([]string{"--literal-pathspecs", "add", "--"}, untracked...)
+		if _, err := g.runGitCommand(addArgs...); err != nil {
+			return err
+		}
+	}
+
+	commitArgs := append([]string{"--literal-pathspecs", "commit", "--only", "-m", message, "--"}, specs...)
+	_, err = g.runGitCommand(commitArgs...)
+	return err
+}
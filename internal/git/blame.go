@@ -0,0 +1,117 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// GetBlame annotates every line of filePath with the commit that last changed it
+// An empty rev blames the working tree version, including uncommitted lines
+func (g *GitService) GetBlame(filePath string, rev string) ([]models.BlameLine, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	filePath, err := g.repoPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	rev = strings.TrimSpace(rev)
+	if strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid revision: %s", rev)
+	}
+
+	args := []string{"blame", "--porcelain"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	output, err := g.runGitCommand(append(args, "--", filePath)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlamePorcelain(output), nil
+}
+
+// blameCommit holds the headers git prints only the first time a commit appears
+type blameCommit struct {
+	author, email, summary, path string
+	timestamp                    int64
+	tz                           string
+}
+
+// parseBlamePorcelain parses git blame --porcelain output
+// Each line starts with "<hash> <orig> <final> [<count>]", followed by the commit's headers
+// when it is new, and ends with the content prefixed by a tab. Hashes are SHA-1 or SHA-256,
+// and lines not committed yet have an all-zero hash
+func parseBlamePorcelain(output string) []models.BlameLine {
+	lines := []models.BlameLine{}
+	commits := map[string]*blameCommit{}
+	var current *models.BlameLine
+	var commit *blameCommit
+
+	for _, line := range strings.Split(output, "\n") {
+		if current == nil {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			orig, origErr := strconv.Atoi(fields[1])
+			final, finalErr := strconv.Atoi(fields[2])
+			if origErr != nil || finalErr != nil {
+				continue
+			}
+			current = &models.BlameLine{Line: final, OrigLine: orig, Hash: fields[0], ShortHash: shortHash(fields[0])}
+			if commit = commits[fields[0]]; commit == nil {
+				commit = &blameCommit{}
+				commits[fields[0]] = commit
+			}
+			continue
+		}
+
+		if content, ok := strings.CutPrefix(line, "\t"); ok {
+			current.Author = commit.author
+			current.Email = commit.email
+			current.Summary = commit.summary
+			current.OrigPath = commit.path
+			current.Timestamp = commit.timestamp
+			current.Date = blameDate(commit.timestamp, commit.tz)
+			current.Uncommitted = strings.Trim(current.Hash, "0") == ""
+			current.Content = strings.TrimSuffix(content, "\r")
+			lines = append(lines, *current)
+			current = nil
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			commit.author = value
+		case "author-mail":
+			commit.email = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			commit.timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "author-tz":
+			commit.tz = value
+		case "summary":
+			commit.summary = value
+		case "filename":
+			commit.path = value
+		}
+	}
+	return lines
+}
+
+// blameDate formats a blame timestamp in its author's time zone like --date=iso
+func blameDate(timestamp int64, tz string) string {
+	if timestamp == 0 {
+		return ""
+	}
+	loc := time.UTC
+	if t, err := time.Parse("-0700", tz); err == nil {
+		loc = t.Location()
+	}
+	return time.Unix(timestamp, 0).In(loc).Format("2006-01-02 15:04:05 -0700")
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// BranchChanges returns the commits of HEAD that are not on base, oldest first, and the
+// cumulative diff since their merge base, which is what a pull request into base shows
+func (g *GitService) BranchChanges(base string) (*models.BranchChanges, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if base == "" {
+		return nil, fmt.Errorf("base branch cannot be empty")
+	}
+	if strings.HasPrefix(base, "-") {
+		return nil, fmt.Errorf("invalid base branch: %s", base)
+	}
+	if _, err := g.ResolveCommit(base); err != nil {
+		return nil, err
+	}
+
+	head, err := g.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if head == "" {
+		head = "HEAD"
+	}
+
+	commits, err := g.logCommits("--reverse", base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	changes := &models.BranchChanges{Base: base, Head: head, Commits: commits}
+	if len(changes.Commits) == 0 {
+		return nil, fmt.Errorf("%s has no commits that are not on %s", head, base)
+	}
+
+	changes.Diff, err = g.runGitCommandLimited("diff", "--no-color", base+"...HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CommitsBetween returns the commits after from up to to, newest first and without merges
+// An empty from covers the whole history of to, an empty to means HEAD
+func (g *GitService) CommitsBetween(from, to string) ([]models.BranchCommit, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	toHash, err := g.ResolveCommit(to)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"--no-merges", toHash}
+	if from != "" {
+		fromHash, err := g.ResolveCommit(from)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "^"+fromHash)
+	}
+	return g.logCommits(args...)
+}
+
+// LatestTag returns the newest tag reachable from ref, not counting a tag on ref itself,
+// or "" when there is none
+func (g *GitService) LatestTag(ref string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	hash, err := g.ResolveCommit(ref)
+	if err != nil {
+		return "", err
+	}
+	// A release is usually described up to its own tag, which must not be its start
+	if _, err := g.runGitCommand("rev-parse", "--verify", "--quiet", hash+"^"); err != nil {
+		return "", nil
+	}
+	tag, err := g.runGitCommand("describe", "--tags", "--abbrev=0", hash+"^")
+	if err != nil {
+		return "", nil
+	}
+	return strings.TrimSpace(tag), nil
+}
+
+// logCommits lists the commits git log selects with args, with their full messages
+func (g *GitService) logCommits(args ...string) ([]models.BranchCommit, error) {
+	output, err := g.runGitCommand(append([]string{"log", "--format=%H%x1f%h%x1f%s%x1f%b%x1e"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	commits := []models.BranchCommit{}
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) < 4 {
+			continue
+		}
+		commits = append(commits, models.BranchCommit{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Subject:   fields[2],
+			Body:      strings.TrimSpace(fields[3]),
+		})
+	}
+	return commits, nil
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// CleanPaths removes untracked files and directories in a selection with a single git call
+// Ignored files are kept
+func (g *GitService) CleanPaths(paths []string) ([]models.PathResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	if err := g.CheckProtectedClean(paths); err != nil {
+		return nil, err
+	}
+	list := []string{"ls-files", "-z", "--others", "--exclude-standard"}
+	return g.runBatch(paths, list, []string{"clean", "-f", "-d", "-q"}, "no untracked files")
+}
+
+// DiscardablePatch returns the unstaged changes of paths as a binary patch
+// It is what discarding those paths would lose
+func (g *GitService) DiscardablePatch(paths []string) ([]byte, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	specs := g.selectionPathspecs(paths)
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	args := append([]string{"--literal-pathspecs", "diff", "--binary", "--"}, specs...)
+	return g.runGitCommandRaw(args...)
+}
+
+// UntrackedFiles returns the untracked, not ignored files under paths, mapping their
+// repository-relative paths to their location on disk
+func (g *GitService) UntrackedFiles(paths []string) (map[string]string, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	files := map[string]string{}
+	specs := g.selectionPathspecs(paths)
+	if len(specs) == 0 {
+		return files, nil
+	}
+	args := append([]string{"--literal-pathspecs", "ls-files", "-z", "--others", "--exclude-standard", "--"}, specs...)
+	output, err := g.runGitCommandRaw(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range strings.Split(string(output), "\x00") {
+		if name != "" {
+			files[name] = filepath.Join(g.currentPath, filepath.FromSlash(name))
+		}
+	}
+	return files, nil
+}
+
+// ApplyPatch applies a patch to the working tree
+func (g *GitService) ApplyPatch(patch []byte) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	_, err := g.runGitCommandInput(patch, "apply", "--binary", "-")
+	return err
+}
+
+// RestoreFile writes a file back into the working tree, refusing to overwrite an existing one
+func (g *GitService) RestoreFile(name string, content []byte) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	clean, ok := g.normalizeSelection(name)
+	if !ok || clean == "." {
+		return fmt.Errorf("path is outside the repository: %s", name)
+	}
+	target := filepath.Join(g.currentPath, filepath.FromSlash(clean))
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("file already exists: %s", clean)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, content, 0644)
+}
+
+// selectionPathspecs normalizes selected paths, dropping the ones outside the repository
+func (g *GitService) selectionPathspecs(paths []string) []string {
+	var specs []string
+	for _, p := range paths {
+		if clean, ok := g.normalizeSelection(p); ok {
+			specs = append(specs, clean)
+		}
+	}
+	return specs
+}
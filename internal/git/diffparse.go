@@ -0,0 +1,202 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// hunkHeader matches "@@ -old,count +new,count @@ section", counts default to 1 when omitted
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// truncationMarker starts the line runGitCommandLimited appends to cut output
+const truncationMarker = "[output truncated:"
+
+// GetStructuredDiff returns the diff of the given file parsed into files, hunks and lines
+func (g *GitService) GetStructuredDiff(filePath string, staged bool) (*models.StructuredDiff, error) {
+	diff, err := g.GetDiff(filePath, staged)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiff(diff), nil
+}
+
+// ParseDiff parses unified diff output from git into files, hunks and numbered lines
+func ParseDiff(diff string) *models.StructuredDiff {
+	result := &models.StructuredDiff{Files: []models.DiffFile{}}
+	var file *models.DiffFile
+	var hunk *models.DiffHunk
+	var oldLine, newLine, oldLeft, newLeft int
+
+	flushHunk := func() {
+		if file != nil && hunk != nil {
+			file.Hunks = append(file.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	flushFile := func() {
+		flushHunk()
+		if file != nil {
+			result.Files = append(result.Files, *file)
+		}
+		file = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n") {
+		// Inside a hunk every line belongs to it until both sides are used up
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			dl := models.DiffLine{}
+			switch {
+			case strings.HasPrefix(line, "+"):
+				dl.Type = models.DiffLineAdded
+				dl.NewLine = newLine
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				dl.Type = models.DiffLineRemoved
+				dl.OldLine = oldLine
+				oldLine++
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+				markNoNewline(hunk)
+				continue
+			default:
+				dl.Type = models.DiffLineContext
+				dl.OldLine, dl.NewLine = oldLine, newLine
+				oldLine++
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+			if line != "" {
+				dl.Content = line[1:]
+			}
+			hunk.Lines = append(hunk.Lines, dl)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, truncationMarker):
+			result.Truncated = true
+		case strings.HasPrefix(line, "diff --git "):
+			flushFile()
+			file = &models.DiffFile{Status: models.DiffModified, Hunks: []models.DiffHunk{}}
+			file.OldPath, file.NewPath = splitDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+		case file == nil:
+			// Preamble before the first file header
+		case strings.HasPrefix(line, `\`):
+			if hunk != nil {
+				markNoNewline(hunk)
+			}
+		case strings.HasPrefix(line, "@@ "):
+			flushHunk()
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			hunk = &models.DiffHunk{Header: line, Section: m[5], Lines: []models.DiffLine{}}
+			hunk.OldStart, hunk.OldLines = hunkRange(m[1], m[2])
+			hunk.NewStart, hunk.NewLines = hunkRange(m[3], m[4])
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+		case strings.HasPrefix(line, "new file mode"):
+			file.Status = models.DiffAdded
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.Status = models.DiffDeleted
+		case strings.HasPrefix(line, "rename from "):
+			file.Status = models.DiffRenamed
+			file.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			file.Status = models.DiffRenamed
+			file.NewPath = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			file.Binary = true
+		case strings.HasPrefix(line, "--- "):
+			if p := diffHeaderPath(line, "--- "); p != "/dev/null" {
+				file.OldPath = strings.TrimPrefix(unquoteDiffPath(p), "a/")
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if p := diffHeaderPath(line, "+++ "); p != "/dev/null" {
+				file.NewPath = strings.TrimPrefix(unquoteDiffPath(p), "b/")
+			}
+		}
+	}
+	flushFile()
+
+	return result
+}
+
+// markNoNewline flags the last line of a hunk as lacking a trailing newline
+func markNoNewline(hunk *models.DiffHunk) {
+	if n := len(hunk.Lines); n > 0 {
+		hunk.Lines[n-1].NoNewline = true
+	}
+}
+
+// hunkRange converts the start and optional count of a hunk header side
+func hunkRange(start, count string) (int, int) {
+	s, _ := strconv.Atoi(start)
+	if count == "" {
+		return s, 1
+	}
+	c, _ := strconv.Atoi(count)
+	return s, c
+}
+
+// SplitFileDiffs cuts the output of git diff into the diff of each file, returning the
+// new path of every file alongside its diff
+func SplitFileDiffs(diff string) ([]string, []string) {
+	var paths, diffs []string
+	var current []string
+	for _, line := range strings.Split(diff, "\n") {
+		if rest, ok := strings.CutPrefix(line, "diff --git "); ok {
+			if len(current) > 0 {
+				diffs = append(diffs, strings.Join(current, "\n"))
+			}
+			_, newPath := splitDiffGitPaths(rest)
+			paths = append(paths, newPath)
+			current = nil
+		}
+		if len(paths) > 0 {
+			current = append(current, line)
+		}
+	}
+	if len(current) > 0 {
+		diffs = append(diffs, strings.TrimRight(strings.Join(current, "\n"), "\n"))
+	}
+	return paths, diffs
+}
+
+// splitDiffGitPaths extracts both paths from the rest of a "diff --git a/x b/y" line
+// The split is ambiguous when a path contains " b/", later header lines correct it
+func splitDiffGitPaths(rest string) (string, string) {
+	if strings.HasPrefix(rest, `"`) {
+		if end := strings.Index(rest[1:], `" `); end >= 0 {
+			oldPath := unquoteDiffPath(rest[:end+2])
+			newPath := unquoteDiffPath(rest[end+3:])
+			return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(newPath, "b/")
+		}
+	}
+	if i := strings.Index(rest, " b/"); i >= 0 {
+		return strings.TrimPrefix(rest[:i], "a/"), rest[i+3:]
+	}
+	return rest, rest
+}
+
+// diffHeaderPath returns the path of a "---" or "+++" line, git ends it with a tab when
+// the name contains a space
+func diffHeaderPath(line, prefix string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(line, prefix), "\t")
+}
+
+// unquoteDiffPath decodes a path git quoted because of special characters
+func unquoteDiffPath(p string) string {
+	if len(p) >= 2 && strings.HasPrefix(p, `"`) && strings.HasSuffix(p, `"`) {
+		if s, err := strconv.Unquote(p); err == nil {
+			return s
+		}
+	}
+	return p
+}
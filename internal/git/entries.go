@@ -0,0 +1,72 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// Git file modes of the entries that are not regular files
+const (
+	modeSymlink = "120000"
+	modeGitlink = "160000"
+)
+
+// entryType classifies a status entry from its file modes
+// submodules holds the .gitmodules paths and is loaded on first use
+func (g *GitService) entryType(modes []string, path string, submodules *map[string]bool) string {
+	kind := models.EntryFile
+	for _, mode := range modes {
+		switch mode {
+		case modeGitlink:
+			if *submodules == nil {
+				*submodules = g.submodulePaths()
+			}
+			if (*submodules)[path] {
+				return models.EntrySubmodule
+			}
+			return models.EntryGitlink
+		case modeSymlink:
+			kind = models.EntrySymlink
+		}
+	}
+	return kind
+}
+
+// isCommitEntry reports whether an entry records a commit rather than file content
+func isCommitEntry(entryType string) bool {
+	return entryType == models.EntrySubmodule || entryType == models.EntryGitlink
+}
+
+// submodulePaths returns the paths registered in .gitmodules
+func (g *GitService) submodulePaths() map[string]bool {
+	paths := map[string]bool{}
+	output, err := g.runGitCommand("config", "--file", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`)
+	if err != nil {
+		return paths
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if _, path, ok := strings.Cut(line, " "); ok {
+			paths[path] = true
+		}
+	}
+	return paths
+}
+
+// worktreeSubmodule describes the unstaged state of a submodule from the porcelain v2
+// sub field "S<c><m><u>" and the commit recorded in the index
+func (g *GitService) worktreeSubmodule(path, sub, indexCommit string) *models.SubmoduleChange {
+	change := &models.SubmoduleChange{OldCommit: indexCommit, NewCommit: indexCommit}
+	if len(sub) == 4 && sub[0] == 'S' {
+		change.CommitChanged = sub[1] == 'C'
+		change.HasModifications = sub[2] == 'M'
+		change.HasUntracked = sub[3] == 'U'
+	}
+	if change.CommitChanged {
+		if head, err := g.runGitCommandIn(filepath.Join(g.currentPath, filepath.FromSlash(path)), "rev-parse", "HEAD"); err == nil {
+			change.NewCommit = strings.TrimSpace(head)
+		}
+	}
+	return change
+}
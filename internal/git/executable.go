@@ -0,0 +1,64 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"git-ai-tools/internal/models"
+)
+
+var (
+	executableMu sync.RWMutex
+	executable   = models.GitExecutable{Path: "git"}
+)
+
+// SetExecutable sets the git binary and global options used by every git command
+// An empty path goes back to "git" from PATH
+func SetExecutable(exe models.GitExecutable) error {
+	exe.Path = strings.TrimSpace(exe.Path)
+	if exe.Path == "" {
+		exe.Path = "git"
+	}
+	for i, arg := range exe.Args {
+		// Only global options are allowed, a subcommand here would run on every call
+		if !strings.HasPrefix(arg, "-") && (i == 0 || (exe.Args[i-1] != "-c" && exe.Args[i-1] != "-C")) {
+			return fmt.Errorf("not a git option: %s", arg)
+		}
+	}
+
+	executableMu.Lock()
+	defer executableMu.Unlock()
+	executable = exe
+	return nil
+}
+
+// Executable returns the git binary in use
+func Executable() string {
+	executableMu.RLock()
+	defer executableMu.RUnlock()
+	return executable.Path
+}
+
+// Version runs "git version" with the given executable, checking that it works
+func Version(exe models.GitExecutable) (string, error) {
+	path := strings.TrimSpace(exe.Path)
+	if path == "" {
+		path = "git"
+	}
+	cmd := exec.Command(path, append(append([]string{}, exe.Args...), "version")...)
+	configureProcess(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s: %w\n%s", path, err, out)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// executableArgs returns the git binary and the configured global options
+func executableArgs() (string, []string) {
+	executableMu.RLock()
+	defer executableMu.RUnlock()
+	return executable.Path, executable.Args
+}
@@ -0,0 +1,151 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// Fetch updates the remote-tracking refs of remote, removing those whose branch is gone
+// when prune is set and fetching all tags when tags is set
+// The result lists the refs that moved or appeared and the refs that were pruned
+func (g *GitService) Fetch(remote string, prune, tags bool) (*models.FetchResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if remote == "" {
+		return nil, fmt.Errorf("remote cannot be empty")
+	}
+	if strings.HasPrefix(remote, "-") {
+		return nil, fmt.Errorf("invalid remote name: %s", remote)
+	}
+
+	patterns := []string{"refs/remotes/" + remote + "/", "refs/tags/"}
+	before, err := g.refSnapshot(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"fetch"}
+	if prune {
+		args = append(args, "--prune")
+	}
+	if tags {
+		args = append(args, "--tags")
+	}
+	if _, err := g.runGitCommand(append(args, remote)...); err != nil {
+		return nil, err
+	}
+
+	after, err := g.refSnapshot(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.FetchResult{Remote: remote, Updated: []models.RefUpdate{}, Pruned: []string{}}
+	for ref, hash := range after {
+		if old, ok := before[ref]; !ok || old != hash {
+			result.Updated = append(result.Updated, models.RefUpdate{Ref: shortRefName(ref), OldHash: old, NewHash: hash})
+		}
+	}
+	for ref := range before {
+		if _, ok := after[ref]; !ok {
+			result.Pruned = append(result.Pruned, shortRefName(ref))
+		}
+	}
+	sort.Slice(result.Updated, func(i, j int) bool { return result.Updated[i].Ref < result.Updated[j].Ref })
+	sort.Strings(result.Pruned)
+	return result, nil
+}
+
+// FetchAll fetches every remote in turn
+// A remote that fails does not stop the others, its error is reported in its result
+func (g *GitService) FetchAll(prune, tags bool) ([]models.FetchResult, error) {
+	remotes, err := g.GetRemoteNames()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []models.FetchResult{}
+	for _, remote := range remotes {
+		result, err := g.Fetch(remote, prune, tags)
+		if err != nil {
+			result = &models.FetchResult{Remote: remote, Updated: []models.RefUpdate{}, Pruned: []string{}, Error: err.Error()}
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// refSnapshot maps the refs under the given prefixes to the objects they point at
+// Symbolic refs such as origin/HEAD are left out
+func (g *GitService) refSnapshot(prefixes ...string) (map[string]string, error) {
+	out, err := g.runGitCommand(append([]string{"for-each-ref", "--format=%(objectname) %(refname) %(symref)"}, prefixes...)...)
+	if err != nil {
+		return nil, err
+	}
+	refs := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			refs[fields[1]] = fields[0]
+		}
+	}
+	return refs, nil
+}
+
+// shortRefName drops the refs/remotes/ or refs/tags/ prefix of a ref
+func shortRefName(ref string) string {
+	if name, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+		return name
+	}
+	return strings.TrimPrefix(ref, "refs/")
+}
+
+// LastFetchTime returns when remote-tracking refs were last updated by a fetch or pull
+// It reports false when the repository has never been fetched
+func (g *GitService) LastFetchTime() (time.Time, bool) {
+	if g.currentPath == "" {
+		return time.Time{}, false
+	}
+
+	gitDir, err := g.gitDir()
+	if err != nil {
+		return time.Time{}, false
+	}
+	info, err := os.Stat(filepath.Join(gitDir, "FETCH_HEAD"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// FetchRefToBranch fetches a single ref from remote into a local branch, such as the head
+// of a pull request, replacing the branch when the ref was force-pushed
+// The checked out branch is fast-forwarded instead, as git refuses to replace it
+func (g *GitService) FetchRefToBranch(remote, ref, branch string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	if remote == "" || strings.HasPrefix(remote, "-") {
+		return fmt.Errorf("invalid remote name: %s", remote)
+	}
+	if !strings.HasPrefix(ref, "refs/") || strings.ContainsAny(ref, ": ") {
+		return fmt.Errorf("invalid ref: %s", ref)
+	}
+	if _, err := g.runGitCommand("check-ref-format", "--branch", branch); err != nil {
+		return fmt.Errorf("invalid branch name: %s", branch)
+	}
+
+	if current, err := g.CurrentBranch(); err == nil && current == branch {
+		_, err := g.runGitCommandEnv(noPromptEnv, "pull", "--ff-only", remote, ref)
+		return err
+	}
+	_, err := g.runGitCommandEnv(noPromptEnv, "fetch", remote, "+"+ref+":refs/heads/"+branch)
+	return err
+}
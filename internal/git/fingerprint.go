@@ -0,0 +1,55 @@
+package git
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// GitDir returns the absolute path of the repository's git directory
+func (g *GitService) GitDir() (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return g.gitDir()
+}
+
+// WorktreeFingerprint hashes the status of the working tree, which changes when files are
+// modified, staged, created or deleted
+// It runs without optional locks so the index is never refreshed, letting watchers rely
+// on the index file changing only when the index does. Watchers poll it, so it is not
+// traced like other commands
+func (g *GitService) WorktreeFingerprint() (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	cmd := newGitCommand(g.currentPath, "--no-optional-locks", "status", "--porcelain=v2", "-z", "--untracked-files=normal")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git status failed: %w", err)
+	}
+	sum := sha1.Sum(output)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// IgnoredDirectories returns the directories of the working tree that are ignored as a
+// whole, relative to the repository root and ending in a slash, such as "node_modules/"
+func (g *GitService) IgnoredDirectories() ([]string, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	output, err := g.runGitCommand("ls-files", "-z", "--others", "--ignored", "--exclude-standard", "--directory")
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{}
+	for _, name := range strings.Split(output, "\x00") {
+		if strings.HasSuffix(name, "/") {
+			dirs = append(dirs, name)
+		}
+	}
+	return dirs, nil
+}
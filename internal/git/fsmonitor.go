@@ -0,0 +1,78 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// fsmonitorThreshold is the tracked file count above which fsmonitor is recommended
+const fsmonitorThreshold = 50000
+
+// GetFSMonitorStatus reports whether the filesystem monitor is available and enabled
+// Mode is "builtin" for git's own daemon, or the hook command (e.g. Watchman) otherwise
+func (g *GitService) GetFSMonitorStatus() (*models.FSMonitorStatus, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	status := &models.FSMonitorStatus{Supported: true}
+	if _, err := g.runGitCommand("fsmonitor--daemon", "status"); err == nil {
+		status.DaemonRunning = true
+	} else if strings.Contains(err.Error(), "not supported") || strings.Contains(err.Error(), "is not a git command") {
+		status.Supported = false
+	}
+
+	if mode, err := g.runGitCommand("config", "--get", "core.fsmonitor"); err == nil {
+		switch strings.TrimSpace(mode) {
+		case "true":
+			status.Mode = "builtin"
+			status.Enabled = true
+		case "false", "":
+		default:
+			status.Mode = strings.TrimSpace(mode)
+			status.Enabled = true
+		}
+	}
+	if cache, err := g.runGitCommand("config", "--get", "--type=bool", "core.untrackedCache"); err == nil {
+		status.UntrackedCache = strings.TrimSpace(cache) == "true"
+	}
+
+	if output, err := g.runGitCommandRaw("ls-files", "-z"); err == nil {
+		status.TrackedFiles = bytes.Count(output, []byte{0})
+	}
+	status.Recommended = status.Supported && !status.Enabled && status.TrackedFiles >= fsmonitorThreshold
+	return status, nil
+}
+
+// SetFSMonitor turns git's builtin filesystem monitor and the untracked cache on or off
+// for the current repository
+func (g *GitService) SetFSMonitor(enabled bool) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if !enabled {
+		// Exit code 5 means the key was not set, which is already the goal
+		if _, err := g.runGitCommand("config", "--unset", "core.fsmonitor"); err != nil && !strings.Contains(err.Error(), "exit status 5") {
+			return err
+		}
+		g.runGitCommand("fsmonitor--daemon", "stop")
+		return nil
+	}
+
+	status, err := g.GetFSMonitorStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Supported {
+		return fmt.Errorf("the builtin filesystem monitor is not supported by this git or platform")
+	}
+	if _, err := g.runGitCommand("config", "core.fsmonitor", "true"); err != nil {
+		return err
+	}
+	_, err = g.runGitCommand("config", "core.untrackedCache", "true")
+	return err
+}
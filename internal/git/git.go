@@ -1,20 +1,29 @@
 package git
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
-	"syscall"
+	"sync"
+	"sync/atomic"
 
 	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/trace"
 )
 
 // GitService handles git operations
 type GitService struct {
 	currentPath string
+	scope       string
+	protection  protection
+	maxOutput   atomic.Int64
+	trial       trial
+	rewrites    rewriteGuard
 }
 
 // NewGitService creates a new GitService instance
@@ -30,6 +39,9 @@ func (g *GitService) Clone(opts models.CloneOptions) error {
 	if opts.Path == "" {
 		return fmt.Errorf("path cannot be empty")
 	}
+	if strings.HasPrefix(opts.URL, "-") || strings.HasPrefix(opts.URL, "ext::") || strings.HasPrefix(opts.URL, "fd::") {
+		return fmt.Errorf("invalid repository URL: %s", opts.URL)
+	}
 
 	// Check if the destination path already exists
 	if _, err := os.Stat(opts.Path); err == nil {
@@ -44,7 +56,10 @@ func (g *GitService) Clone(opts models.CloneOptions) error {
 	if opts.Branch != "" {
 		args = append(args, "-b", opts.Branch)
 	}
-	args = append(args, opts.URL, opts.Path)
+	if opts.Depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", opts.Depth))
+	}
+	args = append(args, "--", opts.URL, opts.Path)
 
 	_, err := g.runGitCommand(args...)
 	if err != nil {
@@ -53,6 +68,7 @@ func (g *GitService) Clone(opts models.CloneOptions) error {
 
 	// Set the cloned repository as the current path
 	g.currentPath = opts.Path
+	g.scope = ""
 	return nil
 }
 
@@ -61,8 +77,12 @@ func (g *GitService) GetRemotes() ([]models.Remote, error) {
 	if g.currentPath == "" {
 		return nil, fmt.Errorf("no repository selected")
 	}
+	return g.GetRemotesAt(g.currentPath)
+}
 
-	output, err := g.runGitCommand("remote", "-v")
+// GetRemotesAt returns all remotes of the repository at path, without selecting it
+func (g *GitService) GetRemotesAt(path string) ([]models.Remote, error) {
+	output, err := g.runGitCommandIn(path, "remote", "-v")
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +129,47 @@ func (g *GitService) AddRemote(name, url string) error {
 	return err
 }
 
+// GetRemoteURL returns the fetch URL of a remote
+func (g *GitService) GetRemoteURL(name string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	if name == "" {
+		name = "origin"
+	}
+
+	output, err := g.runGitCommand("remote", "get-url", name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// ResolveCommit returns the full hash of the commit a ref points to
+// Abbreviated hashes are expanded, ambiguous or unknown ones are rejected
+func (g *GitService) ResolveCommit(ref string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	output, err := g.runGitCommand("rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown revision: %s", ref)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// shortHash abbreviates a full commit hash for display
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
 // RemoveRemote removes an existing remote
 func (g *GitService) RemoveRemote(name string) error {
 	if g.currentPath == "" {
@@ -124,18 +185,88 @@ func (g *GitService) RemoveRemote(name string) error {
 
 // SetPath sets the current working directory
 func (g *GitService) SetPath(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	path = cleanDir(path)
+
 	// Check if it's a valid directory
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return fmt.Errorf("directory does not exist: %s", path)
 	}
 
-	// Check if it's a git repository
-	gitDir := filepath.Join(path, ".git")
-	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-		return fmt.Errorf("not a git repository: %s", path)
+	// Ask git so worktrees and submodules, where .git is a file, are accepted too
+	root, err := g.FindRepositoryRoot(path)
+	if err != nil {
+		return err
+	}
+
+	g.currentPath = root
+	g.scope = ""
+	return nil
+}
+
+// samePath reports whether two paths name the same directory once symlinks are resolved
+// Windows paths compare case-insensitively
+func samePath(a, b string) bool {
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(ra, rb)
+	}
+	return ra == rb
+}
+
+// FindRepositoryRoot returns the top-level directory of the repository containing path,
+// including worktrees and submodules where .git is a file. path itself is kept when it is
+// the top level, even when reached through a symlink, so it keeps matching the saved
+// repository entries. Bare repositories have no working tree and are refused
+func (g *GitService) FindRepositoryRoot(path string) (string, error) {
+	path = cleanDir(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("path does not exist: %s", path)
+	}
+	if !info.IsDir() {
+		path = filepath.Dir(path)
+	}
+
+	if bare, err := g.runGitCommandIn(path, "rev-parse", "--is-bare-repository"); err != nil {
+		return "", fmt.Errorf("not inside a git repository: %s", path)
+	} else if strings.TrimSpace(bare) == "true" {
+		return "", fmt.Errorf("bare repositories are not supported: %s", path)
+	}
+	output, err := g.runGitCommandIn(path, "rev-parse", "--show-toplevel")
+	if err != nil || strings.TrimSpace(output) == "" {
+		return "", fmt.Errorf("not inside a git repository: %s", path)
+	}
+	top := filepath.FromSlash(strings.TrimSpace(output))
+
+	if samePath(path, top) {
+		return path, nil
+	}
+	return top, nil
+}
+
+// Init creates a new repository in the given directory and selects it
+func (g *GitService) Init(path string) error {
+	if path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	path = cleanDir(path)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if _, err := g.runGitCommandIn(path, "init"); err != nil {
+		return err
 	}
 
 	g.currentPath = path
+	g.scope = ""
 	return nil
 }
 
@@ -144,85 +275,238 @@ func (g *GitService) GetCurrentPath() string {
 	return g.currentPath
 }
 
+// Snapshot returns a GitService bound to the current repository and scope
+// Callers reading from several goroutines share it so a concurrent SetPath cannot
+// switch repositories under them; protection and trial state are not carried over
+func (g *GitService) Snapshot() *GitService {
+	s := &GitService{currentPath: g.currentPath, scope: g.scope}
+	s.maxOutput.Store(g.maxOutput.Load())
+	return s
+}
+
+// SetScope restricts status and log to a subdirectory of the repository
+func (g *GitService) SetScope(scope string) {
+	g.scope = strings.Trim(filepath.ToSlash(strings.TrimSpace(scope)), "/")
+}
+
+// GetScope returns the current path scope, empty for the whole repository
+func (g *GitService) GetScope() string {
+	return g.scope
+}
+
+// scopeArgs returns the pathspec arguments limiting a command to the current scope
+func (g *GitService) scopeArgs() []string {
+	if g.scope == "" {
+		return nil
+	}
+	return []string{"--", g.scope}
+}
+
 // GetStatus returns the current git status
 func (g *GitService) GetStatus() (*models.GitStatus, error) {
+	return g.GetStatusWithOptions(models.StatusOptions{})
+}
+
+// GetStatusWithOptions returns the current git status using the given options
+// A zero value behaves like plain git status
+func (g *GitService) GetStatusWithOptions(opts models.StatusOptions) (*models.GitStatus, error) {
 	if g.currentPath == "" {
 		return nil, fmt.Errorf("no repository selected")
 	}
 
 	status := &models.GitStatus{
-		IsRepo:     true,
-		Staged:     []models.FileChange{},
-		Unstaged:   []models.FileChange{},
-		Untracked:  []string{},
+		IsRepo:        true,
+		Staged:        []models.FileChange{},
+		Unstaged:      []models.FileChange{},
+		Untracked:     []string{},
+		Scope:         g.scope,
+		UntrackedDirs: []models.UntrackedDir{},
+	}
+
+	// Get current branch, an unborn branch has no HEAD commit to resolve yet
+	status.HasCommits = g.HasCommits()
+	var branch string
+	var err error
+	if status.HasCommits {
+		branch, err = g.runGitCommand("rev-parse", "--abbrev-ref", "HEAD")
+	} else {
+		branch, err = g.runGitCommand("symbolic-ref", "--short", "HEAD")
 	}
-
-	// Get current branch
-	branch, err := g.runGitCommand("rev-parse", "--abbrev-ref", "HEAD")
 	if err == nil {
 		status.Branch = strings.TrimSpace(branch)
 	}
 
-	// Get branch status (ahead/behind)
-	branchStatus, _ := g.runGitCommand("status", "-sb")
-	if branchStatus != "" {
-		status.Branch = strings.Fields(branchStatus)[0]
-	}
+	status.Operation, _ = g.GetOperationState()
 
-	// Get status in porcelain format
-	output, err := g.runGitCommand("status", "--porcelain=v1")
+	// Get status in porcelain v2 format, which reports rename sources and similarity,
+	// with branch headers for the upstream and ahead/behind counts
+	args := []string{"status", "--porcelain=v2", "--branch", "-z"}
+	switch opts.UntrackedMode {
+	case "":
+	case models.UntrackedNo, models.UntrackedNormal, models.UntrackedAll:
+		args = append(args, "--untracked-files="+string(opts.UntrackedMode))
+	default:
+		return nil, fmt.Errorf("invalid untracked mode: %s", opts.UntrackedMode)
+	}
+	if opts.IgnoreSubmodules {
+		args = append(args, "--ignore-submodules=all")
+	}
+	args = append(args, g.scopeArgs()...)
+	output, err := g.runGitCommand(args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git status: %w", err)
 	}
 
-	if output == "" {
-		status.HasChanges = false
-		return status, nil
-	}
-
-	status.HasChanges = true
+	// With -z entries are NUL separated and paths are never quoted
+	var submodules map[string]bool
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 2 {
+			continue
+		}
 
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if line == "" {
+		switch entry[0] {
+		case '#':
+			parseBranchHeader(status, entry)
+			continue
+		case '?':
+			status.Untracked = append(status.Untracked, entry[2:])
+			continue
+		case '!':
 			continue
 		}
 
-		if len(line) >= 3 {
-			statusCode := line[:2]
-			filePath := line[3:]
+		// 1 XY sub mH mI mW hH hI path
+		// 2 XY sub mH mI mW hH hI Xscore path, followed by the original path as the next entry
+		// u XY sub m1 m2 m3 mW h1 h2 h3 path
+		fieldCount := map[byte]int{'1': 9, '2': 10, 'u': 11}[entry[0]]
+		if fieldCount == 0 {
+			continue
+		}
+		fields := strings.SplitN(entry, " ", fieldCount)
+		if len(fields) < fieldCount {
+			continue
+		}
 
-			// Handle renamed files
-			if strings.Contains(filePath, "->") {
-				parts := strings.Split(filePath, "->")
-				filePath = strings.TrimSpace(parts[len(parts)-1])
+		code := strings.ReplaceAll(fields[1], ".", " ")
+		change := models.FileChange{
+			Path:   fields[fieldCount-1],
+			Status: getStatusDescription(code),
+		}
+		if entry[0] == '2' {
+			change.Similarity, _ = strconv.Atoi(fields[8][1:])
+			if i+1 < len(entries) {
+				i++
+				change.OldPath = entries[i]
 			}
+		}
+		modes := fields[3:6]
+		if entry[0] == 'u' {
+			modes = fields[3:7]
+		}
+		change.EntryType = g.entryType(modes, change.Path, &submodules)
+		change.HunkStaging = change.EntryType == models.EntryFile
 
-			change := models.FileChange{
-				Path:   filePath,
-				Status: getStatusDescription(statusCode),
+		if entry[0] == 'u' {
+			status.Unstaged = append(status.Unstaged, change)
+			continue
+		}
+		if code[0] != ' ' {
+			staged := change
+			if isCommitEntry(change.EntryType) {
+				staged.Submodule = &models.SubmoduleChange{OldCommit: fields[6], NewCommit: fields[7], CommitChanged: fields[6] != fields[7]}
 			}
-
-			switch statusCode[0] {
-			case 'M', 'A', 'R', 'C':
-				status.Staged = append(status.Staged, change)
+			status.Staged = append(status.Staged, staged)
+		}
+		if code[1] != ' ' {
+			unstaged := change
+			if isCommitEntry(change.EntryType) {
+				unstaged.Submodule = g.worktreeSubmodule(change.Path, fields[2], fields[7])
 			}
+			status.Unstaged = append(status.Unstaged, unstaged)
+		}
+	}
 
-			if statusCode[0] == '?' {
-				status.Untracked = append(status.Untracked, filePath)
-			}
+	status.HasChanges = len(status.Staged) > 0 || len(status.Unstaged) > 0 || len(status.Untracked) > 0
 
-			if statusCode[1] == 'M' || (statusCode[0] == '?' && statusCode[1] == '?') {
-				if statusCode[0] != '?' {
-					status.Unstaged = append(status.Unstaged, change)
-				}
-			}
-		}
+	if opts.CollapseThreshold > 0 && len(status.Untracked) > opts.CollapseThreshold {
+		status.Untracked, status.UntrackedDirs = collapseUntracked(status.Untracked, g.scope)
 	}
 
 	return status, nil
 }
 
+// ExpandUntrackedDir lists the untracked entries directly below a collapsed directory
+// Subdirectories are collapsed again once the listing exceeds the threshold
+func (g *GitService) ExpandUntrackedDir(dir string, threshold int) (*models.UntrackedListing, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	dir = strings.Trim(filepath.ToSlash(dir), "/")
+	if dir == "" {
+		return nil, fmt.Errorf("directory cannot be empty")
+	}
+
+	output, err := g.runGitCommand("status", "--porcelain=v1", "--untracked-files=all", "--", dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "?? ") {
+			files = append(files, line[3:])
+		}
+	}
+
+	listing := &models.UntrackedListing{
+		Files: files,
+		Dirs:  []models.UntrackedDir{},
+	}
+	if threshold > 0 && len(files) > threshold {
+		listing.Files, listing.Dirs = collapseUntracked(files, dir)
+	}
+	if listing.Files == nil {
+		listing.Files = []string{}
+	}
+	return listing, nil
+}
+
+// collapseUntracked groups untracked files by their first directory below base
+// Files directly inside base are returned as they are
+func collapseUntracked(paths []string, base string) ([]string, []models.UntrackedDir) {
+	prefix := ""
+	if base != "" {
+		prefix = base + "/"
+	}
+
+	files := []string{}
+	counts := make(map[string]int)
+	var order []string
+	for _, p := range paths {
+		rest := strings.TrimPrefix(p, prefix)
+		idx := strings.Index(rest, "/")
+		// Keep plain files and directories git already reported as a single entry
+		if idx < 0 || idx == len(rest)-1 {
+			files = append(files, p)
+			continue
+		}
+		dir := prefix + rest[:idx+1]
+		if _, ok := counts[dir]; !ok {
+			order = append(order, dir)
+		}
+		counts[dir]++
+	}
+
+	dirs := make([]models.UntrackedDir, 0, len(order))
+	for _, dir := range order {
+		dirs = append(dirs, models.UntrackedDir{Path: dir, Count: counts[dir]})
+	}
+	return files, dirs
+}
+
 // StageFiles stages the given files
 func (g *GitService) StageFiles(files []string) error {
 	if g.currentPath == "" {
@@ -253,6 +537,99 @@ func (g *GitService) UnstageFiles(files []string) error {
 	return err
 }
 
+// changePathspecs returns the paths to pass to git for the given changes
+// A rename needs its old path too, otherwise only half of it is staged or unstaged
+func changePathspecs(changes []models.FileChange) []string {
+	var paths []string
+	for _, c := range changes {
+		if c.OldPath != "" {
+			paths = append(paths, filepath.ToSlash(c.OldPath))
+		}
+		paths = append(paths, filepath.ToSlash(c.Path))
+	}
+	return paths
+}
+
+// StageChanges stages the given changes including both sides of renames
+func (g *GitService) StageChanges(changes []models.FileChange) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	args := append([]string{"add", "-A", "--"}, changePathspecs(changes)...)
+	_, err := g.runGitCommand(args...)
+	return err
+}
+
+// UnstageChanges unstages the given changes including both sides of renames
+func (g *GitService) UnstageChanges(changes []models.FileChange) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	args := append([]string{"reset", "-q", "--"}, changePathspecs(changes)...)
+	_, err := g.runGitCommand(args...)
+	return err
+}
+
+// GetChangeDiff returns the diff of a change, showing renames as a single rename entry
+func (g *GitService) GetChangeDiff(change models.FileChange, staged bool) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	// Submodules are shown as the list of commits between the recorded and new commit
+	args := []string{"diff", "-M", "--submodule=log"}
+	if staged {
+		args = append(args, "--staged")
+	}
+	args = append(args, "--")
+	args = append(args, changePathspecs([]models.FileChange{change})...)
+
+	return g.runGitCommandLimited(args...)
+}
+
+// maxDiffWorkers bounds the git processes started by GetChangeDiffs
+// Most of a small diff's time is process startup, so this does not follow the CPU count
+const maxDiffWorkers = 8
+
+// GetChangeDiffs returns the diffs of several changes, collected concurrently
+// The result is in the order of changes; a file whose diff failed gets an empty string
+func (g *GitService) GetChangeDiffs(changes []models.FileChange, staged bool) ([]string, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	diffs := make([]string, len(changes))
+	workers := min(maxDiffWorkers, len(changes))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				diffs[i], _ = g.GetChangeDiff(changes[i], staged)
+			}
+		}()
+	}
+	for i := range changes {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return diffs, nil
+}
+
 // Commit creates a commit with the given message
 func (g *GitService) Commit(message string) error {
 	if g.currentPath == "" {
@@ -267,6 +644,152 @@ func (g *GitService) Commit(message string) error {
 	return err
 }
 
+// HasCommits reports whether HEAD points to a commit, false on an unborn branch
+func (g *GitService) HasCommits() bool {
+	if g.currentPath == "" {
+		return false
+	}
+	_, err := g.runGitCommand("rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+// CommitAllowEmpty creates a commit even when nothing is staged
+func (g *GitService) CommitAllowEmpty(message string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message cannot be empty")
+	}
+
+	_, err := g.runGitCommand("commit", "--allow-empty", "-m", message)
+	return err
+}
+
+// CreateInitialCommit creates the first commit of a repository without history
+// Staged files are included, otherwise the commit is empty
+func (g *GitService) CreateInitialCommit(message string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if g.HasCommits() {
+		return fmt.Errorf("repository already has commits")
+	}
+
+	if strings.TrimSpace(message) == "" {
+		message = "Initial commit"
+	}
+
+	return g.CommitAllowEmpty(message)
+}
+
+// PreviewCommit reports what a commit would contain and runs the pre-commit
+// and commit-msg hooks against the message without creating the commit
+func (g *GitService) PreviewCommit(message string) (*models.CommitPreview, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	preview := &models.CommitPreview{
+		Files:  []models.FileChange{},
+		Checks: []models.HookResult{},
+	}
+
+	// --dry-run exits non-zero when there is nothing to commit
+	output, err := g.runGitCommand("commit", "--dry-run", "--porcelain")
+	if err != nil {
+		preview.Checks = append(preview.Checks, models.HookResult{
+			Name:   "staged changes",
+			Passed: false,
+			Output: "nothing to commit",
+		})
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 4 || line[0] == ' ' || line[0] == '?' || line[0] == '!' {
+			continue
+		}
+		change := models.FileChange{
+			Path:   line[3:],
+			Status: getStatusDescription(line[:1] + " "),
+		}
+		if idx := strings.Index(change.Path, " -> "); idx >= 0 {
+			change.OldPath = change.Path[:idx]
+			change.Path = change.Path[idx+4:]
+		}
+		preview.Files = append(preview.Files, change)
+	}
+
+	if strings.TrimSpace(message) == "" {
+		preview.Checks = append(preview.Checks, models.HookResult{
+			Name:   "message",
+			Passed: false,
+			Output: "commit message cannot be empty",
+		})
+	}
+
+	hooksDir, err := g.runGitCommand("rev-parse", "--git-path", "hooks")
+	if err == nil {
+		hooksDir = strings.TrimSpace(hooksDir)
+		if !filepath.IsAbs(hooksDir) {
+			hooksDir = filepath.Join(g.currentPath, hooksDir)
+		}
+
+		if result, ok := g.runHook(hooksDir, "pre-commit"); ok {
+			preview.Checks = append(preview.Checks, result)
+		}
+
+		msgFile, err := os.CreateTemp("", "git-ai-tools-msg-*")
+		if err == nil {
+			msgFile.WriteString(message)
+			msgFile.Close()
+			if result, ok := g.runHook(hooksDir, "commit-msg", msgFile.Name()); ok {
+				preview.Checks = append(preview.Checks, result)
+			}
+			os.Remove(msgFile.Name())
+		}
+	}
+
+	preview.CanCommit = true
+	for _, check := range preview.Checks {
+		if !check.Passed {
+			preview.CanCommit = false
+			break
+		}
+	}
+	return preview, nil
+}
+
+// runHook runs a git hook if it exists, reporting false when there is no such hook
+func (g *GitService) runHook(hooksDir, name string, args ...string) (models.HookResult, bool) {
+	hookPath := filepath.Join(hooksDir, name)
+	info, err := os.Stat(hookPath)
+	if err != nil || info.IsDir() {
+		return models.HookResult{}, false
+	}
+
+	// Hooks are usually shell scripts, which Windows cannot execute directly
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(processCtx, "sh", append([]string{hookPath}, args...)...)
+	} else {
+		if info.Mode()&0111 == 0 {
+			return models.HookResult{}, false
+		}
+		cmd = exec.CommandContext(processCtx, hookPath, args...)
+	}
+	cmd.Dir = g.currentPath
+	trackProcess(cmd)
+
+	output, err := cmd.CombinedOutput()
+	return models.HookResult{
+		Name:   name,
+		Passed: err == nil,
+		Output: strings.TrimSpace(string(output)),
+	}, true
+}
+
 // GetBranches returns all branches
 func (g *GitService) GetBranches() ([]models.Branch, error) {
 	if g.currentPath == "" {
@@ -342,49 +865,184 @@ func (g *GitService) GetDiff(filePath string, staged bool) (string, error) {
 	if g.currentPath == "" {
 		return "", fmt.Errorf("no repository selected")
 	}
+	filePath, err := g.repoPath(filePath)
+	if err != nil {
+		return "", err
+	}
 
 	var args []string
 	if staged {
-		args = []string{"diff", "--staged", filePath}
+		args = []string{"diff", "--staged", "--", filePath}
 	} else {
-		args = []string{"diff", filePath}
+		args = []string{"diff", "--", filePath}
 	}
 
-	return g.runGitCommand(args...)
+	return g.runGitCommandLimited(args...)
+}
+
+// GetFileDiffBetween returns the diff of a file between two revisions
+// An empty revB compares revA with the working tree
+func (g *GitService) GetFileDiffBetween(path, revA, revB string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	path, err := g.repoPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"diff", "-M"}
+	for _, rev := range []string{revA, revB} {
+		if rev == "" {
+			continue
+		}
+		hash, err := g.ResolveCommit(rev)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, hash)
+	}
+	if len(args) == 2 {
+		return "", fmt.Errorf("revision cannot be empty")
+	}
+	args = append(args, "--", path)
+
+	return g.runGitCommandLimited(args...)
+}
+
+// CompareWithBranch returns the diff between a file in the working tree and its version on branch
+func (g *GitService) CompareWithBranch(path, branch string) (string, error) {
+	if branch == "" {
+		return "", fmt.Errorf("branch cannot be empty")
+	}
+	return g.GetFileDiffBetween(path, branch, "")
 }
 
 // GetLog returns commit history
 func (g *GitService) GetLog(limit int) ([]models.CommitInfo, error) {
+	return g.GetLogWithOptions(models.LogOptions{Limit: limit})
+}
+
+// GetLogWithOptions returns commit history filtered by path, author and message
+func (g *GitService) GetLogWithOptions(opts models.LogOptions) ([]models.CommitInfo, error) {
 	if g.currentPath == "" {
 		return nil, fmt.Errorf("no repository selected")
 	}
+	if opts.Limit <= 0 {
+		opts.Limit = 100
+	}
+
+	// A repository without commits has an empty history rather than a broken one
+	if !g.HasCommits() {
+		return []models.CommitInfo{}, nil
+	}
 
-	format := "%H|%s|%an|%ad"
-	output, err := g.runGitCommand("log", fmt.Sprintf("-%d", limit), "--pretty=format:"+format, "--date=iso")
+	args, err := g.logArgs(opts)
+	if err != nil {
+		return nil, err
+	}
+	output, err := g.runGitCommand(args...)
 	if err != nil {
 		return nil, err
 	}
 
-	var commits []models.CommitInfo
+	commits := []models.CommitInfo{}
 	lines := strings.Split(output, "\n")
 
 	for _, line := range lines {
-		if line == "" {
-			continue
+		if commit, ok := parseLogLine(line); ok {
+			commits = append(commits, commit)
 		}
+	}
 
-		parts := strings.Split(line, "|")
-		if len(parts) >= 4 {
-			commits = append(commits, models.CommitInfo{
-				Hash:    parts[0][:7],
-				Message: parts[1],
-				Author:  parts[2],
-				Date:    parts[3],
-			})
+	return commits, nil
+}
+
+// logFormat is the pretty format parsed by parseLogLine
+// Subject goes last so a "|" inside it cannot shift the other fields
+const logFormat = "%H|%ae|%an|%ad|%aI|%at|%cI|%ct|%s"
+
+// logArgs builds the git log arguments for opts; a Limit of 0 means the whole history
+func (g *GitService) logArgs(opts models.LogOptions) ([]string, error) {
+	args := []string{"log", "--pretty=format:" + logFormat, "--date=iso"}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", opts.Limit))
+	}
+	if opts.Skip > 0 {
+		args = append(args, fmt.Sprintf("--skip=%d", opts.Skip))
+	}
+	if opts.Author != "" {
+		author := opts.Author
+		// "me" filters on the configured identity
+		if author == "me" {
+			email, err := g.runGitCommand("config", "user.email")
+			if err != nil || strings.TrimSpace(email) == "" {
+				return nil, fmt.Errorf("user.email is not configured")
+			}
+			author = strings.TrimSpace(email)
+		}
+		args = append(args, "--author="+author)
+	}
+	if opts.Grep != "" {
+		args = append(args, "--grep="+opts.Grep)
+	}
+	if opts.IgnoreCase {
+		args = append(args, "--regexp-ignore-case")
+	}
+	if opts.FirstParent {
+		args = append(args, "--first-parent")
+	}
+	if opts.Path != "" {
+		path, err := g.repoPath(opts.Path)
+		if err != nil {
+			return nil, err
 		}
+		args = append(args, "--", path)
+	} else {
+		args = append(args, g.scopeArgs()...)
 	}
+	return args, nil
+}
 
-	return commits, nil
+// parseLogLine parses one line of logFormat output
+func parseLogLine(line string) (models.CommitInfo, bool) {
+	parts := strings.SplitN(line, "|", 9)
+	if len(parts) < 9 {
+		return models.CommitInfo{}, false
+	}
+
+	authorTime, _ := strconv.ParseInt(parts[5], 10, 64)
+	commitTime, _ := strconv.ParseInt(parts[7], 10, 64)
+	return models.CommitInfo{
+		Hash:            parts[0],
+		ShortHash:       shortHash(parts[0]),
+		Message:         parts[8],
+		Author:          parts[2],
+		Email:           parts[1],
+		Date:            parts[3],
+		AuthorDate:      parts[4],
+		AuthorTimestamp: authorTime,
+		CommitDate:      parts[6],
+		CommitTimestamp: commitTime,
+	}, true
+}
+
+// parseBranchHeader reads the upstream and ahead/behind counts from a porcelain v2
+// "# branch.upstream" or "# branch.ab +1 -2" header
+func parseBranchHeader(status *models.GitStatus, header string) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return
+	}
+	switch fields[1] {
+	case "branch.upstream":
+		status.Upstream = fields[2]
+	case "branch.ab":
+		if len(fields) == 4 {
+			status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+			status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+		}
+	}
 }
 
 // DiscardChanges discards changes to the given file
@@ -393,30 +1051,113 @@ func (g *GitService) DiscardChanges(filePath string) error {
 		return fmt.Errorf("no repository selected")
 	}
 
-	_, err := g.runGitCommand("checkout", "--", filePath)
+	filePath, err := g.repoPath(filePath)
+	if err != nil {
+		return err
+	}
+	if err := g.CheckProtectedDiscard([]string{filePath}); err != nil {
+		return err
+	}
+
+	// Literal pathspecs discard exactly what DiscardablePatch saved to the trash
+	_, err = g.runGitCommand("--literal-pathspecs", "checkout", "--", filePath)
 	return err
 }
 
 // runGitCommand executes a git command in the current directory
 func (g *GitService) runGitCommand(args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	if g.currentPath != "" {
-		cmd.Dir = g.currentPath
-	}
+	return g.runGitCommandIn(g.currentPath, args...)
+}
 
-	// Hide command window on Windows
-	if runtime.GOOS == "windows" {
-		cmd.SysProcAttr = &syscall.SysProcAttr{
-			HideWindow: true,
-		}
+// runGitCommandIn executes a git command in the given directory
+func (g *GitService) runGitCommandIn(dir string, args ...string) (string, error) {
+	cmd := newGitCommand(dir, args...)
+	return runCommand(cmd, dir, args)
+}
+
+// runGitCommandEnv executes a git command with extra environment variables
+// Continue commands use it to set GIT_EDITOR so git never waits for an editor
+func (g *GitService) runGitCommandEnv(env []string, args ...string) (string, error) {
+	cmd := newGitCommand(g.currentPath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	return runCommand(cmd, g.currentPath, args)
+}
+
+// runGitCommandInput executes a git command with input on stdin
+func (g *GitService) runGitCommandInput(input []byte, args ...string) (string, error) {
+	cmd := newGitCommand(g.currentPath, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	return runCommand(cmd, g.currentPath, args)
+}
+
+// runCommand runs a prepared git command and returns its stdout
+// stderr is kept out of the result so warnings such as CRLF conversion notices
+// cannot corrupt parsed output, but both streams are included in errors
+func runCommand(cmd *exec.Cmd, dir string, args []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	span := trace.Start(trace.KindGit, gitSubcommand(args), dir)
+	err := cmd.Run()
+	span.End(err)
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w\n%s%s", strings.Join(args, " "), err, stdout.String(), stderr.String())
 	}
 
-	output, err := cmd.CombinedOutput()
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+// runGitCommandRaw executes a git command and returns its stdout untouched
+// Use it for file contents, where stderr and trimming would corrupt the data
+func (g *GitService) runGitCommandRaw(args ...string) ([]byte, error) {
+	cmd := newGitCommand(g.currentPath, args...)
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	span := trace.Start(trace.KindGit, gitSubcommand(args), g.currentPath)
+	output, err := cmd.Output()
+	span.End(err)
 	if err != nil {
-		return "", fmt.Errorf("git %s failed: %w\n%s", strings.Join(args, " "), err, string(output))
+		return nil, fmt.Errorf("git %s failed: %w\n%s", strings.Join(args, " "), err, stderr.String())
 	}
 
-	return strings.TrimSuffix(string(output), "\n"), nil
+	return output, nil
+}
+
+// gitSubcommand returns the git subcommand of args for tracing
+// Only the subcommand is recorded so messages and paths never end up in traces
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		// -c and -C take a separate value that is not the subcommand
+		if args[i] == "-c" || args[i] == "-C" {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(args[i], "-") {
+			return "git " + args[i]
+		}
+	}
+	return "git"
+}
+
+// newGitCommand prepares a git command to run in dir
+// Paths are printed verbatim so non-ASCII names survive, and Windows accepts paths
+// longer than MAX_PATH. The configured global options come after these so they win
+func newGitCommand(dir string, args ...string) *exec.Cmd {
+	path, extra := executableArgs()
+	config := []string{"-c", "core.quotepath=false"}
+	if runtime.GOOS == "windows" {
+		config = append(config, "-c", "core.longpaths=true")
+	}
+	config = append(config, extra...)
+	cmd := exec.CommandContext(processCtx, path, append(config, args...)...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	trackProcess(cmd)
+	trackIndexWriter(cmd, args)
+	return cmd
 }
 
 // getStatusDescription returns a human-readable status description
@@ -436,6 +1177,12 @@ func getStatusDescription(code string) string {
 		return "Deleted (staged)"
 	case "R ":
 		return "Renamed"
+	case "RM":
+		return "Renamed (modified)"
+	case "AM":
+		return "Added (modified)"
+	case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+		return "Conflicted"
 	case "C ":
 		return "Copied"
 	case "??":
@@ -447,21 +1194,84 @@ func getStatusDescription(code string) string {
 	}
 }
 
-// Push pushes the current branch to remote
-func (g *GitService) Push(remote string) error {
+// Push pushes to a remote as described by opts
+// Without a Refspec the branch, or the current branch, is pushed explicitly, to its upstream
+// when it has one on that remote, so branches that track nothing can be pushed too
+func (g *GitService) Push(opts models.PushOptions) error {
 	if g.currentPath == "" {
 		return fmt.Errorf("no repository selected")
 	}
+	if strings.HasPrefix(opts.Remote, "-") {
+		return fmt.Errorf("invalid remote name: %s", opts.Remote)
+	}
+	if strings.HasPrefix(opts.Branch, "-") {
+		return fmt.Errorf("invalid branch name: %s", opts.Branch)
+	}
+	if strings.HasPrefix(opts.Refspec, "-") {
+		return fmt.Errorf("invalid refspec: %s", opts.Refspec)
+	}
 
 	args := []string{"push"}
-	if remote != "" {
-		args = append(args, remote)
+	if opts.ForceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+	if opts.Tags {
+		args = append(args, "--tags")
+	}
+	if opts.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+
+	remote, target := opts.Remote, opts.Refspec
+	if target == "" {
+		branch := opts.Branch
+		if branch == "" {
+			current, err := g.CurrentBranch()
+			if err != nil {
+				return err
+			}
+			if current == "" && !opts.Tags {
+				return fmt.Errorf("HEAD is detached, choose a branch or refspec to push")
+			}
+			branch = current
+		}
+		if branch != "" {
+			upstreamRemote, upstreamRef := g.upstreamOf(branch)
+			if remote == "" {
+				remote = upstreamRemote
+			}
+			target = branch
+			if upstreamRef != "" && remote == upstreamRemote {
+				target = branch + ":" + upstreamRef
+			}
+		}
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	args = append(args, remote)
+	if target != "" {
+		args = append(args, target)
 	}
 
 	_, err := g.runGitCommand(args...)
 	return err
 }
 
+// UpstreamRef returns the remote ref a local branch tracks, such as refs/heads/main,
+// or "" when it tracks none
+func (g *GitService) UpstreamRef(branch string) string {
+	_, ref := g.upstreamOf(branch)
+	return ref
+}
+
+// upstreamOf returns the remote and remote ref a local branch tracks, empty when it has none
+func (g *GitService) upstreamOf(branch string) (string, string) {
+	out, _ := g.runGitCommand("for-each-ref", "--format=%(upstream:remotename) %(upstream:remoteref)", "refs/heads/"+branch)
+	remote, ref, _ := strings.Cut(strings.TrimSpace(out), " ")
+	return remote, ref
+}
+
 // Pull pulls changes from remote
 func (g *GitService) Pull(remote string, branch string) error {
 	if g.currentPath == "" {
@@ -497,7 +1307,15 @@ func (g *GitService) Reset(resetType ResetType, commit string) error {
 
 	args := []string{"reset", "--" + string(resetType)}
 	if commit != "" {
-		args = append(args, commit)
+		hash, err := g.ResolveCommit(commit)
+		if err != nil {
+			return err
+		}
+		// Commits after hash leave the branch
+		if err := g.checkRewrite(hash, "HEAD"); err != nil {
+			return err
+		}
+		args = append(args, hash)
 	}
 
 	_, err := g.runGitCommand(args...)
@@ -510,13 +1328,18 @@ func (g *GitService) Revert(commit string, noCommit bool) error {
 		return fmt.Errorf("no repository selected")
 	}
 
+	hash, err := g.ResolveCommit(commit)
+	if err != nil {
+		return err
+	}
+
 	args := []string{"revert"}
 	if noCommit {
 		args = append(args, "--no-commit")
 	}
-	args = append(args, commit)
+	args = append(args, hash)
 
-	_, err := g.runGitCommand(args...)
+	_, err = g.runGitCommand(args...)
 	return err
 }
 
@@ -538,16 +1361,8 @@ func (g *GitService) GetRemoteNames() ([]string, error) {
 	return names, nil
 }
 
-// Tag represents a git tag
-type Tag struct {
-	Name        string `json:"name"`
-	CommitHash  string `json:"commitHash"`
-	Message     string `json:"message"`
-	IsAnnotated bool   `json:"isAnnotated"`
-}
-
 // GetTags returns all tags
-func (g *GitService) GetTags() ([]Tag, error) {
+func (g *GitService) GetTags() ([]models.Tag, error) {
 	if g.currentPath == "" {
 		return nil, fmt.Errorf("no repository selected")
 	}
@@ -557,7 +1372,7 @@ func (g *GitService) GetTags() ([]Tag, error) {
 		return nil, err
 	}
 
-	var tags []Tag
+	var tags []models.Tag
 	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		if line == "" {
@@ -566,7 +1381,7 @@ func (g *GitService) GetTags() ([]Tag, error) {
 
 		parts := strings.SplitN(line, "|", 4)
 		if len(parts) >= 2 {
-			tag := Tag{
+			tag := models.Tag{
 				Name:        parts[0],
 				CommitHash:  parts[1],
 				IsAnnotated: len(parts) >= 3 && parts[2] != "",
@@ -676,14 +1491,59 @@ func (g *GitService) DeleteBranch(name string, force bool) error {
 	return err
 }
 
+// PreviewDeleteBranch reports what deleting a branch would lose
+// LostCommits are reachable from neither HEAD nor any remote-tracking branch
+func (g *GitService) PreviewDeleteBranch(name string) (*models.DeleteBranchPreview, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("branch name cannot be empty")
+	}
+	ref := "refs/heads/" + name
+	if _, err := g.runGitCommand("rev-parse", "--verify", "--quiet", ref); err != nil {
+		return nil, fmt.Errorf("branch not found: %s", name)
+	}
+
+	preview := &models.DeleteBranchPreview{Branch: name}
+	if current, err := g.runGitCommand("symbolic-ref", "--short", "-q", "HEAD"); err == nil && current == name {
+		preview.IsCurrent = true
+	}
+	preview.Upstream, _ = g.runGitCommand("for-each-ref", "--format=%(upstream:short)", ref)
+
+	remotes, _ := g.GetRemoteNames()
+	for _, remote := range remotes {
+		if _, err := g.runGitCommand("rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+name); err == nil {
+			preview.OnRemotes = append(preview.OnRemotes, remote)
+		}
+	}
+
+	if count, err := g.runGitCommand("rev-list", "--count", ref, "--not", "HEAD"); err == nil {
+		preview.UnmergedCommits, _ = strconv.Atoi(count)
+	}
+	if count, err := g.runGitCommand("rev-list", "--count", ref, "--not", "--remotes"); err == nil {
+		preview.UnpushedCommits, _ = strconv.Atoi(count)
+	}
+
+	lost, err := g.runGitCommand("log", "--format=%h %s", ref, "--not", "HEAD", "--remotes")
+	if err != nil {
+		return nil, err
+	}
+	if lost != "" {
+		preview.LostCommits = strings.Split(lost, "\n")
+	}
+	preview.Safe = !preview.IsCurrent && len(preview.LostCommits) == 0
+	return preview, nil
+}
+
 // DiffBranches compares two branches and returns the diff
 func (g *GitService) DiffBranches(branch1 string, branch2 string) (string, error) {
 	if g.currentPath == "" {
 		return "", fmt.Errorf("no repository selected")
 	}
 
-	output, err := g.runGitCommand("diff", branch1+"..."+branch2)
-	return output, err
+	return g.runGitCommandLimited("diff", branch1+"..."+branch2)
 }
 
 // GetCommitDetail returns detailed information about a commit
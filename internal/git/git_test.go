@@ -0,0 +1,208 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestRepo creates a repository with a commit identity in a temporary directory and
+// selects it in a new GitService
+func newTestRepo(tb testing.TB) *GitService {
+	tb.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		tb.Skip("git is not installed")
+	}
+	dir := tb.TempDir()
+	g := NewGitService()
+	if err := g.Init(dir); err != nil {
+		tb.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		if _, err := g.runGitCommand(args...); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return g
+}
+
+// stageChangedFiles commits count files, changes every one of them and stages the changes
+func stageChangedFiles(tb testing.TB, g *GitService, count int) {
+	tb.Helper()
+	write := func(round int) {
+		for i := 0; i < count; i++ {
+			path := filepath.Join(g.GetCurrentPath(), "pkg", fmt.Sprintf("file%03d.go", i))
+			content := fmt.Sprintf("package pkg\n\n// round %d\nconst Value%d = %d\n", round, i, round*i)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(g.GetCurrentPath(), "pkg"), 0755); err != nil {
+		tb.Fatal(err)
+	}
+	write(1)
+	if _, err := g.runGitCommand("add", "-A"); err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := g.runGitCommand("commit", "-q", "-m", "initial"); err != nil {
+		tb.Fatal(err)
+	}
+	write(2)
+	if _, err := g.runGitCommand("add", "-A"); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+// BenchmarkGetChangeDiffs collects the staged diffs of a commit touching 200 files, the
+// way commit message generation does; the sequential case is the loop it replaced
+func BenchmarkGetChangeDiffs(b *testing.B) {
+	g := newTestRepo(b)
+	stageChangedFiles(b, g, 200)
+	status, err := g.GetStatus()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if len(status.Staged) != 200 {
+		b.Fatalf("staged %d files, want 200", len(status.Staged))
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, change := range status.Staged {
+				if _, err := g.GetChangeDiff(change, true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("concurrent", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			diffs, err := g.GetChangeDiffs(status.Staged, true)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if diffs[len(diffs)-1] == "" {
+				b.Fatal("missing diff")
+			}
+		}
+	})
+}
+
+func TestFindRepositoryRoot(t *testing.T) {
+	g := newTestRepo(t)
+	root := g.GetCurrentPath()
+	sub := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(sub, "a.go")
+	if err := os.WriteFile(file, []byte("package pkg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.runGitCommand("add", "-A"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.runGitCommand("commit", "-q", "-m", "initial"); err != nil {
+		t.Fatal(err)
+	}
+
+	worktree := filepath.Join(t.TempDir(), "wt")
+	if _, err := g.runGitCommand("worktree", "add", "-q", worktree); err != nil {
+		t.Fatal(err)
+	}
+	bare := filepath.Join(t.TempDir(), "bare.git")
+	if _, err := g.runGitCommand("clone", "-q", "--bare", "--", root, bare); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{name: "top level", path: root, want: root},
+		{name: "subdirectory", path: sub, want: root},
+		{name: "file", path: file, want: root},
+		{name: "worktree", path: filepath.Join(worktree, "src"), want: worktree},
+		{name: "bare repository", path: bare, wantErr: "bare repositories are not supported"},
+		{name: "plain folder", path: t.TempDir(), wantErr: "not inside a git repository"},
+		{name: "missing", path: filepath.Join(root, "missing"), wantErr: "path does not exist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.FindRepositoryRoot(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FindRepositoryRoot(%q) = %q, %v; want error %q", tt.path, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !samePath(got, tt.want) {
+				t.Errorf("FindRepositoryRoot(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLineEndings(t *testing.T) {
+	g := newTestRepo(t)
+	root := g.GetCurrentPath()
+	if _, err := g.runGitCommand("config", "core.autocrlf", "false"); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func() {
+		if _, err := g.runGitCommand("add", "-A"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("flip.txt", "a\nb\n")
+	write("same.txt", "a\n")
+	commit()
+	if _, err := g.runGitCommand("commit", "-q", "-m", "initial"); err != nil {
+		t.Fatal(err)
+	}
+	write("flip.txt", "a\r\nb\r\n")
+	write("same.txt", "a\nb\n")
+	write("new [draft] file", "a\r\nb\n")
+	write("binary.dat", "a\r\nb\n\x00")
+	commit()
+
+	issues, err := g.CheckLineEndings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, issue := range issues {
+		got[issue.Path] = issue.Kind + " " + issue.Attributes
+	}
+	want := map[string]string{
+		"flip.txt":         IssueLineEndingsOnly + " *.txt text eol=lf",
+		"new [draft] file": IssueMixedLineEndings + ` /new[[:space:]]\[draft][[:space:]]file text eol=lf`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("issues = %v, want %v", got, want)
+	}
+	for path, w := range want {
+		if got[path] != w {
+			t.Errorf("issue for %q = %q, want %q", path, got[path], w)
+		}
+	}
+}
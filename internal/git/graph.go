@@ -0,0 +1,170 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// graphFormat puts parents and decorations ahead of logFormat, separated by unit separators
+const graphFormat = "%P%x1f%D%x1f" + logFormat
+
+// GetCommitGraph returns the newest commits of all refs in topological order, each with its
+// parents, the refs pointing at it and the lanes to draw it and its edges in
+func (g *GitService) GetCommitGraph(limit int) ([]models.GraphCommit, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if limit <= 0 {
+		limit = 500
+	}
+	if !g.HasCommits() {
+		return []models.GraphCommit{}, nil
+	}
+
+	// Snapshots and stashes are commits of the app and of git stash, not of the user's history
+	output, err := g.runGitCommand("log", "--exclude=refs/gitai/*", "--exclude=refs/stash", "--all", "--topo-order", "--decorate=full",
+		"--pretty=format:"+graphFormat, "--date=iso", fmt.Sprintf("-%d", limit))
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []models.GraphCommit{}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\x1f", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		info, ok := parseLogLine(parts[2])
+		if !ok {
+			continue
+		}
+		commits = append(commits, models.GraphCommit{
+			Commit:  info,
+			Parents: strings.Fields(parts[0]),
+			Refs:    parseDecorations(parts[1]),
+			Edges:   []models.GraphEdge{},
+		})
+	}
+
+	assignLanes(commits)
+	return commits, nil
+}
+
+// parseDecorations parses the full ref names printed by %D with --decorate=full
+func parseDecorations(decorations string) []models.GraphRef {
+	refs := []models.GraphRef{}
+	for _, d := range strings.Split(decorations, ", ") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		if target, ok := strings.CutPrefix(d, "HEAD -> "); ok {
+			refs = append(refs, models.GraphRef{Name: "HEAD", Type: models.RefHead})
+			d = target
+		}
+		d = strings.TrimPrefix(d, "tag: ")
+
+		switch {
+		case d == "HEAD":
+			refs = append(refs, models.GraphRef{Name: "HEAD", Type: models.RefHead})
+		case strings.HasPrefix(d, "refs/heads/"):
+			refs = append(refs, models.GraphRef{Name: strings.TrimPrefix(d, "refs/heads/"), Type: models.RefBranch})
+		case strings.HasPrefix(d, "refs/remotes/"):
+			refs = append(refs, models.GraphRef{Name: strings.TrimPrefix(d, "refs/remotes/"), Type: models.RefRemote})
+		case strings.HasPrefix(d, "refs/tags/"):
+			refs = append(refs, models.GraphRef{Name: strings.TrimPrefix(d, "refs/tags/"), Type: models.RefTag})
+		case strings.HasPrefix(d, "refs/stash"):
+			refs = append(refs, models.GraphRef{Name: "stash", Type: models.RefStash})
+		}
+	}
+	return refs
+}
+
+// assignLanes places each commit in a column and records the edges leaving its row
+// Each lane holds the hash of the commit it is waiting for; a commit takes the leftmost lane
+// waiting for it, passes that lane to its first parent and opens lanes for the others
+func assignLanes(commits []models.GraphCommit) {
+	type pending struct {
+		from, to int
+		hash     string
+	}
+	var lanes []string
+	var edges []pending
+
+	laneOf := func(hash string) int {
+		for i, h := range lanes {
+			if h == hash {
+				return i
+			}
+		}
+		return -1
+	}
+	freeLane := func() int {
+		if i := laneOf(""); i >= 0 {
+			return i
+		}
+		lanes = append(lanes, "")
+		return len(lanes) - 1
+	}
+
+	for i := range commits {
+		c := &commits[i]
+		col := laneOf(c.Commit.Hash)
+		if col < 0 {
+			col = freeLane()
+		}
+		c.Lane = col
+
+		// Edges of the previous row aimed at this commit end in its lane
+		if i > 0 {
+			for _, e := range edges {
+				to := e.to
+				if e.hash == c.Commit.Hash {
+					to = col
+				}
+				commits[i-1].Edges = append(commits[i-1].Edges, models.GraphEdge{From: e.from, To: to})
+			}
+		}
+
+		for j, h := range lanes {
+			if h == c.Commit.Hash {
+				lanes[j] = ""
+			}
+		}
+
+		edges = edges[:0]
+		if len(c.Parents) > 0 {
+			lanes[col] = c.Parents[0]
+			edges = append(edges, pending{from: col, to: col, hash: c.Parents[0]})
+		}
+		opened := map[int]bool{col: true}
+		for _, parent := range c.Parents[min(1, len(c.Parents)):] {
+			lane := laneOf(parent)
+			if lane < 0 {
+				lane = freeLane()
+				lanes[lane] = parent
+				opened[lane] = true
+			}
+			edges = append(edges, pending{from: col, to: lane, hash: parent})
+		}
+		// Lanes already waiting for other commits pass straight through this row
+		for j, h := range lanes {
+			if h != "" && !opened[j] {
+				edges = append(edges, pending{from: j, to: j, hash: h})
+			}
+		}
+
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+	}
+
+	// The last row's edges continue past the loaded history
+	if n := len(commits); n > 0 {
+		for _, e := range edges {
+			commits[n-1].Edges = append(commits[n-1].Edges, models.GraphEdge{From: e.from, To: e.to})
+		}
+	}
+}
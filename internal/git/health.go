@@ -0,0 +1,129 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// Thresholds above which the health probe suggests maintenance
+const (
+	maxLooseObjects = 5000
+	maxPackCount    = 50
+	maxIndexSize    = 50 * 1024 * 1024
+)
+
+// maintenanceTasks maps the actions suggested by the health probe to git commands
+var maintenanceTasks = map[string][]string{
+	"gc":      {"gc"},
+	"repack":  {"repack", "-a", "-d"},
+	"prune":   {"prune"},
+	"gc-auto": {"gc", "--auto"},
+}
+
+// gitDir returns the absolute path of the repository's git directory
+func (g *GitService) gitDir() (string, error) {
+	output, err := g.runGitCommand("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(output)), nil
+}
+
+// CheckHealth probes object storage, index size and unfinished operations
+func (g *GitService) CheckHealth() (*models.RepositoryHealth, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	health := &models.RepositoryHealth{
+		Warnings: []models.HealthWarning{},
+	}
+
+	output, err := g.runGitCommand("count-objects", "-v")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		switch key {
+		case "count":
+			health.LooseObjects = n
+		case "packs":
+			health.PackCount = n
+		case "size-pack":
+			health.PackSizeKB = n
+		case "garbage":
+			health.GarbageCount = n
+		}
+	}
+
+	if health.LooseObjects > maxLooseObjects {
+		health.Warnings = append(health.Warnings, models.HealthWarning{
+			Code:    "loose-objects",
+			Message: fmt.Sprintf("%d loose objects slow down most operations", health.LooseObjects),
+			Action:  "gc",
+		})
+	}
+	if health.PackCount > maxPackCount {
+		health.Warnings = append(health.Warnings, models.HealthWarning{
+			Code:    "pack-count",
+			Message: fmt.Sprintf("%d pack files, consolidating them speeds up object lookups", health.PackCount),
+			Action:  "repack",
+		})
+	}
+	if health.GarbageCount > 0 {
+		health.Warnings = append(health.Warnings, models.HealthWarning{
+			Code:    "garbage",
+			Message: fmt.Sprintf("%d orphaned files in the object directory", health.GarbageCount),
+			Action:  "gc",
+		})
+	}
+
+	dir, err := g.gitDir()
+	if err != nil {
+		return health, nil
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, "index")); err == nil {
+		health.IndexSize = info.Size()
+		if health.IndexSize > maxIndexSize {
+			health.Warnings = append(health.Warnings, models.HealthWarning{
+				Code:    "index-size",
+				Message: fmt.Sprintf("index is %d MB, consider sparse checkout for large working trees", health.IndexSize/1024/1024),
+			})
+		}
+	}
+
+	if op, err := g.GetOperationState(); err == nil && op.State != models.OperationNone {
+		health.Warnings = append(health.Warnings, models.HealthWarning{
+			Code:    "operation-in-progress",
+			Message: fmt.Sprintf("an unfinished operation is in progress: %s", op.State),
+		})
+	}
+
+	return health, nil
+}
+
+// RunMaintenance runs one of the maintenance actions suggested by CheckHealth
+func (g *GitService) RunMaintenance(action string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	args, ok := maintenanceTasks[action]
+	if !ok {
+		return fmt.Errorf("unknown maintenance action: %s", action)
+	}
+
+	_, err := g.runGitCommand(args...)
+	return err
+}
@@ -0,0 +1,284 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/trace"
+)
+
+// File issue kinds reported by CheckLineEndings
+const (
+	IssueMixedLineEndings = "mixed-line-endings"
+	IssueLineEndingsOnly  = "line-endings-only"
+	IssueNonUTF8          = "non-utf8"
+)
+
+// CheckLineEndings inspects the staged version of every staged file for mixed
+// line endings, changes that only flip CRLF/LF, and content that is not UTF-8
+func (g *GitService) CheckLineEndings() ([]models.FileIssue, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	output, err := g.runGitCommand("diff", "--cached", "--name-only", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range strings.Split(output, "\n") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	// The staged and HEAD versions of each file are read as pairs from one cat-file process
+	hasHead := g.HasCommits()
+	objects := make([]string, 0, 2*len(files))
+	for _, file := range files {
+		objects = append(objects, ":"+file)
+		if hasHead {
+			objects = append(objects, "HEAD:"+file)
+		}
+	}
+
+	issues := []models.FileIssue{}
+	var staged []byte
+	err = g.catFileBatch(objects, func(i int, data []byte) {
+		if hasHead && i%2 == 1 {
+			file := files[i/2]
+			if staged == nil || data == nil || bytes.Equal(data, staged) {
+				return
+			}
+			if bytes.Equal(normalizeEOL(data), normalizeEOL(staged)) {
+				issues = append(issues, models.FileIssue{
+					Path:       file,
+					Kind:       IssueLineEndingsOnly,
+					Detail:     "only line endings changed, every line will show as modified",
+					Attributes: attributesPattern(file) + " text eol=lf",
+				})
+			}
+			return
+		}
+
+		index := i
+		if hasHead {
+			index = i / 2
+		}
+		file := files[index]
+		staged = nil
+		if data == nil || isBinary(data) {
+			return
+		}
+
+		if !utf8.Valid(data) {
+			issue := models.FileIssue{
+				Path:       file,
+				Kind:       IssueNonUTF8,
+				Detail:     "file is not valid UTF-8 and its encoding is unknown, diffs and AI prompts may show garbled text",
+				Attributes: attributesPattern(file) + " eol=lf",
+			}
+			if encoding := detectEncoding(data); encoding != "" {
+				issue.Detail = fmt.Sprintf("file looks %s encoded, diffs and AI prompts may show garbled text", encoding)
+				issue.Attributes = attributesPattern(file) + " working-tree-encoding=" + encoding + " eol=lf"
+			}
+			issues = append(issues, issue)
+		}
+
+		crlf := bytes.Count(data, []byte("\r\n"))
+		lf := bytes.Count(data, []byte("\n")) - crlf
+		if crlf > 0 && lf > 0 {
+			issues = append(issues, models.FileIssue{
+				Path:       file,
+				Kind:       IssueMixedLineEndings,
+				Detail:     fmt.Sprintf("%d CRLF and %d LF line endings", crlf, lf),
+				Attributes: attributesPattern(file) + " text eol=lf",
+			})
+			return
+		}
+		staged = data
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+// catFileBatch reads objects through a single git cat-file --batch process
+// fn is called once per object in order, with nil data when the object is missing
+func (g *GitService) catFileBatch(objects []string, fn func(i int, data []byte)) error {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	args := []string{"cat-file", "--batch"}
+	cmd := newGitCommand(g.currentPath, args...)
+	cmd.Stdin = strings.NewReader(strings.Join(objects, "\n") + "\n")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to read git cat-file output: %w", err)
+	}
+
+	span := trace.Start(trace.KindGit, gitSubcommand(args), g.currentPath)
+	if err := cmd.Start(); err != nil {
+		span.End(err)
+		return fmt.Errorf("failed to start git cat-file: %w", err)
+	}
+
+	readErr := readBatch(bufio.NewReader(stdout), len(objects), fn)
+	if readErr != nil {
+		// Drain the rest so git is not blocked writing output nobody reads
+		io.Copy(io.Discard, stdout)
+	}
+	err = cmd.Wait()
+	span.End(err)
+	if err != nil {
+		return fmt.Errorf("git cat-file --batch failed: %w\n%s", err, stderr.String())
+	}
+	return readErr
+}
+
+// readBatch parses count entries of git cat-file --batch output
+// Each entry is a "<oid> <type> <size>" header followed by the content, or "<object> missing"
+func readBatch(r *bufio.Reader, count int, fn func(i int, data []byte)) error {
+	for i := 0; i < count; i++ {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read git cat-file output: %w", err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			// missing or ambiguous objects have no content
+			fn(i, nil)
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid git cat-file header %q", strings.TrimSpace(header))
+		}
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return fmt.Errorf("failed to read git cat-file output: %w", err)
+		}
+		if fields[1] != "blob" {
+			fn(i, nil)
+			continue
+		}
+		fn(i, data[:size])
+	}
+	return nil
+}
+
+// ApplyAttributes appends the given lines to .gitattributes, skipping lines already present
+func (g *GitService) ApplyAttributes(lines []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	attrPath := filepath.Join(g.currentPath, ".gitattributes")
+	existing, err := os.ReadFile(attrPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitattributes: %w", err)
+	}
+
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+
+	var added []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || present[line] {
+			continue
+		}
+		present[line] = true
+		added = append(added, line)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += strings.Join(added, "\n") + "\n"
+
+	if err := fsutil.WriteFileAtomic(attrPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write .gitattributes: %w", err)
+	}
+	return nil
+}
+
+// attributesPattern returns the .gitattributes pattern covering files like the given one
+func attributesPattern(file string) string {
+	if ext := path.Ext(file); ext != "" {
+		return "*" + escapeAttributesPattern(ext)
+	}
+	return "/" + escapeAttributesPattern(file)
+}
+
+// escapeAttributesPattern escapes glob metacharacters and whitespace in a literal path
+// .gitattributes splits on whitespace and has no quoting, so spaces become [[:space:]]
+func escapeAttributesPattern(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		switch r {
+		case '*', '?', '[', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case ' ', '\t':
+			b.WriteString("[[:space:]]")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// detectEncoding returns the legacy encoding data is valid in, or "" when unknown
+// Only GBK is recognised: every byte above ASCII must start a lead and trail byte pair
+func detectEncoding(data []byte) string {
+	for i := 0; i < len(data); i++ {
+		if data[i] < 0x80 {
+			continue
+		}
+		if data[i] == 0x80 || data[i] == 0xFF || i+1 == len(data) {
+			return ""
+		}
+		if trail := data[i+1]; trail < 0x40 || trail == 0x7F || trail == 0xFF {
+			return ""
+		}
+		i++
+	}
+	return "GBK"
+}
+
+// normalizeEOL converts CRLF line endings to LF
+func normalizeEOL(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+}
+
+// isBinary uses the same heuristic as git: a NUL byte in the first 8000 bytes
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
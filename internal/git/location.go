@@ -0,0 +1,84 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// hashPattern matches tokens that could be an abbreviated commit hash
+var hashPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+
+// ResolveLocation works out whether a token is a branch, tag, commit or file
+// so the UI can navigate from mentions such as "reverts abc1234"
+func (g *GitService) ResolveLocation(token string) (*models.Location, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("token cannot be empty")
+	}
+
+	refs := []struct {
+		prefix string
+		kind   string
+	}{
+		{"refs/heads/", "branch"},
+		{"refs/remotes/", "remote-branch"},
+		{"refs/tags/", "tag"},
+	}
+	for _, ref := range refs {
+		if _, err := g.runGitCommand("show-ref", "--verify", "--quiet", ref.prefix+token); err == nil {
+			return &models.Location{
+				Kind:   ref.kind,
+				Name:   token,
+				Commit: g.commitInfo(ref.prefix + token),
+			}, nil
+		}
+	}
+
+	if hashPattern.MatchString(token) {
+		if hash, err := g.ResolveCommit(token); err == nil {
+			return &models.Location{
+				Kind:   "commit",
+				Name:   shortHash(hash),
+				Commit: g.commitInfo(hash),
+			}, nil
+		}
+	}
+
+	path := strings.TrimPrefix(filepath.ToSlash(token), "./")
+	if strings.HasPrefix(path, "../") || filepath.IsAbs(token) {
+		return &models.Location{Kind: "unknown", Name: token}, nil
+	}
+	if _, err := os.Stat(filepath.Join(g.currentPath, filepath.FromSlash(path))); err == nil {
+		return &models.Location{Kind: "file", Name: filepath.Base(path), Path: path}, nil
+	}
+	if g.HasCommits() {
+		if _, err := g.runGitCommand("cat-file", "-e", "HEAD:"+path); err == nil {
+			return &models.Location{Kind: "file", Name: filepath.Base(path), Path: path}, nil
+		}
+	}
+
+	return &models.Location{Kind: "unknown", Name: token}, nil
+}
+
+// commitInfo returns the log entry for a single revision, nil when it cannot be read
+func (g *GitService) commitInfo(rev string) *models.CommitInfo {
+	output, err := g.runGitCommand("log", "-1", "--pretty=format:"+logFormat, "--date=iso", rev, "--")
+	if err != nil {
+		return nil
+	}
+
+	commit, ok := parseLogLine(output)
+	if !ok {
+		return nil
+	}
+	return &commit
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+
+	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/trace"
+)
+
+// defaultLogChunk is the number of commits per chunk when none is given
+const defaultLogChunk = 200
+
+// StreamLog runs git log and hands commits to emit in chunks as git produces them
+// A Limit of 0 streams the whole history; cancelling ctx stops git
+func (g *GitService) StreamLog(ctx context.Context, opts models.LogOptions, chunkSize int, emit func([]models.CommitInfo)) (int, error) {
+	if g.currentPath == "" {
+		return 0, fmt.Errorf("no repository selected")
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultLogChunk
+	}
+	if !g.HasCommits() {
+		return 0, nil
+	}
+
+	args, err := g.logArgs(opts)
+	if err != nil {
+		return 0, err
+	}
+	cmd := newGitCommand(g.currentPath, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, err
+	}
+
+	span := trace.Start(trace.KindGit, gitSubcommand(args), g.currentPath)
+	if err := cmd.Start(); err != nil {
+		span.End(err)
+		return 0, err
+	}
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cmd.Process.Kill()
+		case <-stopped:
+		}
+	}()
+
+	total := 0
+	chunk := make([]models.CommitInfo, 0, chunkSize)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for ctx.Err() == nil && scanner.Scan() {
+		commit, ok := parseLogLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		chunk = append(chunk, commit)
+		if len(chunk) == chunkSize {
+			emit(chunk)
+			total += len(chunk)
+			chunk = make([]models.CommitInfo, 0, chunkSize)
+		}
+	}
+	if len(chunk) > 0 && ctx.Err() == nil {
+		emit(chunk)
+		total += len(chunk)
+	}
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	} else if scanErr := scanner.Err(); scanErr != nil {
+		err = scanErr
+	}
+	span.End(err)
+	if err != nil {
+		return total, fmt.Errorf("git log stream failed: %w", err)
+	}
+	return total, nil
+}
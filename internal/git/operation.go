@@ -0,0 +1,154 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// GetOperationState detects an unfinished merge, rebase, cherry-pick, revert or bisect
+func (g *GitService) GetOperationState() (models.OperationInfo, error) {
+	info := models.OperationInfo{State: models.OperationNone}
+	if g.currentPath == "" {
+		return info, fmt.Errorf("no repository selected")
+	}
+
+	dir, err := g.gitDir()
+	if err != nil {
+		return info, err
+	}
+
+	// rebase-merge is used by interactive and merge-based rebases, rebase-apply by am-based ones
+	for _, rebaseDir := range []string{"rebase-merge", "rebase-apply"} {
+		base := filepath.Join(dir, rebaseDir)
+		if _, err := os.Stat(base); err != nil {
+			continue
+		}
+		// rebase-apply without a rebasing marker is a git am session, which is not a rebase
+		if rebaseDir == "rebase-apply" {
+			if _, err := os.Stat(filepath.Join(base, "rebasing")); err != nil {
+				continue
+			}
+		}
+
+		info.State = models.OperationRebasing
+		info.Branch = strings.TrimPrefix(readStateFile(base, "head-name"), "refs/heads/")
+		info.Onto = readStateFile(base, "onto")
+		if rebaseDir == "rebase-merge" {
+			info.Step, _ = strconv.Atoi(readStateFile(base, "msgnum"))
+			info.Total, _ = strconv.Atoi(readStateFile(base, "end"))
+		} else {
+			info.Step, _ = strconv.Atoi(readStateFile(base, "next"))
+			info.Total, _ = strconv.Atoi(readStateFile(base, "last"))
+		}
+		return info, nil
+	}
+
+	markers := []struct {
+		file  string
+		state models.OperationState
+	}{
+		{"MERGE_HEAD", models.OperationMerging},
+		{"CHERRY_PICK_HEAD", models.OperationCherryPicking},
+		{"REVERT_HEAD", models.OperationReverting},
+	}
+	for _, m := range markers {
+		if head := readStateFile(dir, m.file); head != "" {
+			info.State = m.state
+			// MERGE_HEAD lists one line per merged head for octopus merges
+			info.Head = strings.SplitN(head, "\n", 2)[0]
+			return info, nil
+		}
+	}
+
+	if start := readStateFile(dir, "BISECT_START"); start != "" {
+		info.State = models.OperationBisecting
+		info.Branch = start
+		return info, nil
+	}
+
+	return info, nil
+}
+
+// ContinueOperation resumes the in-progress operation after conflicts were resolved
+func (g *GitService) ContinueOperation() error {
+	info, err := g.GetOperationState()
+	if err != nil {
+		return err
+	}
+
+	// Accept the prepared commit messages instead of opening an editor
+	env := []string{"GIT_EDITOR=true"}
+	switch info.State {
+	case models.OperationMerging:
+		_, err = g.runGitCommandEnv(env, "commit", "--no-edit")
+	case models.OperationRebasing:
+		_, err = g.runGitCommandEnv(env, "rebase", "--continue")
+	case models.OperationCherryPicking:
+		_, err = g.runGitCommandEnv(env, "cherry-pick", "--continue")
+	case models.OperationReverting:
+		_, err = g.runGitCommandEnv(env, "revert", "--continue")
+	case models.OperationBisecting:
+		return fmt.Errorf("bisect has no continue step, mark commits good or bad instead")
+	default:
+		return fmt.Errorf("no operation in progress")
+	}
+	return err
+}
+
+// AbortOperation cancels the in-progress operation and restores the previous state
+func (g *GitService) AbortOperation() error {
+	info, err := g.GetOperationState()
+	if err != nil {
+		return err
+	}
+
+	switch info.State {
+	case models.OperationMerging:
+		_, err = g.runGitCommand("merge", "--abort")
+	case models.OperationRebasing:
+		_, err = g.runGitCommand("rebase", "--abort")
+	case models.OperationCherryPicking:
+		_, err = g.runGitCommand("cherry-pick", "--abort")
+	case models.OperationReverting:
+		_, err = g.runGitCommand("revert", "--abort")
+	case models.OperationBisecting:
+		_, err = g.runGitCommand("bisect", "reset")
+	default:
+		return fmt.Errorf("no operation in progress")
+	}
+	return err
+}
+
+// SkipOperationStep skips the current commit of a rebase, cherry-pick or revert
+func (g *GitService) SkipOperationStep() error {
+	info, err := g.GetOperationState()
+	if err != nil {
+		return err
+	}
+
+	switch info.State {
+	case models.OperationRebasing:
+		_, err = g.runGitCommand("rebase", "--skip")
+	case models.OperationCherryPicking:
+		_, err = g.runGitCommand("cherry-pick", "--skip")
+	case models.OperationReverting:
+		_, err = g.runGitCommand("revert", "--skip")
+	default:
+		return fmt.Errorf("the current operation cannot skip a step")
+	}
+	return err
+}
+
+// readStateFile reads a small state file from the git directory, empty when missing
+func readStateFile(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,147 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/trace"
+)
+
+// DefaultMaxOutput caps output meant for display, such as diffs, when no limit is configured
+const DefaultMaxOutput int64 = 5 << 20
+
+// SetMaxOutput sets the byte limit for displayed output and file contents, 0 restores the default
+func (g *GitService) SetMaxOutput(limit int64) {
+	if limit <= 0 {
+		limit = DefaultMaxOutput
+	}
+	g.maxOutput.Store(limit)
+}
+
+// outputLimit returns the configured byte limit for displayed output
+func (g *GitService) outputLimit() int64 {
+	if limit := g.maxOutput.Load(); limit > 0 {
+		return limit
+	}
+	return DefaultMaxOutput
+}
+
+// limitedWriter keeps the first limit bytes written to it and counts the rest
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int64
+	total int64
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	w.total += int64(len(p))
+	if room := w.limit - int64(w.buf.Len()); room > 0 {
+		if int64(len(p)) > room {
+			w.buf.Write(p[:room])
+		} else {
+			w.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// runGitCommandLimited runs a git command whose output is meant for display
+// Output over the limit is cut at a line boundary and ends with a truncation marker
+func (g *GitService) runGitCommandLimited(args ...string) (string, error) {
+	out := &limitedWriter{limit: g.outputLimit()}
+	if err := g.streamGitCommand(out, args...); err != nil {
+		return "", err
+	}
+
+	data := out.buf.Bytes()
+	if out.total <= out.limit {
+		return strings.TrimSuffix(string(data), "\n"), nil
+	}
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	}
+	return fmt.Sprintf("%s\n[output truncated: showing %d of %d bytes]", data, len(data), out.total), nil
+}
+
+// streamGitCommand copies the stdout of a git command to w as it is produced
+func (g *GitService) streamGitCommand(w io.Writer, args ...string) error {
+	cmd := newGitCommand(g.currentPath, args...)
+	cmd.Stdout = w
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	span := trace.Start(trace.KindGit, gitSubcommand(args), g.currentPath)
+	err := cmd.Run()
+	span.End(err)
+	if err != nil {
+		return fmt.Errorf("git %s failed: %w\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return nil
+}
+
+// GetFileAtRevision returns the raw content of path at rev, or in the index when rev is empty
+// Files larger than the output limit are refused instead of being read into memory
+func (g *GitService) GetFileAtRevision(path, rev string) ([]byte, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	path, err := g.repoPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	object := ":" + path
+	if rev != "" {
+		hash, err := g.ResolveCommit(rev)
+		if err != nil {
+			return nil, err
+		}
+		object = hash + ":" + path
+	}
+
+	sizeOutput, err := g.runGitCommand("cat-file", "-s", object)
+	if err != nil {
+		return nil, fmt.Errorf("file not found: %s", object)
+	}
+	size, _ := strconv.ParseInt(strings.TrimSpace(sizeOutput), 10, 64)
+	if limit := g.outputLimit(); size > limit {
+		return nil, fmt.Errorf("%s is %d bytes, larger than the %d byte limit", path, size, limit)
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(size))
+	if err := g.streamGitCommand(&buf, "cat-file", "blob", object); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportArchive writes a zip, tar or tar.gz archive of rev to dest, streaming it to disk
+func (g *GitService) ExportArchive(rev, format, dest string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	if dest == "" {
+		return fmt.Errorf("destination cannot be empty")
+	}
+
+	switch format {
+	case "zip", "tar", "tar.gz", "tgz":
+	case "":
+		format = "zip"
+	default:
+		return fmt.Errorf("unsupported archive format: %s", format)
+	}
+	hash, err := g.ResolveCommit(rev)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.StreamFileAtomic(dest, 0644, func(w io.Writer) error {
+		return g.streamGitCommand(w, "archive", "--format="+format, hash)
+	})
+}
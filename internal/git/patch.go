@@ -0,0 +1,194 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/models"
+)
+
+// FormatPatchSeries formats the commits of revRange as mailbox patches
+// revRange is a revision range such as origin/main..HEAD, or a number for the last N commits
+func (g *GitService) FormatPatchSeries(revRange string, coverLetter bool) ([]models.PatchFile, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	revRange = strings.TrimSpace(revRange)
+	if revRange == "" || strings.HasPrefix(revRange, "-") {
+		return nil, fmt.Errorf("invalid revision range: %s", revRange)
+	}
+	if n, err := strconv.Atoi(revRange); err == nil {
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid revision range: %s", revRange)
+		}
+		revRange = "-" + revRange
+	}
+
+	dir, err := os.MkdirTemp("", "git-ai-tools-patches-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"format-patch", "--thread", "-o", dir}
+	if coverLetter {
+		args = append(args, "--cover-letter")
+	}
+	args = append(args, revRange)
+	if _, err := g.runGitCommand(args...); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	patches := []models.PatchFile{}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, models.PatchFile{
+			Name:    name,
+			Subject: patchSubject(string(content)),
+			Content: string(content),
+		})
+	}
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no commits in range %s", revRange)
+	}
+	return patches, nil
+}
+
+// SendPatchesWithGit sends patches through git send-email, using its own sendemail configuration
+func (g *GitService) SendPatchesWithGit(patches []models.PatchFile, to, cc []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+
+	dir, err := os.MkdirTemp("", "git-ai-tools-send-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"send-email", "--confirm=never", "--quiet"}
+	for _, addr := range to {
+		args = append(args, "--to="+addr)
+	}
+	for _, addr := range cc {
+		args = append(args, "--cc="+addr)
+	}
+	args = append(args, "--")
+	for _, p := range patches {
+		path := filepath.Join(dir, filepath.Base(p.Name))
+		if err := os.WriteFile(path, []byte(p.Content), 0644); err != nil {
+			return err
+		}
+		args = append(args, path)
+	}
+
+	_, err = g.runGitCommand(args...)
+	return err
+}
+
+// patchSubject returns the unfolded Subject header of a mailbox patch
+func patchSubject(content string) string {
+	var subject []string
+	inSubject := false
+	for _, line := range strings.Split(content, "\n") {
+		if line == "" {
+			break
+		}
+		if inSubject && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			subject = append(subject, strings.TrimSpace(line))
+			continue
+		}
+		inSubject = strings.HasPrefix(line, "Subject: ")
+		if inSubject {
+			subject = append(subject, strings.TrimPrefix(line, "Subject: "))
+		}
+	}
+	return strings.Join(subject, " ")
+}
+
+// SelectionPatch returns a patch of the selected files that git apply accepts: their staged
+// changes, or their working tree changes with untracked files included as new files
+func (g *GitService) SelectionPatch(paths []string, staged bool) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	specs := g.selectionPathspecs(paths)
+	if len(specs) == 0 {
+		return "", fmt.Errorf("no paths selected")
+	}
+	// External diff drivers and textconv would produce output apply cannot read
+	diffArgs := []string{"--literal-pathspecs", "diff", "--binary", "--no-color", "--no-ext-diff", "--no-textconv"}
+	if staged {
+		diffArgs = append(diffArgs, "--cached")
+	}
+	diffArgs = append(append(diffArgs, "--"), specs...)
+
+	var env []string
+	if !staged {
+		listArgs := append([]string{"--literal-pathspecs", "ls-files", "-z", "--others", "--exclude-standard", "--"}, specs...)
+		output, err := g.runGitCommandRaw(listArgs...)
+		if err != nil {
+			return "", err
+		}
+		var untracked []string
+		for _, name := range strings.Split(string(output), "\x00") {
+			if name != "" {
+				untracked = append(untracked, name)
+			}
+		}
+
+		// Untracked files are marked intent-to-add in a copy of the index, so the diff
+		// shows them as new files while the real index stays untouched
+		if len(untracked) > 0 {
+			gitDir, err := g.gitDir()
+			if err != nil {
+				return "", err
+			}
+			tmpIndex := filepath.Join(gitDir, "gitai-export-index")
+			defer os.Remove(tmpIndex)
+			if _, err := os.Stat(filepath.Join(gitDir, "index")); err == nil {
+				if err := fsutil.CopyFileAtomic(filepath.Join(gitDir, "index"), tmpIndex); err != nil {
+					return "", fmt.Errorf("failed to copy index: %w", err)
+				}
+			}
+			env = []string{"GIT_INDEX_FILE=" + tmpIndex}
+			addArgs := append([]string{"--literal-pathspecs", "add", "--intent-to-add", "--"}, untracked...)
+			if _, err := g.runGitCommandEnv(env, addArgs...); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	patch, err := g.runGitCommandEnv(env, diffArgs...)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(patch) == "" {
+		return "", fmt.Errorf("selected files have no changes")
+	}
+	// The trailing newline trimmed from command output ends the last line of the patch
+	return patch + "\n", nil
+}
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// repoPath converts a file path from the UI into a repository-relative path with
+// forward slashes, accepting Windows separators and absolute paths inside the repository
+func (g *GitService) repoPath(p string) (string, error) {
+	if strings.TrimSpace(p) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	clean, ok := g.normalizeSelection(p)
+	if !ok {
+		return "", fmt.Errorf("path is outside the repository: %s", p)
+	}
+	return clean, nil
+}
+
+// ScopePath validates a scope directory for the repository at root and returns it relative
+// to root with forward slashes, or "" for the whole repository
+func ScopePath(root, scope string) (string, error) {
+	if strings.TrimSpace(scope) == "" {
+		return "", nil
+	}
+	clean, ok := relativePath(root, scope)
+	if !ok {
+		return "", fmt.Errorf("scope is outside the repository: %s", scope)
+	}
+	if clean == "." {
+		return "", nil
+	}
+	return clean, nil
+}
+
+// cleanDir normalizes a directory path from the UI for the current platform
+func cleanDir(dir string) string {
+	return filepath.Clean(strings.TrimSpace(dir))
+}
@@ -0,0 +1,136 @@
+package git
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestRepoPath(t *testing.T) {
+	unixRoot := "/home/user/repo"
+	tests := []struct {
+		name    string
+		goos    string // only run on this OS when set
+		root    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "relative", root: unixRoot, path: "src/main.go", want: "src/main.go"},
+		{name: "surrounding spaces", root: unixRoot, path: "  src/main.go ", want: "src/main.go"},
+		{name: "dot and double slashes", root: unixRoot, path: "./src//main.go", want: "src/main.go"},
+		{name: "dot dot inside the repository", root: unixRoot, path: "src/../main.go", want: "main.go"},
+		{name: "repository itself", root: unixRoot, path: ".", want: "."},
+		{name: "dot dot escape", root: unixRoot, path: "../outside.go", wantErr: true},
+		{name: "nested dot dot escape", root: unixRoot, path: "src/../../outside.go", wantErr: true},
+		{name: "empty", root: unixRoot, path: "  ", wantErr: true},
+
+		{name: "absolute inside", goos: "linux", root: unixRoot, path: "/home/user/repo/src/a.go", want: "src/a.go"},
+		{name: "absolute root", goos: "linux", root: unixRoot, path: "/home/user/repo", want: "."},
+		{name: "absolute sibling", goos: "linux", root: unixRoot, path: "/home/user/repo2/a.go", wantErr: true},
+		{name: "absolute outside", goos: "linux", root: unixRoot, path: "/etc/passwd", wantErr: true},
+		{name: "case is significant", goos: "linux", root: unixRoot, path: "/home/user/REPO/a.go", wantErr: true},
+		{name: "backslash is a file name character", goos: "linux", root: unixRoot, path: `src\main.go`, want: `src\main.go`},
+
+		{name: "backslashes", goos: "windows", root: `C:\repo`, path: `src\main.go`, want: "src/main.go"},
+		{name: "mixed separators", goos: "windows", root: `C:\repo`, path: `src/pkg\a.go`, want: "src/pkg/a.go"},
+		{name: "backslash dot dot escape", goos: "windows", root: `C:\repo`, path: `src\..\..\x.go`, wantErr: true},
+		{name: "drive letter", goos: "windows", root: `C:\repo`, path: `C:\repo\src\a.go`, want: "src/a.go"},
+		{name: "drive letter with forward slashes", goos: "windows", root: `C:\repo`, path: `C:/repo/src/a.go`, want: "src/a.go"},
+		{name: "drive letter and folder case", goos: "windows", root: `C:\repo`, path: `c:\REPO\Src\a.go`, want: "Src/a.go"},
+		{name: "other drive", goos: "windows", root: `C:\repo`, path: `D:\repo\a.go`, wantErr: true},
+		{name: "sibling folder", goos: "windows", root: `C:\repo`, path: `C:\repo2\a.go`, wantErr: true},
+		{name: "rooted without drive", goos: "windows", root: `C:\repo`, path: `\repo\a.go`, wantErr: true},
+		{name: "UNC path", goos: "windows", root: `\\server\share\repo`, path: `\\server\share\repo\a\b.go`, want: "a/b.go"},
+		{name: "UNC server case", goos: "windows", root: `\\server\share\repo`, path: `\\SERVER\Share\repo\b.go`, want: "b.go"},
+		{name: "UNC outside the repository", goos: "windows", root: `\\server\share\repo`, path: `\\server\share\other\b.go`, wantErr: true},
+		{name: "UNC other share", goos: "windows", root: `\\server\share\repo`, path: `\\server\other\repo\b.go`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.goos != "" && tt.goos != runtime.GOOS {
+				t.Skipf("only runs on %s", tt.goos)
+			}
+			g := &GitService{currentPath: tt.root}
+			got, err := g.repoPath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("repoPath(%q) = %q, want an error", tt.path, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("repoPath(%q) failed: %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("repoPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanDir(t *testing.T) {
+	tests := []struct {
+		goos string
+		dir  string
+		want string
+	}{
+		{"", "  repo  ", "repo"},
+		{"", "repo/sub/..", "repo"},
+		{"linux", "/home/user/repo/", "/home/user/repo"},
+		{"linux", "/home/user//repo/./", "/home/user/repo"},
+		{"windows", `C:\repo\`, `C:\repo`},
+		{"windows", `C:/repo/sub/..`, `C:\repo`},
+		{"windows", ` C:\Users\me\repo `, `C:\Users\me\repo`},
+		{"windows", `\\server\share\repo\`, `\\server\share\repo`},
+		{"windows", `//server/share/repo`, `\\server\share\repo`},
+	}
+	for _, tt := range tests {
+		if tt.goos != "" && tt.goos != runtime.GOOS {
+			continue
+		}
+		if got := cleanDir(tt.dir); got != filepath.FromSlash(tt.want) {
+			t.Errorf("cleanDir(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestScopePath(t *testing.T) {
+	tests := []struct {
+		goos    string
+		scope   string
+		want    string
+		wantErr bool
+	}{
+		{scope: "", want: ""},
+		{scope: ".", want: ""},
+		{scope: "./src/pkg/", want: "src/pkg"},
+		{scope: "src/../docs", want: "docs"},
+		{scope: "../other-repo", wantErr: true},
+		{scope: "src/../../other-repo", wantErr: true},
+		{goos: "linux", scope: "/home/user/repo/src", want: "src"},
+		{goos: "linux", scope: "/home/user/other-repo", wantErr: true},
+		{goos: "windows", scope: `src\pkg`, want: "src/pkg"},
+		{goos: "windows", scope: `D:\other-repo`, wantErr: true},
+	}
+	root := filepath.FromSlash("/home/user/repo")
+	if runtime.GOOS == "windows" {
+		root = `C:\home\user\repo`
+	}
+	for _, tt := range tests {
+		if tt.goos != "" && tt.goos != runtime.GOOS {
+			continue
+		}
+		got, err := ScopePath(root, tt.scope)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ScopePath(%q) = %q, want an error", tt.scope, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ScopePath(%q) = %q, %v, want %q", tt.scope, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// UpdateSubmodules checks out the submodules of the repository recursively
+// Running it again picks up where an interrupted update stopped
+func (g *GitService) UpdateSubmodules(depth int) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	args := []string{"submodule", "update", "--init", "--recursive"}
+	if depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", depth))
+	}
+	_, err := g.runGitCommand(args...)
+	return err
+}
+
+// PullLFS installs the Git LFS hooks in the repository and downloads its LFS files
+func (g *GitService) PullLFS() error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if _, err := g.runGitCommand("lfs", "version"); err != nil {
+		return fmt.Errorf("git lfs is not installed")
+	}
+	if _, err := g.runGitCommand("lfs", "install", "--local"); err != nil {
+		return err
+	}
+	_, err := g.runGitCommand("lfs", "pull")
+	return err
+}
+
+// RunSetupCommand runs a shell command in the repository and returns its output
+// It is killed with the git commands when the app shuts down
+func (g *GitService) RunSetupCommand(command string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(processCtx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(processCtx, "sh", "-c", command)
+	}
+	cmd.Dir = g.currentPath
+	trackProcess(cmd)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return strings.TrimSpace(string(output)), fmt.Errorf("%q failed: %w", command, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+	"git-ai-tools/internal/trace"
+)
+
+// PredictMergeConflicts merges source into target in memory with git merge-tree and
+// reports the files that would conflict, without touching the working tree or index
+// target defaults to HEAD
+func (g *GitService) PredictMergeConflicts(source, target string) (*models.MergePrediction, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if source == "" {
+		return nil, fmt.Errorf("source branch cannot be empty")
+	}
+	if strings.HasPrefix(source, "-") || strings.HasPrefix(target, "-") {
+		return nil, fmt.Errorf("invalid branch name")
+	}
+	if target == "" {
+		target = "HEAD"
+	}
+
+	sourceHash, err := g.ResolveCommit(source)
+	if err != nil {
+		return nil, err
+	}
+	targetHash, err := g.ResolveCommit(target)
+	if err != nil {
+		return nil, err
+	}
+
+	prediction := &models.MergePrediction{
+		Source:    source,
+		Target:    target,
+		Conflicts: []models.MergeConflict{},
+	}
+	if base, err := g.runGitCommand("merge-base", targetHash, sourceHash); err == nil {
+		prediction.MergeBase = strings.TrimSpace(base)
+	}
+	switch prediction.MergeBase {
+	case sourceHash:
+		prediction.UpToDate = true
+		prediction.Clean = true
+		return prediction, nil
+	case targetHash:
+		prediction.FastForward = true
+		prediction.Clean = true
+		return prediction, nil
+	}
+
+	// Names rather than hashes keep git's conflict messages readable
+	args := []string{"merge-tree", "--write-tree", "-z", "--name-only", target, source}
+	cmd := newGitCommand(g.currentPath, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	span := trace.Start(trace.KindGit, gitSubcommand(args), g.currentPath)
+	err = cmd.Run()
+	span.End(err)
+
+	// merge-tree exits with 1 when the merge has conflicts
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		prediction.Clean = true
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		prediction.Conflicts = parseMergeTree(stdout.String())
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 129:
+		return nil, fmt.Errorf("conflict prediction requires git 2.38 or later")
+	default:
+		return nil, fmt.Errorf("git %s failed: %w\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return prediction, nil
+}
+
+// parseMergeTree reads the conflicted files of `merge-tree --write-tree -z --name-only`
+// The output is the tree, the conflicted paths, an empty field, and then messages of
+// <path count> <paths...> <type> <message>, all NUL separated
+func parseMergeTree(output string) []models.MergeConflict {
+	fields := strings.Split(output, "\x00")
+	if len(fields) < 2 {
+		return []models.MergeConflict{}
+	}
+
+	conflicts := []models.MergeConflict{}
+	index := map[string]int{}
+	i := 1
+	for ; i < len(fields) && fields[i] != ""; i++ {
+		index[fields[i]] = len(conflicts)
+		conflicts = append(conflicts, models.MergeConflict{Path: fields[i]})
+	}
+
+	// Messages attach the conflict type to each path, informational ones such as
+	// Auto-merging are skipped
+	for i++; i < len(fields); {
+		count, err := strconv.Atoi(fields[i])
+		if err != nil || i+count+2 >= len(fields) {
+			break
+		}
+		paths := fields[i+1 : i+1+count]
+		kind := fields[i+1+count]
+		message := strings.TrimSpace(fields[i+2+count])
+		i += count + 3
+
+		if !strings.HasPrefix(kind, "CONFLICT") {
+			continue
+		}
+		kind = strings.TrimSuffix(strings.TrimPrefix(kind, "CONFLICT ("), ")")
+		for _, path := range paths {
+			if n, ok := index[path]; ok && conflicts[n].Type == "" {
+				conflicts[n].Type = kind
+				conflicts[n].Message = message
+			}
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// OutgoingCommits lists the commits a push of branch to remote would send, with their
+// signature state, and whether the remote branch would only fast-forward
+// An empty branch means the current one; the remote branch is the upstream's when branch
+// tracks remote, otherwise one of the same name
+func (g *GitService) OutgoingCommits(remote, branch string) (*models.OutgoingPush, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if branch == "" {
+		current, err := g.CurrentBranch()
+		if err != nil {
+			return nil, err
+		}
+		if current == "" {
+			return nil, fmt.Errorf("HEAD is detached, choose a branch to push")
+		}
+		branch = current
+	}
+	if strings.HasPrefix(branch, "-") || strings.HasPrefix(remote, "-") {
+		return nil, fmt.Errorf("invalid branch or remote name")
+	}
+
+	upstreamRemote, upstreamRef := g.upstreamOf(branch)
+	if remote == "" {
+		remote = upstreamRemote
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	remoteBranch := branch
+	if upstreamRef != "" && remote == upstreamRemote {
+		remoteBranch = strings.TrimPrefix(upstreamRef, "refs/heads/")
+	}
+
+	result := &models.OutgoingPush{
+		Remote:       remote,
+		Branch:       branch,
+		RemoteBranch: remoteBranch,
+		FastForward:  true,
+		Commits:      []models.OutgoingCommit{},
+	}
+
+	// Without the remote branch everything not yet on the remote is new
+	rangeArgs := []string{"refs/heads/" + branch, "--not", "--remotes=" + remote}
+	tracking := "refs/remotes/" + remote + "/" + remoteBranch
+	if _, err := g.runGitCommand("rev-parse", "--verify", "-q", tracking); err == nil {
+		rangeArgs = []string{tracking + "..refs/heads/" + branch}
+		_, err := g.runGitCommand("merge-base", "--is-ancestor", tracking, "refs/heads/"+branch)
+		result.FastForward = err == nil
+	} else {
+		result.NewBranch = true
+	}
+
+	output, err := g.runGitCommand(append([]string{"log", "--format=%H%x1f%P%x1f%G?%x1f%s"}, rangeArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\x1f", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		result.Commits = append(result.Commits, models.OutgoingCommit{
+			Hash:      parts[0],
+			ShortHash: shortHash(parts[0]),
+			Subject:   parts[3],
+			Signature: parts[2],
+			Merge:     len(strings.Fields(parts[1])) > 1,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// processCtx is cancelled by KillRunning to stop the git commands and hooks still running
+var processCtx, cancelProcesses = context.WithCancel(context.Background())
+
+// killGrace is how long a killed command gets to exit before it is killed for good
+// and its lock files are cleaned up
+const killGrace = time.Second
+
+// killedWriter is a killed command that may have left the index of its repository locked
+type killedWriter struct {
+	started time.Time
+	killed  time.Time
+}
+
+var (
+	killedMu      sync.Mutex
+	killedWriters = map[string]killedWriter{}
+)
+
+// indexWriters are the git subcommands that take index.lock
+var indexWriters = map[string]bool{
+	"add": true, "am": true, "apply": true, "checkout": true, "cherry-pick": true,
+	"commit": true, "merge": true, "mv": true, "pull": true, "read-tree": true,
+	"rebase": true, "reset": true, "restore": true, "revert": true, "rm": true,
+	"stash": true, "switch": true, "update-index": true,
+}
+
+// trackProcess ties cmd, created with processCtx, to KillRunning
+// The whole process tree is stopped, so hooks and credential helpers go too
+func trackProcess(cmd *exec.Cmd) {
+	configureProcess(cmd)
+	cmd.Cancel = func() error {
+		return killProcessTree(cmd)
+	}
+	cmd.WaitDelay = killGrace
+}
+
+// trackIndexWriter marks cmd, a git command run with args, so that when it is killed the
+// index.lock it leaves behind is removed; commands that do not write the index never are
+func trackIndexWriter(cmd *exec.Cmd, args []string) {
+	if !indexWriters[strings.TrimPrefix(gitSubcommand(args), "git ")] {
+		return
+	}
+	started := time.Now()
+	kill := cmd.Cancel
+	cmd.Cancel = func() error {
+		killedMu.Lock()
+		w, seen := killedWriters[cmd.Dir]
+		if !seen || started.Before(w.started) {
+			w.started = started
+		}
+		w.killed = time.Now()
+		killedWriters[cmd.Dir] = w
+		killedMu.Unlock()
+		return kill()
+	}
+}
+
+// KillRunning stops every git command and hook still running, waits for them to exit and
+// removes the index.lock a killed command left behind; commands started afterwards fail
+// It is meant for application shutdown
+func KillRunning() {
+	cancelProcesses()
+
+	// Cancel functions run on the goroutines waiting for each command
+	time.Sleep(100 * time.Millisecond)
+	killedMu.Lock()
+	writers := make(map[string]killedWriter, len(killedWriters))
+	for dir, w := range killedWriters {
+		writers[dir] = w
+	}
+	killedMu.Unlock()
+	if len(writers) == 0 {
+		return
+	}
+
+	time.Sleep(killGrace)
+	for dir, w := range writers {
+		removeStaleIndexLock(dir, w)
+	}
+}
+
+// removeStaleIndexLock deletes the index lock of the repository at dir when it was last
+// written while the killed command ran, so it was left by that command and not by a git
+// process started outside the app
+// processCtx is already cancelled, so the git directory is resolved with a plain command
+func removeStaleIndexLock(dir string, w killedWriter) {
+	if dir == "" {
+		return
+	}
+	cmd := exec.Command(Executable(), "rev-parse", "--absolute-git-dir")
+	cmd.Dir = dir
+	configureProcess(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return
+	}
+	lock := filepath.Join(strings.TrimSpace(string(out)), "index.lock")
+	info, err := os.Stat(lock)
+	if err != nil {
+		return
+	}
+	// File times can be coarser than the clock, so allow a second on either side
+	if modified := info.ModTime(); modified.Before(w.started.Add(-time.Second)) || modified.After(w.killed.Add(time.Second)) {
+		return
+	}
+	os.Remove(lock)
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package git
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureProcess starts the command in its own process group so it can be killed
+// together with the processes it starts
+func configureProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+}
+
+// killProcessTree asks the process group of the command to terminate, which lets git
+// remove its lock files; processes still running after killGrace are killed
+func killProcessTree(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+}
+
+// ContainChildProcesses is a no-op, commands run in process groups killed one by one
+func ContainChildProcesses() error {
+	return nil
+}
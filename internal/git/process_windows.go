@@ -0,0 +1,50 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// configureProcess keeps child processes from flashing a console window
+func configureProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow: true,
+	}
+}
+
+// killProcessTree kills the command; its own children belong to the job object set up by
+// ContainChildProcesses and die with the application
+func killProcessTree(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// ContainChildProcesses puts the application in a job object that kills every process
+// started from it, git and whatever git started, once the application exits
+// Processes may still break away from the job when they ask to
+func ContainChildProcesses() error {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create job object: %w", err)
+	}
+
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE | windows.JOB_OBJECT_LIMIT_BREAKAWAY_OK,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		windows.CloseHandle(job)
+		return fmt.Errorf("failed to configure job object: %w", err)
+	}
+	// The handle stays open for the lifetime of the process, closing it kills the job
+	if err := windows.AssignProcessToJobObject(job, windows.CurrentProcess()); err != nil {
+		windows.CloseHandle(job)
+		return fmt.Errorf("failed to join job object: %w", err)
+	}
+	return nil
+}
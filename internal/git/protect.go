@@ -0,0 +1,163 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// ErrProtectedPath is returned when an operation would throw away changes to a protected file
+var ErrProtectedPath = errors.New("protected files would be affected")
+
+// confirmationTTL is how long a confirmation of protected paths stays valid
+const confirmationTTL = time.Minute
+
+// protection holds the protected path patterns and recent confirmations
+type protection struct {
+	mu        sync.Mutex
+	config    models.ProtectedPaths
+	confirmed map[string]time.Time
+}
+
+// SetProtectedPaths replaces the patterns guarding discard, clean and forced checkout
+func (g *GitService) SetProtectedPaths(config models.ProtectedPaths) {
+	g.protection.mu.Lock()
+	defer g.protection.mu.Unlock()
+	g.protection.config = config
+}
+
+// ConfirmProtectedPaths allows the given protected files to be discarded for the next minute
+// Confirmation is refused when protected paths are configured to be blocked outright
+func (g *GitService) ConfirmProtectedPaths(paths []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	g.protection.mu.Lock()
+	defer g.protection.mu.Unlock()
+	if g.protection.config.Block {
+		return fmt.Errorf("protected files cannot be discarded; remove the pattern in settings first")
+	}
+	if g.protection.confirmed == nil {
+		g.protection.confirmed = map[string]time.Time{}
+	}
+	until := time.Now().Add(confirmationTTL)
+	for _, p := range paths {
+		if clean, ok := g.normalizeSelection(p); ok {
+			g.protection.confirmed[g.currentPath+"\x00"+clean] = until
+		}
+	}
+	return nil
+}
+
+// CheckProtected fails with ErrProtectedPath when files would be affected that match a
+// protected pattern and have not been confirmed
+func (g *GitService) CheckProtected(files []string) error {
+	g.protection.mu.Lock()
+	defer g.protection.mu.Unlock()
+	if len(g.protection.config.Patterns) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var hits []string
+	for _, file := range files {
+		clean, ok := g.normalizeSelection(file)
+		if !ok || !matchesAny(g.protection.config.Patterns, clean) {
+			continue
+		}
+		if until, ok := g.protection.confirmed[g.currentPath+"\x00"+clean]; ok && now.Before(until) {
+			continue
+		}
+		hits = append(hits, clean)
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrProtectedPath, strings.Join(hits, ", "))
+}
+
+// CheckProtectedDiscard checks the modified files under paths before their changes are discarded
+func (g *GitService) CheckProtectedDiscard(paths []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	return g.checkProtectedSelection(paths, []string{"ls-files", "-z", "--modified"})
+}
+
+// CheckProtectedClean checks the untracked files under paths before they are removed
+func (g *GitService) CheckProtectedClean(paths []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	return g.checkProtectedSelection(paths, []string{"ls-files", "-z", "--others", "--exclude-standard"})
+}
+
+// checkProtectedSelection checks the files that list reports under the selected paths
+func (g *GitService) checkProtectedSelection(paths []string, list []string) error {
+	specs := g.selectionPathspecs(paths)
+	if len(specs) == 0 {
+		return nil
+	}
+	args := append(append([]string{"--literal-pathspecs"}, list...), "--")
+	output, err := g.runGitCommandRaw(append(args, specs...)...)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, name := range strings.Split(string(output), "\x00") {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+	return g.CheckProtected(files)
+}
+
+// matchesAny reports whether file matches one of the gitignore-style patterns
+// A pattern without a slash matches the file name at any depth, "**" spans directories
+func matchesAny(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(strings.ReplaceAll(pattern, "\\", "/"))
+		if pattern == "" {
+			continue
+		}
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "**"
+		}
+		if !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		if matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(file, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, "**" matching zero or more
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
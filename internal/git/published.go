@@ -0,0 +1,209 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// ErrRewritesPublished is returned when an operation would rewrite commits that already
+// exist on a remote and the rewrite has not been acknowledged
+var ErrRewritesPublished = errors.New("commits already on a remote would be rewritten")
+
+// rewriteGuard holds the published commits recently acknowledged for rewriting
+type rewriteGuard struct {
+	mu           sync.Mutex
+	acknowledged map[string]time.Time
+}
+
+// RewrittenCommits reports the commits from base (exclusive) to tip that are reachable from
+// a remote-tracking branch, which rewriting would force everyone who fetched them to recover
+// An empty base covers the whole history of tip, an empty tip means HEAD
+func (g *GitService) RewrittenCommits(base, tip string) (*models.RewrittenCommits, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if tip == "" {
+		tip = "HEAD"
+	}
+	tipHash, err := g.ResolveCommit(tip)
+	if err != nil {
+		return nil, err
+	}
+	rangeArgs := []string{tipHash}
+	if base != "" {
+		baseHash, err := g.ResolveCommit(base)
+		if err != nil {
+			return nil, err
+		}
+		rangeArgs = append(rangeArgs, "^"+baseHash)
+	}
+
+	report := &models.RewrittenCommits{
+		Base:    base,
+		Tip:     tip,
+		Commits: []models.RewrittenCommit{},
+		Refs:    []string{},
+		Remotes: []string{},
+	}
+
+	unpublished, err := g.revSet(append(rangeArgs, "--not", "--remotes")...)
+	if err != nil {
+		return nil, err
+	}
+	output, err := g.runGitCommand(append([]string{"log", "--format=%H%x1f%h%x1f%P%x1f%s"}, rangeArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	published := map[string]bool{}
+	parents := map[string][]string{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) < 4 || unpublished[fields[0]] {
+			continue
+		}
+		published[fields[0]] = true
+		parents[fields[0]] = strings.Fields(fields[2])
+		report.Commits = append(report.Commits, models.RewrittenCommit{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Subject:   fields[3],
+			Refs:      []string{},
+		})
+	}
+	if len(report.Commits) == 0 {
+		return report, nil
+	}
+
+	// A remote branch containing any published commit contains one whose parents are not
+	// published, so those are enough to find every branch
+	args := []string{"for-each-ref", "--format=%(refname)"}
+	for hash, ps := range parents {
+		bottom := true
+		for _, p := range ps {
+			if published[p] {
+				bottom = false
+				break
+			}
+		}
+		if bottom {
+			args = append(args, "--contains", hash)
+		}
+	}
+	refsOutput, err := g.runGitCommand(append(args, "refs/remotes")...)
+	if err != nil {
+		return nil, err
+	}
+	remoteNames, _ := g.GetRemoteNames()
+	remotes := map[string]bool{}
+	for _, ref := range strings.Split(refsOutput, "\n") {
+		name, ok := strings.CutPrefix(strings.TrimSpace(ref), "refs/remotes/")
+		if !ok || strings.HasSuffix(name, "/HEAD") {
+			continue
+		}
+
+		missing, err := g.revSet(append(rangeArgs, "^"+ref)...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range report.Commits {
+			if !missing[report.Commits[i].Hash] {
+				report.Commits[i].Refs = append(report.Commits[i].Refs, name)
+			}
+		}
+		report.Refs = append(report.Refs, name)
+		if remote := remoteOfRef(name, remoteNames); remote != "" && !remotes[remote] {
+			remotes[remote] = true
+			report.Remotes = append(report.Remotes, remote)
+		}
+	}
+	sort.Strings(report.Remotes)
+	return report, nil
+}
+
+// AcknowledgeRewrite allows the given published commits to be rewritten for the next minute
+func (g *GitService) AcknowledgeRewrite(hashes []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	resolved := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		hash, err := g.ResolveCommit(h)
+		if err != nil {
+			return err
+		}
+		resolved = append(resolved, hash)
+	}
+
+	g.rewrites.mu.Lock()
+	defer g.rewrites.mu.Unlock()
+	if g.rewrites.acknowledged == nil {
+		g.rewrites.acknowledged = map[string]time.Time{}
+	}
+	until := time.Now().Add(confirmationTTL)
+	for _, hash := range resolved {
+		g.rewrites.acknowledged[g.currentPath+"\x00"+hash] = until
+	}
+	return nil
+}
+
+// checkRewrite fails with ErrRewritesPublished when base..tip contains commits on a remote
+// that have not been acknowledged with AcknowledgeRewrite
+func (g *GitService) checkRewrite(base, tip string) error {
+	if !g.HasCommits() {
+		return nil
+	}
+	report, err := g.RewrittenCommits(base, tip)
+	if err != nil {
+		return err
+	}
+
+	g.rewrites.mu.Lock()
+	defer g.rewrites.mu.Unlock()
+	now := time.Now()
+	var hits []string
+	for _, c := range report.Commits {
+		if until, ok := g.rewrites.acknowledged[g.currentPath+"\x00"+c.Hash]; ok && now.Before(until) {
+			continue
+		}
+		hits = append(hits, c.ShortHash)
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s on %s", ErrRewritesPublished, strings.Join(hits, ", "), strings.Join(report.Refs, ", "))
+}
+
+// revSet returns the commits rev-list lists for args
+func (g *GitService) revSet(args ...string) (map[string]bool, error) {
+	output, err := g.runGitCommand(append([]string{"rev-list"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	set := map[string]bool{}
+	for _, hash := range strings.Fields(output) {
+		set[hash] = true
+	}
+	return set, nil
+}
+
+// remoteOfRef returns the remote of a remote-tracking branch such as origin/main,
+// preferring the longest remote name since remote names may contain slashes
+func remoteOfRef(ref string, remotes []string) string {
+	best := ""
+	for _, r := range remotes {
+		if strings.HasPrefix(ref, r+"/") && len(r) > len(best) {
+			best = r
+		}
+	}
+	if best == "" {
+		best, _, _ = strings.Cut(ref, "/")
+	}
+	return best
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FetchRepository fetches a remote of the repository at path without selecting it
+// An empty remote fetches every remote
+func (g *GitService) FetchRepository(path, remote string) error {
+	if path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	if strings.HasPrefix(remote, "-") {
+		return fmt.Errorf("invalid remote name: %s", remote)
+	}
+
+	args := []string{"fetch", "--all"}
+	if remote != "" {
+		args = []string{"fetch", remote}
+	}
+	_, err := g.runGitCommandIn(path, args...)
+	return err
+}
+
+// CleanupPartialClone removes what an interrupted clone left at path
+// A destination that now holds a working repository is left alone, since it
+// may have been completed or used since, and reported through the error
+func (g *GitService) CleanupPartialClone(path string, createdDir bool) error {
+	if path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read clone destination: %w", err)
+	}
+	if len(entries) == 0 {
+		if createdDir {
+			return os.Remove(path)
+		}
+		return nil
+	}
+
+	// Only touch directories git clone created
+	if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+		return fmt.Errorf("destination is not a partial clone: %s", path)
+	}
+	if _, err := g.runGitCommandIn(path, "rev-parse", "--verify", "HEAD^{commit}"); err == nil {
+		if out, err := g.runGitCommandIn(path, "status", "--porcelain"); err == nil && out == "" {
+			return fmt.Errorf("clone at %s is complete, nothing to clean", path)
+		}
+		return fmt.Errorf("clone at %s has a checkout with changes, left for manual review", path)
+	}
+
+	if createdDir {
+		return os.RemoveAll(path)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(path, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
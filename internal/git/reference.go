@@ -0,0 +1,50 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommitSummary returns the abbreviated hash, full hash and subject of a commit
+func (g *GitService) CommitSummary(ref string) (short, full, subject string, err error) {
+	if g.currentPath == "" {
+		return "", "", "", fmt.Errorf("no repository selected")
+	}
+
+	hash, err := g.ResolveCommit(ref)
+	if err != nil {
+		return "", "", "", err
+	}
+	output, err := g.runGitCommand("log", "-1", "--format=%h%x00%s", hash)
+	if err != nil {
+		return "", "", "", err
+	}
+	short, subject, _ = strings.Cut(output, "\x00")
+	return short, hash, subject, nil
+}
+
+// CommitAsPatch returns a single commit as mailbox patch text, as git format-patch writes it
+func (g *GitService) CommitAsPatch(ref string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	hash, err := g.ResolveCommit(ref)
+	if err != nil {
+		return "", err
+	}
+	return g.runGitCommandLimited("format-patch", "-1", "--stdout", hash)
+}
+
+// CurrentBranch returns the short name of the checked out branch, or "" when HEAD is detached
+func (g *GitService) CurrentBranch() (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	branch, err := g.runGitCommand("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return "", nil
+	}
+	return strings.TrimSpace(branch), nil
+}
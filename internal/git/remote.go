@@ -0,0 +1,98 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// scpURLPattern matches scp-like remotes such as git@github.com:owner/repo.git
+var scpURLPattern = regexp.MustCompile(`^([\w.\-]+@)?[\w.\-]+:[^/\\].*$`)
+
+// noPromptEnv keeps git from waiting for credentials nobody can type
+var noPromptEnv = []string{"GIT_TERMINAL_PROMPT=0"}
+
+// ValidateRemoteURL checks that a remote URL is a supported URL, scp-like address or existing path
+func ValidateRemoteURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return fmt.Errorf("remote URL cannot be empty")
+	}
+	if strings.HasPrefix(raw, "-") || strings.ContainsAny(raw, " \t\r\n") {
+		return fmt.Errorf("invalid remote URL: %s", raw)
+	}
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid remote URL: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "ssh", "git", "git+ssh":
+			if u.Host == "" {
+				return fmt.Errorf("remote URL has no host: %s", raw)
+			}
+		case "file":
+		default:
+			return fmt.Errorf("unsupported remote URL scheme: %s", u.Scheme)
+		}
+		return nil
+	}
+
+	// A local path is checked before the scp form so Windows drive letters are not taken for hosts
+	if _, err := os.Stat(raw); err == nil {
+		return nil
+	}
+	if scpURLPattern.MatchString(raw) {
+		return nil
+	}
+	return fmt.Errorf("remote URL is neither a URL, an scp-like address nor an existing path: %s", raw)
+}
+
+// AddRemoteVerified adds a remote and optionally checks it: the URL format, that it can be
+// reached, and fetching it. The remote is removed again if any check fails
+func (g *GitService) AddRemoteVerified(opts models.AddRemoteOptions) (*models.AddRemoteResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if opts.Validate {
+		if err := ValidateRemoteURL(opts.URL); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.AddRemote(opts.Name, opts.URL); err != nil {
+		return nil, err
+	}
+
+	result := &models.AddRemoteResult{Remote: models.Remote{Name: opts.Name, URL: opts.URL}, Branches: []string{}}
+	rollback := func(err error) (*models.AddRemoteResult, error) {
+		g.runGitCommand("remote", "remove", opts.Name)
+		return nil, err
+	}
+
+	if opts.TestConnection && !opts.Fetch {
+		if _, err := g.runGitCommandEnv(noPromptEnv, "ls-remote", "--heads", opts.Name); err != nil {
+			return rollback(fmt.Errorf("cannot reach remote %s: %w", opts.Name, err))
+		}
+	}
+	if opts.Fetch {
+		if _, err := g.runGitCommandEnv(noPromptEnv, "fetch", opts.Name); err != nil {
+			return rollback(fmt.Errorf("failed to fetch remote %s: %w", opts.Name, err))
+		}
+		output, err := g.runGitCommand("for-each-ref", "--format=%(refname)", "refs/remotes/"+opts.Name+"/")
+		if err != nil {
+			return rollback(err)
+		}
+		for _, ref := range strings.Split(output, "\n") {
+			branch := strings.TrimPrefix(ref, "refs/remotes/"+opts.Name+"/")
+			if ref != "" && branch != "HEAD" {
+				result.Branches = append(result.Branches, branch)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsBare reports whether the selected repository has no working tree
+func (g *GitService) IsBare() bool {
+	if g.currentPath == "" {
+		return false
+	}
+	out, err := g.runGitCommand("rev-parse", "--is-bare-repository")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// StashCount returns the number of entries in the stash
+func (g *GitService) StashCount() (int, error) {
+	if g.currentPath == "" {
+		return 0, fmt.Errorf("no repository selected")
+	}
+
+	out, err := g.runGitCommand("rev-list", "--walk-reflogs", "--count", "refs/stash")
+	if err != nil {
+		// refs/stash does not exist until something is stashed
+		return 0, nil
+	}
+	return strconv.Atoi(strings.TrimSpace(out))
+}
@@ -0,0 +1,354 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// CommitFixup commits the staged changes as a fixup of target, to be folded in by AutosquashRebase
+func (g *GitService) CommitFixup(target string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	hash, err := g.ResolveCommit(target)
+	if err != nil {
+		return err
+	}
+	if _, err := g.runGitCommand("diff", "--cached", "--quiet"); err == nil {
+		return fmt.Errorf("no staged changes to fix up %s with", shortHash(hash))
+	}
+
+	_, err = g.runGitCommand("commit", "--no-edit", "--fixup="+hash)
+	return err
+}
+
+// AutosquashRebase folds fixup! and squash! commits after base into their targets
+// The rebase runs without an editor; on conflicts it stops like any other rebase
+func (g *GitService) AutosquashRebase(base string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if strings.TrimSpace(base) == "" {
+		return fmt.Errorf("base commit cannot be empty")
+	}
+	baseHash, err := g.ResolveCommit(base)
+	if err != nil {
+		return err
+	}
+	if err := g.requireCleanTree(); err != nil {
+		return err
+	}
+	if err := g.checkRewrite(baseHash, "HEAD"); err != nil {
+		return err
+	}
+
+	env := []string{"GIT_SEQUENCE_EDITOR=true", "GIT_EDITOR=true"}
+	_, err = g.runGitCommandEnv(env, "rebase", "--interactive", "--autosquash", baseHash)
+	return err
+}
+
+// CommitPatch returns the full patch introduced by a commit
+func (g *GitService) CommitPatch(hash string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	resolved, err := g.ResolveCommit(hash)
+	if err != nil {
+		return "", err
+	}
+	return g.runGitCommandLimited("show", "--format=", "--patch", "--no-color", resolved)
+}
+
+// RewordCommit replaces the message of a commit
+// HEAD is amended in place; older commits are rewritten with a non-interactive rebase
+// Commits already on a remote must be acknowledged with AcknowledgeRewrite first
+func (g *GitService) RewordCommit(hash, message string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message cannot be empty")
+	}
+	target, err := g.ResolveCommit(hash)
+	if err != nil {
+		return err
+	}
+	base := g.parentOf(target)
+	if base == "--root" {
+		base = ""
+	}
+	if err := g.checkRewrite(base, "HEAD"); err != nil {
+		return err
+	}
+
+	head, err := g.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+	if target == head {
+		// --only without paths keeps staged changes out of the amended commit
+		_, err := g.runGitCommand("commit", "--amend", "--only", "--allow-empty", "-m", message)
+		return err
+	}
+
+	commits, err := g.commitsAfter(target)
+	if err != nil {
+		return err
+	}
+	todo := []string{"reword " + target}
+	for _, c := range commits {
+		todo = append(todo, "pick "+c)
+	}
+	return g.runScriptedRebase(g.parentOf(target), todo, message)
+}
+
+// ReorderCommits rewrites the commits after base in newOrder, oldest first
+// newOrder must contain exactly the commits between base and HEAD; commits already on a
+// remote must be acknowledged with AcknowledgeRewrite first
+func (g *GitService) ReorderCommits(base string, newOrder []string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	baseHash, err := g.ResolveCommit(base)
+	if err != nil {
+		return err
+	}
+	commits, err := g.commitsAfter(baseHash)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("no commits after %s to reorder", shortHash(baseHash))
+	}
+	if len(newOrder) != len(commits) {
+		return fmt.Errorf("new order has %d commits, expected the %d commits after %s", len(newOrder), len(commits), shortHash(baseHash))
+	}
+
+	inRange := make(map[string]bool, len(commits))
+	for _, c := range commits {
+		inRange[c] = true
+	}
+	todo := make([]string, 0, len(newOrder))
+	seen := make(map[string]bool, len(newOrder))
+	for _, ref := range newOrder {
+		hash, err := g.ResolveCommit(ref)
+		if err != nil {
+			return err
+		}
+		if !inRange[hash] {
+			return fmt.Errorf("commit %s is not between %s and HEAD", shortHash(hash), shortHash(baseHash))
+		}
+		if seen[hash] {
+			return fmt.Errorf("commit %s appears more than once", shortHash(hash))
+		}
+		seen[hash] = true
+		todo = append(todo, "pick "+hash)
+	}
+
+	if err := g.checkRewrite(baseHash, "HEAD"); err != nil {
+		return err
+	}
+	return g.runScriptedRebase(baseHash, todo, "")
+}
+
+// commitsAfter lists the commits from hash (exclusive) to HEAD, oldest first
+// It fails when the range contains merges, which a linear rebase would flatten
+func (g *GitService) commitsAfter(hash string) ([]string, error) {
+	if merges, err := g.runGitCommand("rev-list", "--merges", hash+"..HEAD"); err != nil {
+		return nil, err
+	} else if merges != "" {
+		return nil, fmt.Errorf("cannot rewrite history containing merge commits")
+	}
+	if _, err := g.runGitCommand("merge-base", "--is-ancestor", hash, "HEAD"); err != nil {
+		return nil, fmt.Errorf("commit %s is not on the current branch", shortHash(hash))
+	}
+
+	output, err := g.runGitCommand("rev-list", "--reverse", hash+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// parentOf returns the rebase base for rewriting hash: its parent, or --root for a root commit
+func (g *GitService) parentOf(hash string) string {
+	parent, err := g.runGitCommand("rev-parse", "--verify", "--quiet", hash+"^")
+	if err != nil || parent == "" {
+		return "--root"
+	}
+	return strings.TrimSpace(parent)
+}
+
+// requireUnpushed fails when hash is reachable from a remote-tracking branch
+func (g *GitService) requireUnpushed(hash string) error {
+	output, err := g.runGitCommand("branch", "--remotes", "--contains", hash)
+	if err != nil {
+		return err
+	}
+	if remotes := strings.Fields(output); len(remotes) > 0 {
+		return fmt.Errorf("commit %s has already been pushed to %s", shortHash(hash), remotes[0])
+	}
+	return nil
+}
+
+// runScriptedRebase runs an interactive rebase onto base with a prepared todo list
+// message, when set, is used for every reword step
+func (g *GitService) runScriptedRebase(base string, todo []string, message string) error {
+	if err := g.requireCleanTree(); err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "gitai-rebase-*")
+	if err != nil {
+		return fmt.Errorf("failed to prepare rebase: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	todoFile := filepath.Join(dir, "todo")
+	if err := os.WriteFile(todoFile, []byte(strings.Join(todo, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to prepare rebase: %w", err)
+	}
+	// Both editors run through git's shell, which provides cp on every platform
+	env := []string{"GIT_SEQUENCE_EDITOR=cp " + shellQuote(todoFile), "GIT_EDITOR=true"}
+	if message != "" {
+		messageFile := filepath.Join(dir, "message")
+		if err := os.WriteFile(messageFile, []byte(message+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to prepare rebase: %w", err)
+		}
+		env[1] = "GIT_EDITOR=cp " + shellQuote(messageFile)
+	}
+
+	_, err = g.runGitCommandEnv(env, "-c", "commit.cleanup=whitespace", "rebase", "--interactive", base)
+	return err
+}
+
+// shellQuote quotes a path for git's POSIX shell
+func shellQuote(path string) string {
+	return "'" + strings.ReplaceAll(filepath.ToSlash(path), "'", `'\''`) + "'"
+}
+
+// requireCleanTree fails when tracked files have uncommitted changes, which would block a rebase
+func (g *GitService) requireCleanTree() error {
+	dirty, err := g.DirtyPaths()
+	if err != nil {
+		return err
+	}
+	if len(dirty) > 0 {
+		return fmt.Errorf("commit or stash your changes first: %d file(s) have uncommitted changes", len(dirty))
+	}
+	return nil
+}
+
+// PreviewRebaseOnto lists the commits `git rebase --onto newBase oldBase branch` would
+// transplant, oldest first, without changing anything
+// branch defaults to the current branch
+func (g *GitService) PreviewRebaseOnto(newBase, oldBase, branch string) (*models.RebaseOntoPreview, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	newHash, oldHash, branch, err := g.resolveRebaseOnto(newBase, oldBase, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &models.RebaseOntoPreview{
+		NewBase: newBase,
+		OldBase: oldBase,
+		Branch:  branch,
+		Commits: []models.CommitInfo{},
+		Skipped: []string{},
+	}
+	output, err := g.runGitCommand("log", "--reverse", "--no-merges", "--pretty=format:"+logFormat, "--date=iso", oldHash+".."+branch)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if commit, ok := parseLogLine(line); ok {
+			preview.Commits = append(preview.Commits, commit)
+		}
+	}
+
+	if merges, err := g.runGitCommand("rev-list", "--count", "--merges", oldHash+".."+branch); err == nil {
+		preview.DroppedMerges, _ = strconv.Atoi(strings.TrimSpace(merges))
+	}
+	// git cherry marks commits whose change newBase already has with "-", rebase skips them
+	if cherry, err := g.runGitCommand("cherry", newHash, branch, oldHash); err == nil {
+		for _, line := range strings.Split(cherry, "\n") {
+			if hash, ok := strings.CutPrefix(line, "- "); ok {
+				preview.Skipped = append(preview.Skipped, hash)
+			}
+		}
+	}
+	if len(preview.Commits) > 0 {
+		preview.Pushed = g.requireUnpushed(preview.Commits[0].Hash) != nil
+	}
+	return preview, nil
+}
+
+// RebaseOnto moves the commits of branch after oldBase onto newBase, for a branch that was
+// cut from the wrong base; branch defaults to the current branch and is checked out
+func (g *GitService) RebaseOnto(newBase, oldBase, branch string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+	newHash, oldHash, branch, err := g.resolveRebaseOnto(newBase, oldBase, branch)
+	if err != nil {
+		return err
+	}
+	if err := g.requireCleanTree(); err != nil {
+		return err
+	}
+	if err := g.checkRewrite(oldHash, "refs/heads/"+branch); err != nil {
+		return err
+	}
+
+	_, err = g.runGitCommandEnv([]string{"GIT_EDITOR=true"}, "rebase", "--onto", newHash, oldHash, branch)
+	return err
+}
+
+// resolveRebaseOnto validates the arguments of a rebase --onto, returning the new and old
+// base commits and the branch name
+func (g *GitService) resolveRebaseOnto(newBase, oldBase, branch string) (string, string, string, error) {
+	if newBase == "" || oldBase == "" {
+		return "", "", "", fmt.Errorf("new base and old base are required")
+	}
+	if branch == "" {
+		current, err := g.CurrentBranch()
+		if err != nil {
+			return "", "", "", err
+		}
+		if current == "" {
+			return "", "", "", fmt.Errorf("HEAD is not on a branch")
+		}
+		branch = current
+	}
+	if _, err := g.runGitCommand("show-ref", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+		return "", "", "", fmt.Errorf("branch not found: %s", branch)
+	}
+
+	newHash, err := g.ResolveCommit(newBase)
+	if err != nil {
+		return "", "", "", err
+	}
+	oldHash, err := g.ResolveCommit(oldBase)
+	if err != nil {
+		return "", "", "", err
+	}
+	if _, err := g.runGitCommand("merge-base", "--is-ancestor", oldHash, branch); err != nil {
+		return "", "", "", fmt.Errorf("%s is not an ancestor of %s", oldBase, branch)
+	}
+	return newHash, oldHash, branch, nil
+}
@@ -0,0 +1,199 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// Separators used in --format so commit headers can be split from the patches that follow
+const (
+	recordSeparator = "\x1e"
+	fieldSeparator  = "\x1f"
+)
+
+// patchLogFormat prints a header record before each commit's patch
+const patchLogFormat = "--format=" + recordSeparator + "%H" + fieldSeparator + "%an" + fieldSeparator + "%ae" + fieldSeparator + "%ad" + fieldSeparator + "%s"
+
+// WhenWasLineChanged returns the commits that touched a range of lines, newest first,
+// using git log -L which follows the lines through edits and renames
+func (g *GitService) WhenWasLineChanged(filePath string, lines models.LineRange, limit int) ([]models.CommitPatch, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	filePath, err := g.repoPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if lines.Start < 1 || lines.End < lines.Start {
+		return nil, fmt.Errorf("invalid line range %d-%d", lines.Start, lines.End)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	output, err := g.runGitCommand("log", fmt.Sprintf("-%d", limit), "--date=iso", patchLogFormat,
+		fmt.Sprintf("-L%d,%d:%s", lines.Start, lines.End, filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePatchLog(output), nil
+}
+
+// parsePatchLog splits the output of a log using patchLogFormat into commits
+func parsePatchLog(output string) []models.CommitPatch {
+	commits := []models.CommitPatch{}
+	for _, record := range strings.Split(output, recordSeparator) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		header, patch, _ := strings.Cut(record, "\n")
+		fields := strings.SplitN(header, fieldSeparator, 5)
+		if len(fields) < 5 {
+			continue
+		}
+
+		commits = append(commits, models.CommitPatch{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Email:   fields[2],
+			Date:    fields[3],
+			Message: fields[4],
+			Patch:   strings.Trim(patch, "\n"),
+		})
+	}
+	return commits
+}
+
+// FindCommitsTouchingString runs a pickaxe search: commits that add or remove text (-S),
+// or whose changed lines match a regular expression (-G), optionally limited to a path.
+// Each commit carries only the hunks that contain a match
+func (g *GitService) FindCommitsTouchingString(text string, regex bool, filePath string, limit int) ([]models.CommitPatch, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if text == "" {
+		return nil, fmt.Errorf("search text cannot be empty")
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var matcher func(string) bool
+	args := []string{"log", fmt.Sprintf("-%d", limit), "--date=iso", patchLogFormat, "-p", "--no-color"}
+	if regex {
+		re, err := regexp.Compile(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		matcher = re.MatchString
+		args = append(args, "-G"+text)
+	} else {
+		matcher = func(line string) bool { return strings.Contains(line, text) }
+		args = append(args, "-S"+text)
+	}
+	if filePath != "" {
+		path, err := g.repoPath(filePath)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--", path)
+	} else {
+		args = append(args, g.scopeArgs()...)
+	}
+
+	output, err := g.runGitCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := parsePatchLog(output)
+	for i := range commits {
+		commits[i].Patch = filterHunks(commits[i].Patch, matcher)
+	}
+	return commits, nil
+}
+
+// filterHunks keeps the file headers and the hunks whose added or removed lines match
+func filterHunks(patch string, match func(string) bool) string {
+	var out []string
+	var fileHeader []string
+	var hunk []string
+	hunkMatches := false
+	headerWritten := false
+
+	flushHunk := func() {
+		if len(hunk) > 0 && hunkMatches {
+			if !headerWritten {
+				out = append(out, fileHeader...)
+				headerWritten = true
+			}
+			out = append(out, hunk...)
+		}
+		hunk = nil
+		hunkMatches = false
+	}
+
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flushHunk()
+			fileHeader = []string{line}
+			headerWritten = false
+		case strings.HasPrefix(line, "@@"):
+			flushHunk()
+			hunk = []string{line}
+		case hunk != nil:
+			hunk = append(hunk, line)
+			if (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")) && match(line[1:]) {
+				hunkMatches = true
+			}
+		default:
+			fileHeader = append(fileHeader, line)
+		}
+	}
+	flushHunk()
+
+	return strings.Join(out, "\n")
+}
+
+// GetCommitMessages returns the full messages of the most recent non-merge commits,
+// newest first; Message holds the subject and body
+func (g *GitService) GetCommitMessages(limit int) ([]models.CommitInfo, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	if !g.HasCommits() {
+		return []models.CommitInfo{}, nil
+	}
+
+	output, err := g.runGitCommand("log", fmt.Sprintf("-%d", limit), "--no-merges", "--date=iso",
+		"--format="+recordSeparator+"%H"+fieldSeparator+"%an"+fieldSeparator+"%ae"+fieldSeparator+"%ad"+fieldSeparator+"%B")
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []models.CommitInfo{}
+	for _, record := range strings.Split(output, recordSeparator) {
+		fields := strings.SplitN(record, fieldSeparator, 5)
+		if len(fields) < 5 {
+			continue
+		}
+		commits = append(commits, models.CommitInfo{
+			Hash:      fields[0],
+			ShortHash: shortHash(fields[0]),
+			Author:    fields[1],
+			Email:     fields[2],
+			Date:      fields[3],
+			Message:   strings.TrimSpace(fields[4]),
+		})
+	}
+	return commits, nil
+}
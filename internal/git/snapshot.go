@@ -0,0 +1,256 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/models"
+)
+
+const (
+	// snapshotRefPrefix is the namespace holding snapshot commits, outside branches and tags
+	snapshotRefPrefix = "refs/gitai/snapshots/"
+	// autoSnapshotPrefix marks the subject of snapshots taken before risky operations
+	autoSnapshotPrefix = "[auto] "
+	// maxAutoSnapshots is the number of automatic snapshots kept
+	maxAutoSnapshots = 50
+)
+
+// ErrNothingToSnapshot is returned when the working tree and index match HEAD
+var ErrNothingToSnapshot = errors.New("no changes to snapshot")
+
+// snapshotIdentity keeps snapshot commits working when no git identity is configured
+var snapshotIdentity = []string{
+	"GIT_AUTHOR_NAME=Git AI Tools",
+	"GIT_AUTHOR_EMAIL=snapshot@git-ai-tools",
+	"GIT_COMMITTER_NAME=Git AI Tools",
+	"GIT_COMMITTER_EMAIL=snapshot@git-ai-tools",
+}
+
+// CreateSnapshot records the working tree, untracked files included, and the index as a checkpoint
+// Neither the index nor the working tree is modified. The snapshot commit holds the working
+// tree, its parents are HEAD and a commit holding the index
+func (g *GitService) CreateSnapshot(message string, automatic bool) (*models.Snapshot, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	gitDir, err := g.gitDir()
+	if err != nil {
+		return nil, err
+	}
+
+	indexTree, err := g.runGitCommand("write-tree")
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage everything into a copy of the index so the real one stays untouched
+	// Each snapshot gets its own copy so concurrent checkpoints cannot share one
+	tmp, err := os.CreateTemp(gitDir, "gitai-snapshot-index-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	tmp.Close()
+	tmpIndex := tmp.Name()
+	defer os.Remove(tmpIndex)
+	if _, err := os.Stat(filepath.Join(gitDir, "index")); err == nil {
+		if err := fsutil.CopyFileAtomic(filepath.Join(gitDir, "index"), tmpIndex); err != nil {
+			return nil, fmt.Errorf("failed to copy index: %w", err)
+		}
+	} else {
+		// git reads an empty file as a corrupt index, a missing one as an empty index
+		os.Remove(tmpIndex)
+	}
+	env := []string{"GIT_INDEX_FILE=" + tmpIndex}
+	if _, err := g.runGitCommandEnv(env, "add", "-A"); err != nil {
+		return nil, err
+	}
+	workTree, err := g.runGitCommandEnv(env, "write-tree")
+	if err != nil {
+		return nil, err
+	}
+
+	var head string
+	if g.HasCommits() {
+		head, _ = g.runGitCommand("rev-parse", "HEAD")
+	}
+	headTree := ""
+	if head != "" {
+		headTree, _ = g.runGitCommand("rev-parse", "HEAD^{tree}")
+	}
+	if workTree == headTree && indexTree == headTree {
+		return nil, ErrNothingToSnapshot
+	}
+
+	subject := strings.TrimSpace(message)
+	if subject == "" {
+		subject = "snapshot"
+	}
+	if automatic {
+		subject = autoSnapshotPrefix + subject
+	}
+
+	parentArgs := []string{}
+	if head != "" {
+		parentArgs = append(parentArgs, "-p", head)
+	}
+	indexCommit, err := g.runGitCommandEnv(snapshotIdentity, append([]string{"commit-tree", indexTree, "-m", "index"}, parentArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := g.runGitCommandEnv(snapshotIdentity,
+		append(append([]string{"commit-tree", workTree, "-m", subject}, parentArgs...), "-p", indexCommit)...)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if _, err := g.runGitCommand("update-ref", snapshotRefPrefix+id, snapshot); err != nil {
+		return nil, err
+	}
+
+	if automatic {
+		g.pruneSnapshots()
+	}
+
+	return &models.Snapshot{
+		ID:        id,
+		Hash:      snapshot,
+		ShortHash: shortHash(snapshot),
+		Message:   strings.TrimPrefix(subject, autoSnapshotPrefix),
+		Automatic: automatic,
+		Head:      head,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// ListSnapshots returns the snapshots of the repository, newest first
+// Snapshot IDs are creation times in nanoseconds, so sorting by ref name sorts by age
+func (g *GitService) ListSnapshots() ([]models.Snapshot, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	output, err := g.runGitCommand("for-each-ref", "--sort=-refname",
+		"--format=%(refname)|%(objectname)|%(creatordate:iso-strict)|%(parent)|%(subject)", snapshotRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []models.Snapshot{}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "|", 5)
+		if len(parts) < 5 {
+			continue
+		}
+		// Without HEAD the only parent is the index commit
+		head := ""
+		if parents := strings.Fields(parts[3]); len(parents) == 2 {
+			head = parents[0]
+		}
+		snapshots = append(snapshots, models.Snapshot{
+			ID:        strings.TrimPrefix(parts[0], snapshotRefPrefix),
+			Hash:      parts[1],
+			ShortHash: shortHash(parts[1]),
+			Message:   strings.TrimPrefix(parts[4], autoSnapshotPrefix),
+			Automatic: strings.HasPrefix(parts[4], autoSnapshotPrefix),
+			Head:      head,
+			CreatedAt: parts[2],
+		})
+	}
+	return snapshots, nil
+}
+
+// GetSnapshotDiff returns the diff between a snapshot and the working tree
+func (g *GitService) GetSnapshotDiff(id string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	hash, err := g.snapshotCommit(id)
+	if err != nil {
+		return "", err
+	}
+	return g.runGitCommand("diff", "-M", hash)
+}
+
+// RestoreSnapshot puts the files and the index of a snapshot back
+// The current state is snapshotted first so the restore can be undone. Files
+// created after the snapshot are left in place
+func (g *GitService) RestoreSnapshot(id string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	hash, err := g.snapshotCommit(id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.CreateSnapshot("before restoring snapshot "+id, true); err != nil && !errors.Is(err, ErrNothingToSnapshot) {
+		return err
+	}
+
+	if _, err := g.runGitCommand("checkout", hash, "--", "."); err != nil {
+		return err
+	}
+
+	// The last parent holds the index as it was when the snapshot was taken
+	indexCommit, err := g.runGitCommand("rev-parse", hash+"^@")
+	if err != nil {
+		return err
+	}
+	parents := strings.Split(indexCommit, "\n")
+	_, err = g.runGitCommand("read-tree", parents[len(parents)-1]+"^{tree}")
+	return err
+}
+
+// DeleteSnapshot removes a snapshot
+func (g *GitService) DeleteSnapshot(id string) error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	if _, err := g.snapshotCommit(id); err != nil {
+		return err
+	}
+	_, err := g.runGitCommand("update-ref", "-d", snapshotRefPrefix+id)
+	return err
+}
+
+// snapshotCommit resolves a snapshot ID to its commit hash
+func (g *GitService) snapshotCommit(id string) (string, error) {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid snapshot id: %s", id)
+	}
+	hash, err := g.runGitCommand("rev-parse", "--verify", "-q", snapshotRefPrefix+id)
+	if err != nil {
+		return "", fmt.Errorf("snapshot not found: %s", id)
+	}
+	return hash, nil
+}
+
+// pruneSnapshots drops automatic snapshots beyond maxAutoSnapshots, manual ones are kept
+func (g *GitService) pruneSnapshots() {
+	snapshots, err := g.ListSnapshots()
+	if err != nil {
+		return
+	}
+	kept := 0
+	for _, s := range snapshots {
+		if !s.Automatic {
+			continue
+		}
+		kept++
+		if kept > maxAutoSnapshots {
+			g.runGitCommand("update-ref", "-d", snapshotRefPrefix+s.ID)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/models"
+)
+
+// maxFuzz is the number of context lines that may be dropped from each end of a hunk
+const maxFuzz = 2
+
+// suggestedHunk is a hunk of a suggested patch; line counts in its header are ignored
+// because generated patches often get them wrong
+type suggestedHunk struct {
+	start    int
+	old, new []string
+	// lead and trail count the context lines at each end, the only lines fuzz may drop
+	lead, trail int
+}
+
+// ApplySuggestedPatch applies a unified diff for a single file, such as one proposed by AI,
+// tolerating moved lines, wrong hunk counts and whitespace differences
+// Every hunk must match before the file is written, and the working tree is snapshotted first
+func (g *GitService) ApplySuggestedPatch(filePath string, unifiedDiff string) (*models.SuggestedPatchResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	filePath, err := g.repoPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	hunks, err := parseSuggestedPatch(filePath, unifiedDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(g.currentPath, filepath.FromSlash(filePath))
+	content, err := os.ReadFile(target)
+	created := os.IsNotExist(err)
+	if err != nil && !created {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	eol := "\n"
+	if strings.Contains(string(content), "\r\n") {
+		eol = "\r\n"
+	}
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	finalNewline := text == "" || strings.HasSuffix(text, "\n")
+	lines := []string{}
+	if text != "" {
+		lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	}
+
+	result := &models.SuggestedPatchResult{FilePath: filePath, Created: created, Hunks: []models.AppliedHunk{}}
+	shift := 0
+	for i, h := range hunks {
+		applied, next, ok := applyHunk(lines, h, &shift)
+		if !ok {
+			return nil, fmt.Errorf("hunk %d does not match %s", i+1, filePath)
+		}
+		applied.Index = i + 1
+		lines = next
+		result.Hunks = append(result.Hunks, applied)
+	}
+
+	out := strings.Join(lines, eol)
+	if finalNewline && len(lines) > 0 {
+		out += eol
+	}
+
+	snapshot, err := g.CreateSnapshot("before applying suggestion to "+filePath, true)
+	switch {
+	case err == nil:
+		result.SnapshotID = snapshot.ID
+	case !errors.Is(err, ErrNothingToSnapshot):
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(target); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := fsutil.WriteFileAtomic(target, []byte(out), perm); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return result, nil
+}
+
+// parseSuggestedPatch reads the hunks of a single-file patch, checking that any file headers
+// name filePath. Blank lines inside a hunk are read as empty context lines
+func parseSuggestedPatch(filePath, diff string) ([]suggestedHunk, error) {
+	var hunks []suggestedHunk
+	var hunk *suggestedHunk
+	sawChange := false
+
+	checkHeader := func(line, prefix, side string) error {
+		p := unquoteDiffPath(diffHeaderPath(line, prefix))
+		if p == "/dev/null" {
+			return nil
+		}
+		if p = strings.TrimPrefix(p, side); p != filePath {
+			return fmt.Errorf("patch is for %s, not %s", p, filePath)
+		}
+		return nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			if hunk != nil {
+				hunks = append(hunks, *hunk)
+			}
+			hunk = &suggestedHunk{}
+			if m := hunkHeader.FindStringSubmatch(line); m != nil {
+				hunk.start, _ = strconv.Atoi(m[1])
+			}
+			sawChange = false
+		case hunk == nil:
+			// File headers before the first hunk
+			if strings.HasPrefix(line, "--- ") {
+				if err := checkHeader(line, "--- ", "a/"); err != nil {
+					return nil, err
+				}
+			}
+			if strings.HasPrefix(line, "+++ ") {
+				if err := checkHeader(line, "+++ ", "b/"); err != nil {
+					return nil, err
+				}
+			}
+		case strings.HasPrefix(line, "diff --git "):
+			return nil, fmt.Errorf("patch changes more than one file")
+		case strings.HasPrefix(line, "+"):
+			hunk.new = append(hunk.new, line[1:])
+			sawChange = true
+			hunk.trail = 0
+		case strings.HasPrefix(line, "-"):
+			hunk.old = append(hunk.old, line[1:])
+			sawChange = true
+			hunk.trail = 0
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			context := strings.TrimPrefix(line, " ")
+			hunk.old = append(hunk.old, context)
+			hunk.new = append(hunk.new, context)
+			if sawChange {
+				hunk.trail++
+			} else {
+				hunk.lead++
+			}
+		}
+	}
+	if hunk != nil {
+		hunks = append(hunks, *hunk)
+	}
+
+	// A trailing newline of the patch text reads as one blank context line too many
+	for i := range hunks {
+		h := &hunks[i]
+		for h.trail > 0 && h.old[len(h.old)-1] == "" && h.new[len(h.new)-1] == "" {
+			h.old, h.new = h.old[:len(h.old)-1], h.new[:len(h.new)-1]
+			h.trail--
+		}
+	}
+
+	changed := 0
+	for _, h := range hunks {
+		if len(h.old) != h.lead+h.trail || len(h.new) != h.lead+h.trail {
+			changed++
+		}
+	}
+	if changed == 0 {
+		return nil, fmt.Errorf("patch contains no changes")
+	}
+	return hunks, nil
+}
+
+// applyHunk finds where h applies in lines, preferring exact matches nearest to the hunk's
+// position, then whitespace-insensitive ones, then dropping context lines
+// shift carries how far earlier hunks moved the file; the lines after applying h are returned
+func applyHunk(lines []string, h suggestedHunk, shift *int) (models.AppliedHunk, []string, bool) {
+	for fuzz := 0; fuzz <= maxFuzz; fuzz++ {
+		lead, trail := min(fuzz, h.lead), min(fuzz, h.trail)
+		if fuzz > 0 && lead == 0 && trail == 0 {
+			break
+		}
+		old := h.old[lead : len(h.old)-trail]
+		replacement := h.new[lead : len(h.new)-trail]
+
+		// The header's start refers to the original file, 0 for a file being created
+		expected := max(h.start-1, 0) + lead
+		hint := expected + *shift
+		for _, loose := range []bool{false, true} {
+			at := findLines(lines, old, hint, loose)
+			if at < 0 {
+				continue
+			}
+
+			// Context lines keep the file's own text when matched loosely
+			next := make([]string, 0, len(lines)-len(old)+len(replacement))
+			next = append(next, lines[:at]...)
+			next = append(next, keepContext(lines[at:at+len(old)], old, replacement)...)
+			next = append(next, lines[at+len(old):]...)
+
+			*shift = at - expected + len(replacement) - len(old)
+			return models.AppliedHunk{
+				Line:                  at + 1,
+				Offset:                at - hint,
+				Fuzz:                  fuzz,
+				WhitespaceInsensitive: loose,
+			}, next, true
+		}
+	}
+	return models.AppliedHunk{}, nil, false
+}
+
+// findLines returns the start of the match of want in lines closest to hint, or -1
+func findLines(lines, want []string, hint int, loose bool) int {
+	if len(want) == 0 {
+		return max(0, min(hint, len(lines)))
+	}
+	best := -1
+	for at := 0; at+len(want) <= len(lines); at++ {
+		match := true
+		for i, w := range want {
+			if !sameLine(lines[at+i], w, loose) {
+				match = false
+				break
+			}
+		}
+		if match && (best < 0 || abs(at-hint) < abs(best-hint)) {
+			best = at
+		}
+	}
+	return best
+}
+
+// keepContext builds the replacement of matched, using the file's version of context lines
+// that appear unchanged at the same place at the start and end of the hunk
+func keepContext(matched, old, replacement []string) []string {
+	out := append([]string{}, replacement...)
+	for i := 0; i < len(old) && i < len(out) && old[i] == out[i]; i++ {
+		out[i] = matched[i]
+	}
+	for i := 1; i <= len(old) && i <= len(out) && old[len(old)-i] == out[len(out)-i]; i++ {
+		out[len(out)-i] = matched[len(matched)-i]
+	}
+	return out
+}
+
+// sameLine compares lines exactly, or ignoring whitespace differences when loose
+func sameLine(a, b string, loose bool) bool {
+	if a == b {
+		return true
+	}
+	return loose && strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// CheckoutStrategy decides what happens to uncommitted changes when switching branches
+type CheckoutStrategy string
+
+const (
+	// CheckoutBlock refuses to switch while there are uncommitted changes
+	CheckoutBlock CheckoutStrategy = "block"
+	// CheckoutStash stashes the changes, switches and reapplies them
+	CheckoutStash CheckoutStrategy = "stash"
+	// CheckoutDiscard throws the changes away before switching
+	CheckoutDiscard CheckoutStrategy = "discard"
+	// CheckoutCarry takes the changes along, merging them into the target branch
+	CheckoutCarry CheckoutStrategy = "carry"
+)
+
+// DirtyPaths returns the tracked files with uncommitted changes, staged or not
+func (g *GitService) DirtyPaths() ([]string, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	output, err := g.runGitCommandRaw("status", "--porcelain", "-z", "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		paths = append(paths, entry[3:])
+		// Renames and copies are followed by their source path
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
+		}
+	}
+	return paths, nil
+}
+
+// CheckoutBranchWithStrategy switches to branch, handling uncommitted changes as the strategy says
+func (g *GitService) CheckoutBranchWithStrategy(branch string, strategy CheckoutStrategy) (*models.CheckoutResult, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	if branch == "" {
+		return nil, fmt.Errorf("branch name cannot be empty")
+	}
+	if strings.HasPrefix(branch, "-") {
+		return nil, fmt.Errorf("invalid branch name: %s", branch)
+	}
+
+	dirty, err := g.DirtyPaths()
+	if err != nil {
+		return nil, err
+	}
+	result := &models.CheckoutResult{Branch: branch, Strategy: string(strategy), Changes: dirty}
+
+	if len(dirty) == 0 {
+		if _, err := g.runGitCommand("checkout", branch); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	switch strategy {
+	case CheckoutBlock, "":
+		return nil, fmt.Errorf("cannot switch to %s: %d file(s) have uncommitted changes", branch, len(dirty))
+
+	case CheckoutStash:
+		message := "gitai: switching to " + branch
+		if _, err := g.runGitCommand("stash", "push", "--include-untracked", "-m", message); err != nil {
+			return nil, fmt.Errorf("failed to stash changes: %w", err)
+		}
+		result.Stashed = true
+
+		if _, err := g.runGitCommand("checkout", branch); err != nil {
+			// Put the changes back where they were
+			if _, popErr := g.runGitCommand("stash", "pop", "--index"); popErr != nil {
+				return nil, fmt.Errorf("checkout failed: %w; changes remain in the stash: %v", err, popErr)
+			}
+			return nil, err
+		}
+
+		if _, err := g.runGitCommand("stash", "pop"); err != nil {
+			// A conflicting pop keeps the stash entry so nothing is lost
+			result.Conflicts = g.conflictedPaths()
+			if len(result.Conflicts) == 0 {
+				return nil, fmt.Errorf("switched to %s but failed to reapply changes, they remain in the stash: %w", branch, err)
+			}
+			return result, nil
+		}
+		result.Reapplied = true
+		return result, nil
+
+	case CheckoutDiscard:
+		if err := g.CheckProtected(dirty); err != nil {
+			return nil, err
+		}
+		if _, err := g.runGitCommand("checkout", "--force", branch); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	case CheckoutCarry:
+		if _, err := g.runGitCommand("checkout", "--merge", branch); err != nil {
+			return nil, err
+		}
+		result.Reapplied = true
+		result.Conflicts = g.conflictedPaths()
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unknown checkout strategy: %s", strategy)
+}
+
+// conflictedPaths returns the files left with unresolved conflicts
+func (g *GitService) conflictedPaths() []string {
+	output, err := g.runGitCommand("diff", "--name-only", "--diff-filter=U")
+	if err != nil || output == "" {
+		return nil
+	}
+	return strings.Split(output, "\n")
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// testFilePattern matches the file names test runners of common languages pick up
+var testFilePattern = regexp.MustCompile(`(_test\.(go|py|rb|exs?)|^test_.+\.py|\.(test|spec)\.[cm]?[jt]sx?|Tests?\.(java|kt|cs|swift)|_spec\.rb)$`)
+
+// IsTestFile reports whether a repository path looks like a test file
+func IsTestFile(p string) bool {
+	return testFilePattern.MatchString(path.Base(p)) || strings.Contains("/"+p, "/__tests__/")
+}
+
+// FindRelatedTests maps each source path to the tracked test files that exercise it,
+// found by the naming conventions of common languages: foo_test.go beside foo.go,
+// foo.test.ts or __tests__/foo.ts, test_foo.py, src/test/.../FooTest.java and so on
+// Go test files of the same package are included, as they share its scope
+func (g *GitService) FindRelatedTests(paths []string) (map[string][]string, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	output, err := g.runGitCommandRaw("ls-files", "-z", "--cached")
+	if err != nil {
+		return nil, err
+	}
+	byBase := map[string][]string{}
+	byDir := map[string][]string{}
+	for _, f := range strings.Split(string(output), "\x00") {
+		if f == "" || !IsTestFile(f) {
+			continue
+		}
+		byBase[path.Base(f)] = append(byBase[path.Base(f)], f)
+		byDir[path.Dir(f)] = append(byDir[path.Dir(f)], f)
+	}
+
+	result := make(map[string][]string, len(paths))
+	for _, p := range paths {
+		p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+		seen := map[string]bool{}
+		var tests []string
+		add := func(f string) {
+			if !seen[f] && f != p {
+				seen[f] = true
+				tests = append(tests, f)
+			}
+		}
+
+		for _, name := range testFileNames(p) {
+			for _, f := range byBase[name] {
+				add(f)
+			}
+		}
+		if strings.HasSuffix(p, ".go") {
+			for _, f := range byDir[path.Dir(p)] {
+				if strings.HasSuffix(f, "_test.go") {
+					add(f)
+				}
+			}
+		}
+		sort.SliceStable(tests, func(i, j int) bool {
+			// Tests beside the source come first
+			return path.Dir(tests[i]) == path.Dir(p) && path.Dir(tests[j]) != path.Dir(p)
+		})
+		result[p] = tests
+	}
+	return result, nil
+}
+
+// testFileNames returns the conventional test file names for a source file
+func testFileNames(p string) []string {
+	base := path.Base(p)
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	if stem == "" {
+		return nil
+	}
+
+	switch ext {
+	case ".go":
+		return []string{stem + "_test.go"}
+	case ".py":
+		return []string{"test_" + stem + ".py", stem + "_test.py"}
+	case ".rb":
+		return []string{stem + "_spec.rb", stem + "_test.rb"}
+	case ".ex", ".exs":
+		return []string{stem + "_test.exs"}
+	case ".java", ".kt", ".cs", ".swift":
+		return []string{stem + "Test" + ext, stem + "Tests" + ext}
+	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs", ".vue":
+		// base itself matches files under __tests__
+		names := []string{base}
+		for _, e := range []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs"} {
+			names = append(names, stem+".test"+e, stem+".spec"+e)
+		}
+		return names
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// IssuePlaceholder in a trailer value is replaced by the issue linked to the branch
+const IssuePlaceholder = "{issue}"
+
+var (
+	// trailerKeyPattern matches the tokens git accepts as trailer keys
+	trailerKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+	// identityPattern matches "Name <email>" as used by Reviewed-by and similar trailers
+	identityPattern = regexp.MustCompile(`^[^<>]+ <[^<>\s@]+@[^<>\s]+>$`)
+	// issueRefPattern matches an issue reference: #12, group/project#12, PROJ-12 or a URL
+	issueRefPattern = regexp.MustCompile(`^([\w.-]+(/[\w.-]+)*#[0-9]+|#[0-9]+|[A-Z][A-Z0-9]+-[0-9]+|https?://\S+)$`)
+)
+
+// issueTrailers are the keys whose values must be issue references
+var issueTrailers = map[string]bool{"refs": true, "closes": true, "fixes": true, "resolves": true, "see-also": true}
+
+// ValidateTrailer checks that a trailer has a valid key and a single-line value suited to
+// it: "Name <email>" for keys ending in -by, comma-separated issue references for Refs,
+// Closes, Fixes and Resolves; the {issue} placeholder counts as an issue reference
+func ValidateTrailer(t models.CommitTrailer) error {
+	if !trailerKeyPattern.MatchString(t.Key) {
+		return fmt.Errorf("invalid trailer key: %q", t.Key)
+	}
+	value := strings.TrimSpace(t.Value)
+	if value == "" {
+		return fmt.Errorf("trailer %s has no value", t.Key)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("trailer %s must be a single line", t.Key)
+	}
+
+	key := strings.ToLower(t.Key)
+	switch {
+	case strings.HasSuffix(key, "-by"):
+		if !identityPattern.MatchString(value) {
+			return fmt.Errorf("trailer %s must look like \"Name <email>\": %s", t.Key, value)
+		}
+	case issueTrailers[key]:
+		for _, ref := range strings.Split(value, ",") {
+			ref = strings.TrimSpace(ref)
+			if ref != IssuePlaceholder && !issueRefPattern.MatchString(ref) {
+				return fmt.Errorf("trailer %s has an invalid issue reference: %s", t.Key, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// AppendTrailers adds the trailers to the end of message with git interpret-trailers, so
+// they join an existing trailer block and trailers the message already has are not repeated
+// {issue} is replaced by issue; trailers that need it are skipped when issue is empty
+func (g *GitService) AppendTrailers(message string, trailers []models.CommitTrailer, issue string) (string, error) {
+	if g.currentPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+
+	args := []string{"interpret-trailers", "--if-exists", "addIfDifferent"}
+	for _, t := range trailers {
+		if strings.Contains(t.Value, IssuePlaceholder) {
+			if issue == "" {
+				continue
+			}
+			t.Value = strings.ReplaceAll(t.Value, IssuePlaceholder, issue)
+		}
+		t.Value = strings.TrimSpace(t.Value)
+		if err := ValidateTrailer(t); err != nil {
+			return "", err
+		}
+		args = append(args, "--trailer", t.Key+": "+t.Value)
+	}
+	if len(args) == 3 {
+		return message, nil
+	}
+
+	output, err := g.runGitCommandInput([]byte(strings.TrimRight(message, "\n")+"\n"), args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(output, "\n"), nil
+}
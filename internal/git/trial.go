@@ -0,0 +1,189 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// trial tracks the patch being tried out, there is at most one per session
+type trial struct {
+	mu    sync.Mutex
+	state *models.TryState
+	// source is the commit holding the files as they were before the patch
+	source string
+	// restore lists touched paths to bring back from source, remove those the patch created
+	restore, remove []string
+}
+
+// TryChanges applies a patch to the working tree so it can be kept or reverted with RevertTry
+// The working tree is snapshotted first; the index is left alone
+func (g *GitService) TryChanges(patch string) (*models.TryState, error) {
+	if g.currentPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+
+	g.trial.mu.Lock()
+	defer g.trial.mu.Unlock()
+	if g.trial.state != nil {
+		return nil, fmt.Errorf("changes are already being tried in %s, keep or revert them first", g.trial.state.RepoPath)
+	}
+
+	if strings.TrimSpace(patch) == "" {
+		return nil, fmt.Errorf("patch cannot be empty")
+	}
+	if !strings.HasSuffix(patch, "\n") {
+		patch += "\n"
+	}
+
+	// AI and pasted patches often carry wrong hunk line counts, but recounting misreads
+	// patches without "diff --git" headers, so it is only the fallback
+	applyArgs := []string{"--whitespace=nowarn", "-"}
+	if _, err := g.runGitCommandInput([]byte(patch), append([]string{"apply", "--check"}, applyArgs...)...); err != nil {
+		applyArgs = append([]string{"--recount"}, applyArgs...)
+		if _, recountErr := g.runGitCommandInput([]byte(patch), append([]string{"apply", "--check"}, applyArgs...)...); recountErr != nil {
+			return nil, fmt.Errorf("patch does not apply: %w", err)
+		}
+	}
+	numstat, err := g.runGitCommandInput([]byte(patch), append([]string{"apply", "--numstat", "-z"}, applyArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	paths := parseNumstatPaths(numstat)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("patch contains no file changes")
+	}
+
+	state := &models.TryState{Active: true, RepoPath: g.currentPath, Files: []string{}, StartedAt: time.Now().Format(time.RFC3339)}
+	source := ""
+	snapshot, err := g.CreateSnapshot("before trying changes", true)
+	switch {
+	case err == nil:
+		state.SnapshotID = snapshot.ID
+		source = snapshot.Hash
+	case errors.Is(err, ErrNothingToSnapshot):
+		// Resolved now so a commit made before RevertTry does not become the source;
+		// without any commit there is nothing to restore from
+		source, _ = g.ResolveCommit("HEAD")
+	default:
+		return nil, err
+	}
+
+	var restore, remove []string
+	for _, p := range paths {
+		state.Files = append(state.Files, p)
+		if _, err := g.runGitCommand("cat-file", "-e", source+":"+p); source != "" && err == nil {
+			restore = append(restore, p)
+		} else {
+			remove = append(remove, p)
+		}
+	}
+
+	if _, err := g.runGitCommandInput([]byte(patch), append([]string{"apply"}, applyArgs...)...); err != nil {
+		return nil, err
+	}
+
+	g.trial.state = state
+	g.trial.source = source
+	g.trial.restore, g.trial.remove = restore, remove
+	result := *state
+	return &result, nil
+}
+
+// RevertTry puts the files touched by the tried patch back the way they were
+// Other edits made since are kept
+func (g *GitService) RevertTry() error {
+	if g.currentPath == "" {
+		return fmt.Errorf("no repository selected")
+	}
+
+	g.trial.mu.Lock()
+	defer g.trial.mu.Unlock()
+	state := g.trial.state
+	if state == nil {
+		return fmt.Errorf("no changes are being tried")
+	}
+	if state.RepoPath != g.currentPath {
+		return fmt.Errorf("changes are being tried in %s, open it to revert them", state.RepoPath)
+	}
+
+	if len(g.trial.restore) > 0 {
+		args := append([]string{"restore", "--source=" + g.trial.source, "--worktree", "--"}, g.trial.restore...)
+		if _, err := g.runGitCommand(args...); err != nil {
+			return err
+		}
+	}
+	for _, p := range g.trial.remove {
+		if err := os.Remove(filepath.Join(g.currentPath, filepath.FromSlash(p))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", p, err)
+		}
+	}
+
+	g.clearTry()
+	return nil
+}
+
+// KeepTry keeps the tried patch in the working tree and ends the trial
+func (g *GitService) KeepTry() error {
+	g.trial.mu.Lock()
+	defer g.trial.mu.Unlock()
+	if g.trial.state == nil {
+		return fmt.Errorf("no changes are being tried")
+	}
+	g.clearTry()
+	return nil
+}
+
+// GetTryState returns the patch being tried out, Active is false when there is none
+func (g *GitService) GetTryState() models.TryState {
+	g.trial.mu.Lock()
+	defer g.trial.mu.Unlock()
+	if g.trial.state == nil {
+		return models.TryState{Files: []string{}}
+	}
+	return *g.trial.state
+}
+
+// parseNumstatPaths returns every path in "git apply --numstat -z" output, both sides of renames
+// Each entry is "added\tdeleted\tpath\0", or "added\tdeleted\t\0old\0new\0" for a rename
+func parseNumstatPaths(output string) []string {
+	paths := []string{}
+	seen := map[string]bool{}
+	add := func(p string) {
+		if p != "" && !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		if parts[2] != "" {
+			add(parts[2])
+			continue
+		}
+		if i+2 < len(fields) {
+			add(fields[i+1])
+			add(fields[i+2])
+			i += 2
+		}
+	}
+	return paths
+}
+
+// clearTry forgets the trial; callers must hold trial.mu
+func (g *GitService) clearTry() {
+	g.trial.state = nil
+	g.trial.source = ""
+	g.trial.restore, g.trial.remove = nil, nil
+}
@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+const (
+	// fileName is the active log file, rotated copies get a numeric suffix
+	fileName = "app.log"
+	// maxFileSize is the size at which the active log file is rotated
+	maxFileSize = 5 * 1024 * 1024
+	// maxBackups is the number of rotated files kept
+	maxBackups = 3
+)
+
+var (
+	logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+	logDir string
+)
+
+// Init starts writing JSON log lines to rotating files under the config directory
+func Init() error {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+	logDir = filepath.Join(configDir, "git-ai-tools", "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	writer, err := newRotatingWriter(filepath.Join(logDir, fileName), maxFileSize, maxBackups)
+	if err != nil {
+		return err
+	}
+
+	logger = slog.New(slog.NewJSONHandler(writer, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	return nil
+}
+
+// Logger returns the application logger, it discards output until Init succeeds
+func Logger() *slog.Logger {
+	return logger
+}
+
+// Dir returns the directory holding the log files
+func Dir() string {
+	return logDir
+}
+
+// NewID returns a short random correlation ID
+func NewID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Recent returns the newest log entries at or above level, oldest first
+// An empty level returns entries of every level
+func Recent(level string, limit int) ([]models.LogEntry, error) {
+	if logDir == "" {
+		return []models.LogEntry{}, nil
+	}
+	if limit <= 0 {
+		limit = 200
+	}
+
+	minLevel := slog.LevelDebug
+	if level != "" {
+		if err := minLevel.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("unknown log level: %s", level)
+		}
+	}
+
+	// Read rotated files from oldest to newest so entries stay in order
+	var files []string
+	for i := maxBackups; i >= 1; i-- {
+		files = append(files, filepath.Join(logDir, fmt.Sprintf("%s.%d", fileName, i)))
+	}
+	files = append(files, filepath.Join(logDir, fileName))
+
+	entries := []models.LogEntry{}
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			entry, ok := parseLine(scanner.Bytes())
+			if !ok {
+				continue
+			}
+			var entryLevel slog.Level
+			if entryLevel.UnmarshalText([]byte(entry.Level)) != nil || entryLevel < minLevel {
+				continue
+			}
+			entries = append(entries, entry)
+			if len(entries) > limit {
+				entries = entries[1:]
+			}
+		}
+		f.Close()
+	}
+
+	return entries, nil
+}
+
+// parseLine decodes a JSON log line written by the slog handler
+func parseLine(line []byte) (models.LogEntry, bool) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return models.LogEntry{}, false
+	}
+
+	entry := models.LogEntry{}
+	entry.Time, _ = fields[slog.TimeKey].(string)
+	entry.Level, _ = fields[slog.LevelKey].(string)
+	entry.Message, _ = fields[slog.MessageKey].(string)
+	entry.ID, _ = fields["id"].(string)
+	delete(fields, slog.TimeKey)
+	delete(fields, slog.LevelKey)
+	delete(fields, slog.MessageKey)
+	delete(fields, "id")
+	entry.Fields = fields
+
+	if entry.Time != "" {
+		if t, err := time.Parse(time.RFC3339Nano, entry.Time); err == nil {
+			entry.Time = t.Format(time.RFC3339)
+		}
+	}
+	entry.Level = strings.ToUpper(entry.Level)
+	return entry, true
+}
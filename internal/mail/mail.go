@@ -0,0 +1,172 @@
+package mail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// Placeholders git format-patch leaves in a cover letter
+const (
+	coverSubjectPlaceholder = "*** SUBJECT HERE ***"
+	coverBlurbPlaceholder   = "*** BLURB HERE ***"
+)
+
+// FillCoverLetter replaces the placeholders of a cover letter patch
+func FillCoverLetter(patch *models.PatchFile, subject, body string) {
+	if subject != "" {
+		patch.Content = strings.Replace(patch.Content, coverSubjectPlaceholder, subject, 1)
+		patch.Subject = strings.Replace(patch.Subject, coverSubjectPlaceholder, subject, 1)
+	}
+	if body != "" {
+		patch.Content = strings.Replace(patch.Content, coverBlurbPlaceholder, body, 1)
+	}
+}
+
+// SendPatches mails a patch series through an SMTP server, one message per patch
+// The patches keep the threading headers git format-patch generated
+func SendPatches(config models.SMTPConfig, to, cc []string, patches []models.PatchFile) error {
+	if config.Host == "" || config.From == "" {
+		return fmt.Errorf("SMTP server is not configured")
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+
+	client, err := dial(config)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	recipients := append(append([]string{}, to...), cc...)
+	for _, patch := range patches {
+		msg := buildMessage(config.From, to, cc, patch.Content)
+		if err := send(client, config.From, recipients, msg); err != nil {
+			return fmt.Errorf("failed to send %s: %w", patch.Name, err)
+		}
+	}
+	return client.Quit()
+}
+
+// dial connects and authenticates, using implicit TLS when configured and STARTTLS when offered
+func dial(config models.SMTPConfig) (*smtp.Client, error) {
+	port := config.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(port))
+	tlsConfig := &tls.Config{ServerName: config.Host}
+
+	var client *smtp.Client
+	if config.UseTLS {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 30 * time.Second}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+		client, err = smtp.NewClient(conn, config.Host)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+	} else {
+		conn, err := net.DialTimeout("tcp", addr, 30*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+		client, err = smtp.NewClient(conn, config.Host)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+
+	if config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", config.Username, config.Password, config.Host)); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// send transmits one message
+func send(client *smtp.Client, from string, recipients []string, msg []byte) error {
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// buildMessage turns a mailbox patch into a message sent by from
+// Like git send-email, a patch by another author keeps its author as a From line in the body
+func buildMessage(from string, to, cc []string, patch string) []byte {
+	lines := strings.Split(strings.ReplaceAll(patch, "\r\n", "\n"), "\n")
+
+	// Drop the mbox separator line
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "From ") {
+		lines = lines[1:]
+	}
+
+	var headers, body []string
+	author := ""
+	for i, line := range lines {
+		if line == "" {
+			body = lines[i+1:]
+			break
+		}
+		if strings.HasPrefix(line, "From: ") {
+			author = strings.TrimPrefix(line, "From: ")
+			continue
+		}
+		headers = append(headers, line)
+	}
+
+	out := []string{"From: " + from, "To: " + strings.Join(to, ", ")}
+	if len(cc) > 0 {
+		out = append(out, "Cc: "+strings.Join(cc, ", "))
+	}
+	out = append(out, headers...)
+	out = append(out, "")
+	if author != "" && !strings.Contains(author, addressOf(from)) {
+		out = append(out, "From: "+author, "")
+	}
+	out = append(out, body...)
+
+	return []byte(strings.Join(out, "\r\n"))
+}
+
+// addressOf returns the bare address of "Name <address>"
+func addressOf(from string) string {
+	if start := strings.LastIndex(from, "<"); start >= 0 {
+		if end := strings.LastIndex(from, ">"); end > start {
+			return from[start+1 : end]
+		}
+	}
+	return strings.TrimSpace(from)
+}
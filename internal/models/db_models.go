@@ -20,6 +20,12 @@ type RepositoryDB struct {
 	Path        string `gorm:"type:varchar(512);uniqueIndex;not null" json:"path"`
 	Alias       string `gorm:"type:varchar(255)" json:"alias"`
 	Description string `gorm:"type:text" json:"description"`
+	PathScope   string `gorm:"type:varchar(512)" json:"pathScope"`
+
+	DefaultRemote string `gorm:"type:varchar(255)" json:"defaultRemote"`
+	DefaultBranch string `gorm:"type:varchar(255)" json:"defaultBranch"`
+	PushBehavior  string `gorm:"type:varchar(32)" json:"pushBehavior"`
+	ForgeAccount  string `gorm:"type:varchar(64)" json:"forgeAccount"`
 }
 
 // PromptDB represents an AI prompt template in database
@@ -29,6 +35,10 @@ type PromptDB struct {
 	Description string `gorm:"type:text" json:"description"`
 	Template    string `gorm:"type:text;not null" json:"template"`
 	IsDefault   bool   `gorm:"default:false" json:"isDefault"`
+
+	// LibraryID and LibraryVersion identify a prompt installed from the built-in library
+	LibraryID      string `gorm:"type:varchar(64);index" json:"libraryId"`
+	LibraryVersion int    `json:"libraryVersion"`
 }
 
 // CommandDB represents a custom git command in database
@@ -40,6 +50,44 @@ type CommandDB struct {
 	Category    string `gorm:"type:varchar(255)" json:"category"`
 }
 
+// TemplateRevisionDB stores a previous version of a prompt or command
+type TemplateRevisionDB struct {
+	BaseModel
+	Kind        string `gorm:"type:varchar(16);index:idx_revision_item;not null" json:"kind"`
+	ItemID      string `gorm:"type:varchar(36);index:idx_revision_item;not null" json:"itemId"`
+	Name        string `gorm:"type:varchar(255)" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	Content     string `gorm:"type:text" json:"content"`
+	Category    string `gorm:"type:varchar(255)" json:"category"`
+}
+
+// PendingOperationDB records a long operation while it runs so a crash can be recovered
+type PendingOperationDB struct {
+	BaseModel
+	Kind       string `gorm:"type:varchar(16);not null" json:"kind"`
+	Repo       string `gorm:"type:varchar(512)" json:"repo"`
+	URL        string `gorm:"type:varchar(1024)" json:"url"`
+	Remote     string `gorm:"type:varchar(255)" json:"remote"`
+	Branch     string `gorm:"type:varchar(255)" json:"branch"`
+	CreatedDir bool   `json:"createdDir"`
+	BootID     string `gorm:"type:varchar(36);index" json:"bootId"`
+}
+
+// BackupTargetDB represents a backup remote of a repository in database
+// Branches is comma-separated, an IntervalMinutes of 0 disables scheduled pushes
+type BackupTargetDB struct {
+	BaseModel
+	RepoPath        string     `gorm:"type:varchar(512);index;not null" json:"repoPath"`
+	Remote          string     `gorm:"type:varchar(1024);not null" json:"remote"`
+	Mirror          bool       `json:"mirror"`
+	Branches        string     `gorm:"type:text" json:"branches"`
+	IntervalMinutes int        `json:"intervalMinutes"`
+	OnCommit        bool       `json:"onCommit"`
+	LastAttemptAt   *time.Time `json:"lastAttemptAt"`
+	LastSuccessAt   *time.Time `json:"lastSuccessAt"`
+	LastError       string     `gorm:"type:text" json:"lastError"`
+}
+
 // AppConfigDB represents app configuration in database
 type AppConfigDB struct {
 	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
@@ -53,3 +101,37 @@ type RecentRepoDB struct {
 	BaseModel
 	Path string `gorm:"type:varchar(512);uniqueIndex;not null" json:"path"`
 }
+
+// OperationTraceDB records the duration and outcome of a git command or AI call
+type OperationTraceDB struct {
+	ID         string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Operation  string    `gorm:"type:varchar(16);index" json:"operation"`
+	Kind       string    `gorm:"type:varchar(16);index:idx_trace_command" json:"kind"`
+	Command    string    `gorm:"type:varchar(255);index:idx_trace_command" json:"command"`
+	Repo       string    `gorm:"type:varchar(512)" json:"repo"`
+	DurationMs int64     `json:"durationMs"`
+	Success    bool      `json:"success"`
+	CreatedAt  time.Time `gorm:"index" json:"createdAt"`
+}
+
+// AvatarDB caches the resolved avatar URL of a commit author
+type AvatarDB struct {
+	Email     string    `gorm:"primaryKey;type:varchar(255)" json:"email"`
+	URL       string    `gorm:"type:varchar(1024)" json:"url"`
+	Source    string    `gorm:"type:varchar(32)" json:"source"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CommitEmbeddingDB stores the embedding of a commit message for semantic search
+// Vector holds little-endian float32 values; vectors of different models are not comparable
+type CommitEmbeddingDB struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	RepoPath  string    `gorm:"type:varchar(512);uniqueIndex:idx_embedding_commit;not null" json:"repoPath"`
+	Model     string    `gorm:"type:varchar(255);uniqueIndex:idx_embedding_commit;not null" json:"model"`
+	Hash      string    `gorm:"type:varchar(64);uniqueIndex:idx_embedding_commit;not null" json:"hash"`
+	Message   string    `gorm:"type:text" json:"message"`
+	Author    string    `gorm:"type:varchar(255)" json:"author"`
+	Date      string    `gorm:"type:varchar(64)" json:"date"`
+	Vector    []byte    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
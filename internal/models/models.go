@@ -7,14 +7,211 @@ const (
 	ProviderOpenAI AIProvider = "openai"
 	ProviderClaude AIProvider = "claude"
 	ProviderOllama AIProvider = "ollama"
+	ProviderGemini AIProvider = "gemini"
 )
 
 // AIConfig holds AI service configuration
+// EmbeddingModel is used for semantic commit search, the provider's default when empty
+// TokenBudget is the estimated number of diff tokens sent in one request, 0 for the default;
+// larger diffs are summarized file by file first
 type AIConfig struct {
-	Provider AIProvider `json:"provider"`
-	APIKey   string     `json:"apiKey"`
-	BaseURL  string     `json:"baseUrl"`
-	Model    string     `json:"model"`
+	Provider       AIProvider `json:"provider"`
+	APIKey         string     `json:"apiKey"`
+	BaseURL        string     `json:"baseUrl"`
+	Model          string     `json:"model"`
+	EmbeddingModel string     `json:"embeddingModel"`
+	TokenBudget    int        `json:"tokenBudget"`
+}
+
+// Commit message generators
+const (
+	GeneratorAI        = "ai"
+	GeneratorHeuristic = "heuristic"
+	GeneratorSeeded    = "seeded"
+)
+
+// MessageStyle configures the post-processing applied to generated commit messages
+// RequireType reports subjects without a type; DefaultType, when set, is added to them
+type MessageStyle struct {
+	Enabled          bool   `json:"enabled"`
+	MaxSubjectLength int    `json:"maxSubjectLength"`
+	BodyWrapWidth    int    `json:"bodyWrapWidth"`
+	RequireType      bool   `json:"requireType"`
+	DefaultType      string `json:"defaultType"`
+	Emoji            bool   `json:"emoji"`
+}
+
+// TeamConfig is the shared configuration committed to a repository as .gitai.yml
+// Commit overrides the user's message style, Scopes maps directories to commit scopes and
+// Prompt names the prompt template used to generate commit messages and Setup lists the
+// shell commands that prepare a fresh clone
+type TeamConfig struct {
+	Commit            *TeamCommitStyle  `json:"commit"`
+	ProtectedBranches []string          `json:"protectedBranches"`
+	Scopes            map[string]string `json:"scopes"`
+	Prompt            string            `json:"prompt"`
+	Setup             []string          `json:"setup"`
+}
+
+// TeamCommitStyle holds the message style settings a team file overrides, nil ones keep
+// the user's setting
+type TeamCommitStyle struct {
+	MaxSubjectLength *int    `json:"maxSubjectLength"`
+	BodyWrapWidth    *int    `json:"bodyWrapWidth"`
+	RequireType      *bool   `json:"requireType"`
+	DefaultType      *string `json:"defaultType"`
+	Emoji            *bool   `json:"emoji"`
+}
+
+// ForgeProvider represents a code hosting service
+type ForgeProvider string
+
+const (
+	ForgeGitHub ForgeProvider = "github"
+	ForgeGitLab ForgeProvider = "gitlab"
+)
+
+// ForgeConfig is an account on a code hosting service
+// Several accounts may share a provider and host, a repository picks one through
+// RepositoryDefaults.ForgeAccount. Username, Scopes and ExpiresAt are filled in when the
+// token is validated, HasToken reports a stored token when Token is withheld from the UI
+type ForgeConfig struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Provider  ForgeProvider `json:"provider"`
+	Token     string        `json:"token"`
+	HasToken  bool          `json:"hasToken"`
+	BaseURL   string        `json:"baseUrl"`
+	Username  string        `json:"username"`
+	Scopes    []string      `json:"scopes"`
+	ExpiresAt string        `json:"expiresAt"`
+}
+
+// TokenInfo is the result of validating a forge access token
+// MissingScopes lists required scopes the token lacks, it is empty when the provider
+// does not report scopes
+type TokenInfo struct {
+	Username      string   `json:"username"`
+	Scopes        []string `json:"scopes"`
+	MissingScopes []string `json:"missingScopes"`
+	ExpiresAt     string   `json:"expiresAt"`
+	Expired       bool     `json:"expired"`
+}
+
+// RemoteRepository represents a repository listed from a code hosting service
+type RemoteRepository struct {
+	Name        string `json:"name"`
+	FullName    string `json:"fullName"`
+	Description string `json:"description"`
+	CloneURL    string `json:"cloneUrl"`
+	SSHURL      string `json:"sshUrl"`
+	WebURL      string `json:"webUrl"`
+	Private     bool   `json:"private"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// CheckRun represents a single CI check or pipeline job
+type CheckRun struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	URL        string `json:"url"`
+}
+
+// ChecksStatus summarizes the CI state of a commit
+// State is one of success, failure, pending or none
+type ChecksStatus struct {
+	Ref    string     `json:"ref"`
+	Commit string     `json:"commit"`
+	State  string     `json:"state"`
+	Checks []CheckRun `json:"checks"`
+}
+
+// BranchProtection summarizes the rules a forge enforces on pushes to a branch
+// Detailed is false when only part of the rules could be read, as GitHub shows classic
+// protection settings to repository admins only
+type BranchProtection struct {
+	Branch               string   `json:"branch"`
+	Protected            bool     `json:"protected"`
+	Detailed             bool     `json:"detailed"`
+	PushRestricted       bool     `json:"pushRestricted"`
+	RequirePullRequest   bool     `json:"requirePullRequest"`
+	RequireSignatures    bool     `json:"requireSignatures"`
+	RequireLinearHistory bool     `json:"requireLinearHistory"`
+	AllowForcePush       bool     `json:"allowForcePush"`
+	RequiredChecks       []string `json:"requiredChecks"`
+}
+
+// Issue represents an issue on a code hosting service
+type Issue struct {
+	Number    int      `json:"number"`
+	Key       string   `json:"key"`
+	Title     string   `json:"title"`
+	State     string   `json:"state"`
+	Author    string   `json:"author"`
+	Labels    []string `json:"labels"`
+	Assignees []string `json:"assignees"`
+	URL       string   `json:"url"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// IssueFilter narrows the issues returned by ListIssues
+// State is open, closed or all; Assignee may be a username or "me"
+type IssueFilter struct {
+	State    string   `json:"state"`
+	Query    string   `json:"query"`
+	Assignee string   `json:"assignee"`
+	Labels   []string `json:"labels"`
+}
+
+// ReviewComment represents a pull request review comment anchored to a file line
+// Side is "new" for lines of the changed file and "old" for removed lines
+type ReviewComment struct {
+	ID        string `json:"id"`
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Side      string `json:"side"`
+	Body      string `json:"body"`
+	Author    string `json:"author"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+	InReplyTo string `json:"inReplyTo"`
+	Outdated  bool   `json:"outdated"`
+}
+
+// PullRequestComments holds the review comments of a pull request
+type PullRequestComments struct {
+	Number   int             `json:"number"`
+	Title    string          `json:"title"`
+	URL      string          `json:"url"`
+	Comments []ReviewComment `json:"comments"`
+}
+
+// ReviewRequest is an open pull or merge request awaiting the user's review
+// Host and Project identify it on the forge, RepositoryID, RepositoryPath and Remote the
+// managed repository and remote it was matched to. HeadRef is the ref its head is
+// fetched from
+type ReviewRequest struct {
+	Provider       ForgeProvider `json:"provider"`
+	Host           string        `json:"host"`
+	Project        string        `json:"project"`
+	Number         int           `json:"number"`
+	Title          string        `json:"title"`
+	Author         string        `json:"author"`
+	URL            string        `json:"url"`
+	Draft          bool          `json:"draft"`
+	UpdatedAt      string        `json:"updatedAt"`
+	HeadRef        string        `json:"headRef"`
+	RepositoryID   string        `json:"repositoryId"`
+	RepositoryPath string        `json:"repositoryPath"`
+	Remote         string        `json:"remote"`
+}
+
+// ReviewQueue holds the review requests of all forge accounts
+// Errors maps the name of an account that could not be queried to the reason
+type ReviewQueue struct {
+	Requests []ReviewRequest   `json:"requests"`
+	Errors   map[string]string `json:"errors"`
 }
 
 // AppConfig holds the application configuration
@@ -33,21 +230,257 @@ type WindowConfig struct {
 }
 
 // GitStatus represents the status of a git repository
+// Upstream is empty when the branch tracks nothing, Ahead and Behind count the commits
+// not yet pushed to and pulled from it
 type GitStatus struct {
-	Branch     string       `json:"branch"`
-	Staged     []FileChange `json:"staged"`
-	Unstaged   []FileChange `json:"unstaged"`
-	Untracked  []string     `json:"untracked"`
-	IsRepo     bool         `json:"isRepo"`
-	HasChanges bool         `json:"hasChanges"`
+	Branch     string        `json:"branch"`
+	Upstream   string        `json:"upstream"`
+	Ahead      int           `json:"ahead"`
+	Behind     int           `json:"behind"`
+	Staged     []FileChange  `json:"staged"`
+	Unstaged   []FileChange  `json:"unstaged"`
+	Untracked  []string      `json:"untracked"`
+	IsRepo     bool          `json:"isRepo"`
+	HasChanges bool          `json:"hasChanges"`
+	Scope      string        `json:"scope"`
+	HasCommits bool          `json:"hasCommits"`
+	Operation  OperationInfo `json:"operation"`
+	// UntrackedDirs holds collapsed directories when untracked files exceed the threshold
+	UntrackedDirs []UntrackedDir `json:"untrackedDirs"`
+}
+
+// RepositoryInfo is everything the status bar shows about the selected repository
+// LastFetch is empty when the repository was never fetched, Health is nil until probed
+type RepositoryInfo struct {
+	Path       string            `json:"path"`
+	Branch     string            `json:"branch"`
+	Upstream   string            `json:"upstream"`
+	Ahead      int               `json:"ahead"`
+	Behind     int               `json:"behind"`
+	StashCount int               `json:"stashCount"`
+	LastFetch  string            `json:"lastFetch"`
+	Operation  OperationInfo     `json:"operation"`
+	Detached   bool              `json:"detached"`
+	Bare       bool              `json:"bare"`
+	HasChanges bool              `json:"hasChanges"`
+	IsRepo     bool              `json:"isRepo"`
+	Scope      string            `json:"scope"`
+	HasCommits bool              `json:"hasCommits"`
+	Health     *RepositoryHealth `json:"health"`
+	RemoteView RemoteStaleness   `json:"remoteView"`
+}
+
+// RepositoryOverview is everything the UI needs to show a freshly opened repository
+// Errors maps the name of each part that failed to load to its error
+type RepositoryOverview struct {
+	Repository *Repository       `json:"repository"`
+	Info       *RepositoryInfo   `json:"info"`
+	Status     *GitStatus        `json:"status"`
+	Branches   []Branch          `json:"branches"`
+	Tags       []Tag             `json:"tags"`
+	Remotes    []Remote          `json:"remotes"`
+	Log        []CommitInfo      `json:"log"`
+	Errors     map[string]string `json:"errors"`
+}
+
+// Tag represents a git tag
+type Tag struct {
+	Name        string `json:"name"`
+	CommitHash  string `json:"commitHash"`
+	Message     string `json:"message"`
+	IsAnnotated bool   `json:"isAnnotated"`
+}
+
+// DiffFileStatus describes how a file changed in a structured diff
+type DiffFileStatus string
+
+const (
+	DiffModified DiffFileStatus = "modified"
+	DiffAdded    DiffFileStatus = "added"
+	DiffDeleted  DiffFileStatus = "deleted"
+	DiffRenamed  DiffFileStatus = "renamed"
+)
+
+// DiffLineType is the kind of a line in a diff hunk
+type DiffLineType string
+
+const (
+	DiffLineAdded   DiffLineType = "added"
+	DiffLineRemoved DiffLineType = "removed"
+	DiffLineContext DiffLineType = "context"
+)
+
+// StructuredDiff is unified diff output parsed for side-by-side rendering
+// Truncated is set when the diff was cut at the output limit
+type StructuredDiff struct {
+	Files     []DiffFile `json:"files"`
+	Truncated bool       `json:"truncated"`
+}
+
+// DiffFile is one file of a structured diff, binary files have no hunks
+type DiffFile struct {
+	OldPath string         `json:"oldPath"`
+	NewPath string         `json:"newPath"`
+	Status  DiffFileStatus `json:"status"`
+	Binary  bool           `json:"binary"`
+	Hunks   []DiffHunk     `json:"hunks"`
+}
+
+// DiffHunk is one "@@" section of a file diff, Section is the function context git printed
+type DiffHunk struct {
+	Header   string     `json:"header"`
+	Section  string     `json:"section"`
+	OldStart int        `json:"oldStart"`
+	OldLines int        `json:"oldLines"`
+	NewStart int        `json:"newStart"`
+	NewLines int        `json:"newLines"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine is a line of a hunk with its number on each side, 0 where the line does not exist
+type DiffLine struct {
+	Type      DiffLineType `json:"type"`
+	Content   string       `json:"content"`
+	OldLine   int          `json:"oldLine"`
+	NewLine   int          `json:"newLine"`
+	NoNewline bool         `json:"noNewline"`
+}
+
+// RefType is the kind of ref decorating a commit in the graph
+type RefType string
+
+const (
+	RefHead   RefType = "head"
+	RefBranch RefType = "branch"
+	RefRemote RefType = "remote"
+	RefTag    RefType = "tag"
+	RefStash  RefType = "stash"
+)
+
+// GraphRef is a ref pointing at a commit, a "HEAD" ref precedes the branch it is on
+type GraphRef struct {
+	Name string  `json:"name"`
+	Type RefType `json:"type"`
+}
+
+// GraphEdge is a line from a lane of one row to a lane of the next row
+type GraphEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// GraphCommit is a row of the history graph
+// Lane is the column of the commit, Edges are the lines leaving its row downwards
+type GraphCommit struct {
+	Commit  CommitInfo  `json:"commit"`
+	Parents []string    `json:"parents"`
+	Refs    []GraphRef  `json:"refs"`
+	Lane    int         `json:"lane"`
+	Edges   []GraphEdge `json:"edges"`
+}
+
+// BranchChange is sent when HEAD of the watched repository moves to another branch
+// Branch is empty when HEAD is detached
+type BranchChange struct {
+	RepoPath string `json:"repoPath"`
+	Branch   string `json:"branch"`
+}
+
+// RepoChange is sent after a mutating call and tells the frontend which panels to reload
+// Head is the commit HEAD points to afterwards and is only set when the log changed
+type RepoChange struct {
+	RepoPath string `json:"repoPath"`
+	Status   bool   `json:"status"`
+	Branches bool   `json:"branches"`
+	Log      bool   `json:"log"`
+	Tags     bool   `json:"tags"`
+	Remotes  bool   `json:"remotes"`
+	Head     string `json:"head"`
+}
+
+// OperationState represents a multi-step git operation that is waiting for the user
+type OperationState string
+
+const (
+	OperationNone          OperationState = "none"
+	OperationMerging       OperationState = "merging"
+	OperationRebasing      OperationState = "rebasing"
+	OperationCherryPicking OperationState = "cherry-picking"
+	OperationReverting     OperationState = "reverting"
+	OperationBisecting     OperationState = "bisecting"
+)
+
+// OperationInfo describes the in-progress operation and the refs involved
+// Head is the commit being merged, picked or reverted; Onto and the step counters apply to rebases
+type OperationInfo struct {
+	State  OperationState `json:"state"`
+	Head   string         `json:"head"`
+	Branch string         `json:"branch"`
+	Onto   string         `json:"onto"`
+	Step   int            `json:"step"`
+	Total  int            `json:"total"`
+}
+
+// UntrackedDir represents a collapsed directory of untracked files
+type UntrackedDir struct {
+	Path  string `json:"path"`
+	Count int    `json:"count"`
+}
+
+// UntrackedListing holds the untracked entries below a directory
+type UntrackedListing struct {
+	Files []string       `json:"files"`
+	Dirs  []UntrackedDir `json:"dirs"`
+}
+
+// UntrackedMode controls how untracked files are reported by status
+type UntrackedMode string
+
+const (
+	UntrackedNo     UntrackedMode = "no"
+	UntrackedNormal UntrackedMode = "normal"
+	UntrackedAll    UntrackedMode = "all"
+)
+
+// StatusOptions controls how much work git status does
+type StatusOptions struct {
+	UntrackedMode    UntrackedMode `json:"untrackedMode"`
+	IgnoreSubmodules bool          `json:"ignoreSubmodules"`
+	// CollapseThreshold groups untracked files by directory once their number exceeds it, 0 disables
+	CollapseThreshold int `json:"collapseThreshold"`
 }
 
 // FileChange represents a changed file
+// OldPath and Similarity are only set for renames and copies
 type FileChange struct {
-	Path     string `json:"path"`
-	Status   string `json:"status"`
-	Additions int   `json:"additions"`
-	Deletions int   `json:"deletions"`
+	Path       string           `json:"path"`
+	OldPath    string           `json:"oldPath"`
+	Similarity int              `json:"similarity"`
+	Status     string           `json:"status"`
+	Additions  int              `json:"additions"`
+	Deletions  int              `json:"deletions"`
+	EntryType  string           `json:"entryType"`
+	Submodule  *SubmoduleChange `json:"submodule,omitempty"`
+	// HunkStaging is false for entries whose diff has no lines to stage, like symlinks and submodules
+	HunkStaging bool `json:"hunkStaging"`
+}
+
+// Entry types of a FileChange
+const (
+	EntryFile      = "file"
+	EntrySymlink   = "symlink"
+	EntrySubmodule = "submodule"
+	EntryGitlink   = "gitlink" // a commit entry with no .gitmodules registration
+)
+
+// SubmoduleChange describes a submodule entry: the recorded commit before and after,
+// and for unstaged entries whether the submodule has local changes of its own
+type SubmoduleChange struct {
+	OldCommit        string `json:"oldCommit"`
+	NewCommit        string `json:"newCommit"`
+	CommitChanged    bool   `json:"commitChanged"`
+	HasModifications bool   `json:"hasModifications"`
+	HasUntracked     bool   `json:"hasUntracked"`
 }
 
 // Branch represents a git branch
@@ -57,18 +490,677 @@ type Branch struct {
 }
 
 // CommitInfo represents a git commit
+// AuthorDate and CommitDate are strict ISO 8601 in the original timezone,
+// the timestamps are Unix seconds for sorting and relative formatting
 type CommitInfo struct {
+	Hash            string `json:"hash"`
+	ShortHash       string `json:"shortHash"`
+	Message         string `json:"message"`
+	Author          string `json:"author"`
+	Email           string `json:"email"`
+	Date            string `json:"date"`
+	AuthorDate      string `json:"authorDate"`
+	AuthorTimestamp int64  `json:"authorTimestamp"`
+	CommitDate      string `json:"commitDate"`
+	CommitTimestamp int64  `json:"commitTimestamp"`
+}
+
+// HookResult represents the outcome of a single commit check
+type HookResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Output string `json:"output"`
+}
+
+// CommitPreview describes what a commit would contain without creating it
+type CommitPreview struct {
+	Files     []FileChange `json:"files"`
+	Checks    []HookResult `json:"checks"`
+	CanCommit bool         `json:"canCommit"`
+}
+
+// FileIssue represents a problem detected in a file before committing
+type FileIssue struct {
+	Path       string `json:"path"`
+	Kind       string `json:"kind"`
+	Detail     string `json:"detail"`
+	Attributes string `json:"attributes"`
+}
+
+// DroppedPathResult describes how a path dropped onto the window was handled
+// Kind is "repository" when it was registered and selected, or "folder" when it
+// is a plain directory that the user may init or clone into
+type DroppedPathResult struct {
+	Kind       string      `json:"kind"`
+	Path       string      `json:"path"`
+	Repository *Repository `json:"repository"`
+}
+
+// LineRange is an inclusive, 1-based range of lines in a file
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// CommitPatch represents a commit together with the patch hunks relevant to a query
+type CommitPatch struct {
 	Hash    string `json:"hash"`
 	Message string `json:"message"`
 	Author  string `json:"author"`
+	Email   string `json:"email"`
 	Date    string `json:"date"`
+	Patch   string `json:"patch"`
+}
+
+// BlameLine is one line of a file annotated with the commit that last changed it
+// Uncommitted lines carry an all-zero Hash. OrigPath is the file's path in that commit,
+// which differs from the blamed path after a rename
+type BlameLine struct {
+	Line        int    `json:"line"`
+	OrigLine    int    `json:"origLine"`
+	OrigPath    string `json:"origPath"`
+	Hash        string `json:"hash"`
+	ShortHash   string `json:"shortHash"`
+	Author      string `json:"author"`
+	Email       string `json:"email"`
+	Date        string `json:"date"`
+	Timestamp   int64  `json:"timestamp"`
+	Summary     string `json:"summary"`
+	Content     string `json:"content"`
+	Uncommitted bool   `json:"uncommitted"`
+}
+
+// SemanticMatch is a commit found by semantic search, Score being the cosine similarity
+// of its message to the query
+type SemanticMatch struct {
+	Hash      string  `json:"hash"`
+	ShortHash string  `json:"shortHash"`
+	Message   string  `json:"message"`
+	Author    string  `json:"author"`
+	Date      string  `json:"date"`
+	Score     float64 `json:"score"`
+}
+
+// HealthWarning represents a repository health problem with a suggested fix
+// Action names a maintenance task accepted by RunMaintenance
+type HealthWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Action  string `json:"action"`
+}
+
+// RepositoryHealth holds the result of a quick repository health probe
+type RepositoryHealth struct {
+	LooseObjects int64           `json:"looseObjects"`
+	PackCount    int64           `json:"packCount"`
+	PackSizeKB   int64           `json:"packSizeKB"`
+	GarbageCount int64           `json:"garbageCount"`
+	IndexSize    int64           `json:"indexSize"`
+	Warnings     []HealthWarning `json:"warnings"`
+}
+
+// LogOptions filters the commit history
+// Author and Grep are regular expressions; Author "me" means the configured user.email
+type LogOptions struct {
+	Limit       int    `json:"limit"`
+	Skip        int    `json:"skip"`
+	Path        string `json:"path"`
+	Author      string `json:"author"`
+	Grep        string `json:"grep"`
+	IgnoreCase  bool   `json:"ignoreCase"`
+	FirstParent bool   `json:"firstParent"`
+}
+
+// LogChunk is one batch of a streamed history, sent with the "log:chunk" event
+// The last chunk of a stream has Done set, with the commit count and any error
+type LogChunk struct {
+	StreamID string       `json:"streamId"`
+	Commits  []CommitInfo `json:"commits"`
+	Done     bool         `json:"done"`
+	Total    int          `json:"total"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// Location describes what a token from the UI refers to
+// Kind is branch, remote-branch, tag, commit, file or unknown
+type Location struct {
+	Kind   string      `json:"kind"`
+	Name   string      `json:"name"`
+	Path   string      `json:"path"`
+	Commit *CommitInfo `json:"commit"`
+}
+
+// OperationStats aggregates the recorded runs of one command
+type OperationStats struct {
+	Kind     string  `json:"kind"`
+	Command  string  `json:"command"`
+	Count    int64   `json:"count"`
+	Failures int64   `json:"failures"`
+	AvgMs    float64 `json:"avgMs"`
+	MaxMs    int64   `json:"maxMs"`
+	TotalMs  int64   `json:"totalMs"`
+}
+
+// OperationTrace represents a single recorded operation
+type OperationTrace struct {
+	Operation  string `json:"operation,omitempty"`
+	Kind       string `json:"kind"`
+	Command    string `json:"command"`
+	Repo       string `json:"repo"`
+	DurationMs int64  `json:"durationMs"`
+	Success    bool   `json:"success"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// PerformanceReport summarizes recorded operations, slowest in total first
+type PerformanceReport struct {
+	Enabled    bool             `json:"enabled"`
+	Since      string           `json:"since"`
+	Operations []OperationStats `json:"operations"`
+	Slowest    []OperationTrace `json:"slowest"`
+}
+
+// FSMonitorStatus describes the filesystem monitor setup of a repository
+// Supported refers to git's builtin daemon; hook based monitors such as Watchman show up in Mode
+// StatusLatency holds the recorded timings of git status for the repository
+type FSMonitorStatus struct {
+	Supported      bool            `json:"supported"`
+	Enabled        bool            `json:"enabled"`
+	Mode           string          `json:"mode"`
+	DaemonRunning  bool            `json:"daemonRunning"`
+	UntrackedCache bool            `json:"untrackedCache"`
+	TrackedFiles   int             `json:"trackedFiles"`
+	Recommended    bool            `json:"recommended"`
+	StatusLatency  *OperationStats `json:"statusLatency,omitempty"`
+}
+
+// LogEntry represents a line of the application log
+// ID is the correlation ID shared by the lines of one operation
+type LogEntry struct {
+	Time    string                 `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	ID      string                 `json:"id"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// CrashReport is a fatal crash captured while crash reporting was on, with the log entries
+// leading up to it; paths, tokens and repository names are scrubbed before it is stored
+type CrashReport struct {
+	ID      string     `json:"id"`
+	Time    string     `json:"time"`
+	Version string     `json:"version"`
+	OS      string     `json:"os"`
+	Arch    string     `json:"arch"`
+	Summary string     `json:"summary"`
+	Stack   string     `json:"stack"`
+	Logs    []LogEntry `json:"logs"`
+}
+
+// ActionParam describes a parameter of an action, Type is a JSON schema type
+type ActionParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ActionInfo describes an App operation for the command palette and shortcuts
+type ActionInfo struct {
+	ID        string        `json:"id"`
+	Title     string        `json:"title"`
+	Category  string        `json:"category"`
+	Dangerous bool          `json:"dangerous"`
+	Params    []ActionParam `json:"params"`
 }
 
 // CloneOptions represents options for cloning a repository
+// Depth makes a shallow clone of that many commits, 0 clones the full history; it also
+// applies to submodules. PostClone lists the steps run after cloning, see PostCloneLFS
 type CloneOptions struct {
-	URL    string `json:"url"`
-	Path   string `json:"path"`
-	Branch string `json:"branch"`
+	URL               string   `json:"url"`
+	Path              string   `json:"path"`
+	Branch            string   `json:"branch"`
+	Depth             int      `json:"depth"`
+	RecurseSubmodules bool     `json:"recurseSubmodules"`
+	PostClone         []string `json:"postClone"`
+}
+
+// Post-clone steps of CloneOptions: download Git LFS files, or list the setup commands
+// of the cloned repository's .gitai.yml for the user to confirm
+const (
+	PostCloneLFS   = "lfs"
+	PostCloneSetup = "setup"
+)
+
+// Steps of a clone reported by CloneProgress
+const (
+	CloneStepClone      = "clone"
+	CloneStepSubmodules = "submodules"
+	CloneStepLFS        = "lfs"
+	CloneStepSetup      = "setup"
+)
+
+// CloneProgress is sent when a clone step starts and ends
+// Status is "running", "done", "skipped", "failed" or "confirm"; a setup step ends with
+// "confirm" and the Commands the user must approve before any of them runs
+type CloneProgress struct {
+	Path     string   `json:"path"`
+	Step     string   `json:"step"`
+	Status   string   `json:"status"`
+	Output   string   `json:"output,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// CloneGrouping arranges cloned repositories below the clone directory
+type CloneGrouping string
+
+const (
+	CloneGroupNone  CloneGrouping = ""
+	CloneGroupOwner CloneGrouping = "owner"
+	CloneGroupHost  CloneGrouping = "host"
+)
+
+// CloneSettings are the defaults of the clone dialog
+// Root is the default clone directory; with Grouping "owner" a repository goes to
+// Root/owner/name and with "host" to Root/host/owner/name
+type CloneSettings struct {
+	Root     string        `json:"root"`
+	Grouping CloneGrouping `json:"grouping"`
+}
+
+// GitExecutable selects the git binary and the global options put before every command
+// An empty Path runs "git" from PATH; Args are options such as "-c", "protocol.version=2"
+type GitExecutable struct {
+	Path string   `json:"path"`
+	Args []string `json:"args"`
+}
+
+// UpdateChannel selects which releases the update checker offers
+type UpdateChannel string
+
+const (
+	UpdateStable UpdateChannel = "stable"
+	UpdateBeta   UpdateChannel = "beta"
+)
+
+// UpdateInfo describes the newest release of the channel and the artifact for this platform
+// AssetURL is empty when the release has no build for this platform
+type UpdateInfo struct {
+	CurrentVersion string `json:"currentVersion"`
+	LatestVersion  string `json:"latestVersion"`
+	Available      bool   `json:"available"`
+	Prerelease     bool   `json:"prerelease"`
+	Notes          string `json:"notes"`
+	ReleaseURL     string `json:"releaseUrl"`
+	PublishedAt    string `json:"publishedAt"`
+	AssetName      string `json:"assetName"`
+	AssetURL       string `json:"assetUrl"`
+	Size           int64  `json:"size"`
+	Downloaded     bool   `json:"downloaded"`
+	InstallPending bool   `json:"installPending"`
+}
+
+// PendingOperation represents a clone, fetch or pull that was interrupted
+// Repo is the repository path, or the destination directory of a clone,
+// CreatedDir reports whether the clone created that directory
+type PendingOperation struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	Repo       string `json:"repo"`
+	URL        string `json:"url"`
+	Remote     string `json:"remote"`
+	Branch     string `json:"branch"`
+	CreatedDir bool   `json:"createdDir"`
+	StartedAt  string `json:"startedAt"`
+}
+
+// PendingOperationResult reports how an interrupted operation was handled
+// Action is one of resumed, cleaned or skipped
+type PendingOperationResult struct {
+	Operation PendingOperation `json:"operation"`
+	Action    string           `json:"action"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// Snapshot represents a local checkpoint of the working tree and index
+// Automatic snapshots are taken before risky operations, Head is the commit checked out at the time
+type Snapshot struct {
+	ID        string `json:"id"`
+	Hash      string `json:"hash"`
+	ShortHash string `json:"shortHash"`
+	Message   string `json:"message"`
+	Automatic bool   `json:"automatic"`
+	Head      string `json:"head"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// TryState describes a patch being tried out in the working tree
+// Files are the paths the patch touched; SnapshotID is the checkpoint taken before it was
+// applied, empty when the working tree was clean
+type TryState struct {
+	Active     bool     `json:"active"`
+	RepoPath   string   `json:"repoPath"`
+	Files      []string `json:"files"`
+	SnapshotID string   `json:"snapshotId"`
+	StartedAt  string   `json:"startedAt"`
+}
+
+// AppliedHunk tells where a hunk of a suggested patch was applied
+// Offset is how many lines it moved from where the patch placed it, Fuzz how many context
+// lines were ignored at each end
+type AppliedHunk struct {
+	Index                 int  `json:"index"`
+	Line                  int  `json:"line"`
+	Offset                int  `json:"offset"`
+	Fuzz                  int  `json:"fuzz"`
+	WhitespaceInsensitive bool `json:"whitespaceInsensitive"`
+}
+
+// SuggestedPatchResult describes a suggested patch written to a file
+// SnapshotID is the checkpoint taken before writing, empty when the working tree was clean
+type SuggestedPatchResult struct {
+	FilePath   string        `json:"filePath"`
+	Created    bool          `json:"created"`
+	Hunks      []AppliedHunk `json:"hunks"`
+	SnapshotID string        `json:"snapshotId"`
+}
+
+// DiscardedEntry represents changes or files moved to the trash instead of being lost
+// Skipped lists removed files too large to keep
+type DiscardedEntry struct {
+	ID        string   `json:"id"`
+	Kind      string   `json:"kind"`
+	Repo      string   `json:"repo"`
+	Paths     []string `json:"paths"`
+	HasPatch  bool     `json:"hasPatch"`
+	Files     []string `json:"files"`
+	Skipped   []string `json:"skipped"`
+	Size      int64    `json:"size"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// PatchFile represents one mailbox patch of a formatted series
+type PatchFile struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Content string `json:"content"`
+}
+
+// SMTPConfig holds the account used to mail patches
+// UseTLS selects implicit TLS, otherwise STARTTLS is used when the server offers it;
+// HasPassword reports a stored password when Password is withheld from the UI
+type SMTPConfig struct {
+	Host        string `json:"host"`
+	Port        int    `json:"port"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	HasPassword bool   `json:"hasPassword"`
+	From        string `json:"from"`
+	UseTLS      bool   `json:"useTls"`
+}
+
+// SendPatchOptions describes a patch series to mail
+// UseGit sends through git send-email instead of the configured SMTP account
+type SendPatchOptions struct {
+	Range        string   `json:"range"`
+	CoverLetter  bool     `json:"coverLetter"`
+	CoverSubject string   `json:"coverSubject"`
+	CoverBody    string   `json:"coverBody"`
+	To           []string `json:"to"`
+	Cc           []string `json:"cc"`
+	UseGit       bool     `json:"useGit"`
+}
+
+// ShareInfo describes a repository served over HTTP on the local network
+// A writable share accepts pushes only with Token as the basic auth password; PushURL
+// carries it so it can be handed to trusted peers
+type ShareInfo struct {
+	Active    bool   `json:"active"`
+	Repo      string `json:"repo"`
+	Port      int    `json:"port"`
+	ReadOnly  bool   `json:"readOnly"`
+	URL       string `json:"url"`
+	PushURL   string `json:"pushUrl,omitempty"`
+	Token     string `json:"token,omitempty"`
+	StartedAt string `json:"startedAt"`
+}
+
+// BackupTarget describes a remote a repository is backed up to
+// Remote is a remote name or URL, Branches are pushed when Mirror is off
+type BackupTarget struct {
+	ID              string   `json:"id"`
+	RepoPath        string   `json:"repoPath"`
+	Remote          string   `json:"remote"`
+	Mirror          bool     `json:"mirror"`
+	Branches        []string `json:"branches"`
+	IntervalMinutes int      `json:"intervalMinutes"`
+	OnCommit        bool     `json:"onCommit"`
+	LastAttemptAt   string   `json:"lastAttemptAt"`
+	LastSuccessAt   string   `json:"lastSuccessAt"`
+	LastError       string   `json:"lastError"`
+}
+
+// CheckoutResult reports what a branch switch did with uncommitted changes
+type CheckoutResult struct {
+	Branch    string   `json:"branch"`
+	Strategy  string   `json:"strategy"`
+	Changes   []string `json:"changes"`
+	Stashed   bool     `json:"stashed"`
+	Reapplied bool     `json:"reapplied"`
+	Conflicts []string `json:"conflicts"`
+}
+
+// RebaseOntoPreview describes what moving Branch from OldBase onto NewBase would do
+// Commits are transplanted oldest first, Skipped lists the hashes of those whose change
+// NewBase already has, DroppedMerges counts merge commits the rebase flattens away and
+// Pushed warns that the branch will need a force push
+type RebaseOntoPreview struct {
+	NewBase       string       `json:"newBase"`
+	OldBase       string       `json:"oldBase"`
+	Branch        string       `json:"branch"`
+	Commits       []CommitInfo `json:"commits"`
+	Skipped       []string     `json:"skipped"`
+	DroppedMerges int          `json:"droppedMerges"`
+	Pushed        bool         `json:"pushed"`
+}
+
+// CommitQuality is the score of one commit message, from 0 to 100
+// AIScore is the model's descriptiveness rating from 1 to 5, 0 when AI was not used
+type CommitQuality struct {
+	Hash      string   `json:"hash"`
+	ShortHash string   `json:"shortHash"`
+	Author    string   `json:"author"`
+	Email     string   `json:"email"`
+	Subject   string   `json:"subject"`
+	Score     int      `json:"score"`
+	AIScore   int      `json:"aiScore"`
+	Typed     bool     `json:"typed"`
+	Problems  []string `json:"problems"`
+}
+
+// AuthorQuality summarizes the commit message scores of one author
+type AuthorQuality struct {
+	Author       string  `json:"author"`
+	Email        string  `json:"email"`
+	Commits      int     `json:"commits"`
+	AverageScore float64 `json:"averageScore"`
+	TypedPercent float64 `json:"typedPercent"`
+}
+
+// CommitQualityReport scores recent commit messages against the commit convention
+// AIError is set when the AI rating failed and only rule-based scores are reported
+type CommitQualityReport struct {
+	Commits      []CommitQuality `json:"commits"`
+	Authors      []AuthorQuality `json:"authors"`
+	AverageScore float64         `json:"averageScore"`
+	AIUsed       bool            `json:"aiUsed"`
+	AIError      string          `json:"aiError,omitempty"`
+}
+
+// DependencyBump is a dependency change found in a manifest diff
+// From is empty for an added dependency, To for a removed one
+type DependencyBump struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	File string `json:"file"`
+}
+
+// FileOwners are the code owners of a file and the CODEOWNERS pattern that assigned them
+type FileOwners struct {
+	Path    string   `json:"path"`
+	Owners  []string `json:"owners"`
+	Pattern string   `json:"pattern"`
+}
+
+// ChangeOwners lists who owns the staged files according to CODEOWNERS
+// Owners is the union of all owners; Users and Teams are those that can be requested as
+// reviewers, without the leading @. File is empty when the repository has no CODEOWNERS
+type ChangeOwners struct {
+	File    string       `json:"file"`
+	Files   []FileOwners `json:"files"`
+	Owners  []string     `json:"owners"`
+	Users   []string     `json:"users"`
+	Teams   []string     `json:"teams"`
+	Unowned []string     `json:"unowned"`
+}
+
+// TestTarget is an existing test file related to the staged changes
+// Sources are the staged files it covers; Staged is set when the test itself is staged
+type TestTarget struct {
+	Path    string   `json:"path"`
+	Sources []string `json:"sources"`
+	Staged  bool     `json:"staged"`
+}
+
+// TestImpact lists the tests to run before committing the staged changes
+// Untested are staged source files without a related test, Suggestions the AI's advice on
+// missing coverage; AIError is set when the AI could not be asked
+type TestImpact struct {
+	Tests       []TestTarget `json:"tests"`
+	Untested    []string     `json:"untested"`
+	Suggestions string       `json:"suggestions"`
+	AIUsed      bool         `json:"aiUsed"`
+	AIError     string       `json:"aiError,omitempty"`
+}
+
+// MergePrediction is the outcome of merging Source into Target, computed without
+// touching the working tree
+// UpToDate means Target already contains Source, FastForward that Target can simply move
+// to Source; neither can conflict
+type MergePrediction struct {
+	Source      string          `json:"source"`
+	Target      string          `json:"target"`
+	MergeBase   string          `json:"mergeBase"`
+	UpToDate    bool            `json:"upToDate"`
+	FastForward bool            `json:"fastForward"`
+	Clean       bool            `json:"clean"`
+	Conflicts   []MergeConflict `json:"conflicts"`
+}
+
+// MergeConflict is a file that would conflict in a merge
+// Type is git's conflict kind, such as "contents" or "modify/delete"
+type MergeConflict struct {
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// DeleteBranchPreview describes the consequences of deleting a branch
+// LostCommits lists "<short hash> <subject>" of commits kept by no other branch or remote
+type DeleteBranchPreview struct {
+	Branch          string   `json:"branch"`
+	IsCurrent       bool     `json:"isCurrent"`
+	Upstream        string   `json:"upstream"`
+	OnRemotes       []string `json:"onRemotes"`
+	UnmergedCommits int      `json:"unmergedCommits"`
+	UnpushedCommits int      `json:"unpushedCommits"`
+	LostCommits     []string `json:"lostCommits"`
+	Safe            bool     `json:"safe"`
+}
+
+// CommitTrailer is a trailer such as "Reviewed-by: Name <email>" appended to commits
+// {issue} in Value is replaced by the issue linked to the branch
+type CommitTrailer struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Destinations of an exported patch
+const (
+	PatchToClipboard = "clipboard"
+	PatchToFile      = "file"
+)
+
+// ProtectedPaths lists gitignore-style patterns of files that discard, clean and forced
+// checkout must not throw away without confirmation, or at all when Block is set
+type ProtectedPaths struct {
+	Patterns []string `json:"patterns"`
+	Block    bool     `json:"block"`
+}
+
+// AIContextFilter selects the files whose diffs are left out of AI requests, replaced by
+// a one-line summary; Patterns are gitignore-style and Generated also excludes files
+// .gitattributes marks as linguist-generated
+type AIContextFilter struct {
+	Patterns  []string `json:"patterns"`
+	Generated bool     `json:"generated"`
+}
+
+// AddRemoteOptions controls the checks run when adding a remote
+// Fetch implies a connection test
+type AddRemoteOptions struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	Validate       bool   `json:"validate"`
+	TestConnection bool   `json:"testConnection"`
+	Fetch          bool   `json:"fetch"`
+}
+
+// AddRemoteResult is a verified remote with the branches it offers after fetching
+type AddRemoteResult struct {
+	Remote   Remote   `json:"remote"`
+	Branches []string `json:"branches"`
+}
+
+// FetchPolicy controls fetching before branch operations
+// Remote refs older than StaleMinutes are fetched when AutoFetch is on, otherwise reported as stale
+type FetchPolicy struct {
+	AutoFetch    bool `json:"autoFetch"`
+	StaleMinutes int  `json:"staleMinutes"`
+}
+
+// RefUpdate is a ref a fetch moved or created, OldHash is empty for a new ref
+type RefUpdate struct {
+	Ref     string `json:"ref"`
+	OldHash string `json:"oldHash"`
+	NewHash string `json:"newHash"`
+}
+
+// FetchResult reports what fetching one remote changed
+// Remote-tracking refs are named like origin/main and tags like tags/v1.0
+type FetchResult struct {
+	Remote  string      `json:"remote"`
+	Updated []RefUpdate `json:"updated"`
+	Pruned  []string    `json:"pruned"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// RemoteStaleness describes how current the local view of the remotes is
+// LastFetch is empty when the repository was never fetched
+type RemoteStaleness struct {
+	LastFetch  string `json:"lastFetch"`
+	AgeMinutes int    `json:"ageMinutes"`
+	Stale      bool   `json:"stale"`
+	Fetched    bool   `json:"fetched"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PathResult reports the outcome of a batch operation for one selected path
+type PathResult struct {
+	Path    string `json:"path"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
 }
 
 // Remote represents a git remote
@@ -77,15 +1169,53 @@ type Remote struct {
 	URL  string `json:"url"`
 }
 
+// WebURL is the browser page of a repository object on one remote's forge
+type WebURL struct {
+	Remote string `json:"remote"`
+	Flavor string `json:"flavor"`
+	URL    string `json:"url"`
+}
+
 // Prompt represents an AI prompt template
 type Prompt struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Template       string `json:"template"`
+	IsDefault      bool   `json:"isDefault"`
+	LibraryID      string `json:"libraryId"`
+	LibraryVersion int    `json:"libraryVersion"`
+	CreatedAt      string `json:"createdAt"`
+	UpdatedAt      string `json:"updatedAt"`
+}
+
+// LibraryPrompt is a prompt template of the built-in library
+// PromptID and InstalledVersion describe the installed copy, UpdateAvailable is set when
+// the library has a newer version than the one installed
+type LibraryPrompt struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	Category         string `json:"category"`
+	Version          int    `json:"version"`
+	Template         string `json:"template"`
+	Installed        bool   `json:"installed"`
+	PromptID         string `json:"promptId"`
+	InstalledVersion int    `json:"installedVersion"`
+	UpdateAvailable  bool   `json:"updateAvailable"`
+}
+
+// TemplateRevision represents a saved version of a prompt or command
+// Content holds the prompt template or the command line
+type TemplateRevision struct {
 	ID          string `json:"id"`
+	ItemID      string `json:"itemId"`
+	Kind        string `json:"kind"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Template    string `json:"template"`
-	IsDefault   bool   `json:"isDefault"`
+	Content     string `json:"content"`
+	Category    string `json:"category"`
 	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
 }
 
 // Command represents a custom git command
@@ -111,14 +1241,163 @@ type CommandsConfig struct {
 
 // Repository represents a managed repository
 type Repository struct {
-	ID          string `json:"id"`
-	Path        string `json:"path"`
-	Alias       string `json:"alias"`
+	ID          string             `json:"id"`
+	Path        string             `json:"path"`
+	Alias       string             `json:"alias"`
+	Description string             `json:"description"`
+	PathScope   string             `json:"pathScope"`
+	Defaults    RepositoryDefaults `json:"defaults"`
+	LastBackup  string             `json:"lastBackup"`
+	CreatedAt   string             `json:"createdAt"`
+	UpdatedAt   string             `json:"updatedAt"`
+}
+
+// PushOptions describes what Push sends
+// Remote defaults to the branch's upstream remote or origin, Branch to the current branch;
+// a Refspec, such as "HEAD:refs/for/main", is pushed as given instead of a branch
+type PushOptions struct {
+	Remote         string `json:"remote"`
+	Branch         string `json:"branch"`
+	Refspec        string `json:"refspec"`
+	ForceWithLease bool   `json:"forceWithLease"`
+	Tags           bool   `json:"tags"`
+	SetUpstream    bool   `json:"setUpstream"`
+}
+
+// OutgoingCommit is a commit a push would send
+// Signature is git's %G? code: N for unsigned, G for a good signature, and so on
+type OutgoingCommit struct {
+	Hash      string `json:"hash"`
+	ShortHash string `json:"shortHash"`
+	Subject   string `json:"subject"`
+	Signature string `json:"signature"`
+	Merge     bool   `json:"merge"`
+}
+
+// OutgoingPush describes what pushing Branch to RemoteBranch on Remote would send
+// FastForward is false when the remote branch has commits the local one lacks
+type OutgoingPush struct {
+	Remote       string           `json:"remote"`
+	Branch       string           `json:"branch"`
+	RemoteBranch string           `json:"remoteBranch"`
+	NewBranch    bool             `json:"newBranch"`
+	FastForward  bool             `json:"fastForward"`
+	Commits      []OutgoingCommit `json:"commits"`
+}
+
+// Push preflight warning kinds
+const (
+	PushWarnRestricted  = "restricted"
+	PushWarnPullRequest = "pull_request"
+	PushWarnSignatures  = "signatures"
+	PushWarnLinear      = "linear_history"
+	PushWarnForce       = "force_push"
+	PushWarnChecks      = "status_checks"
+)
+
+// PushWarning is a branch protection rule the push would likely break
+// Commits lists the short hashes of the offending commits, when the rule is about commits
+type PushWarning struct {
+	Kind    string   `json:"kind"`
+	Message string   `json:"message"`
+	Commits []string `json:"commits"`
+}
+
+// PushPreflight is the result of checking a push against the branch protection of the forge
+// Protection is nil when the remote is not on a configured forge
+type PushPreflight struct {
+	Outgoing   OutgoingPush      `json:"outgoing"`
+	Protection *BranchProtection `json:"protection"`
+	Warnings   []PushWarning     `json:"warnings"`
+}
+
+// BranchCommit is a commit of a branch with its full message
+type BranchCommit struct {
+	Hash      string `json:"hash"`
+	ShortHash string `json:"shortHash"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+}
+
+// BranchChanges are the commits and cumulative diff a pull request of Head into Base shows
+type BranchChanges struct {
+	Base    string         `json:"base"`
+	Head    string         `json:"head"`
+	Commits []BranchCommit `json:"commits"`
+	Diff    string         `json:"diff"`
+}
+
+// RewrittenCommit is a commit on a remote that a history rewrite would replace
+// Refs lists the remote-tracking branches that contain it
+type RewrittenCommit struct {
+	Hash      string   `json:"hash"`
+	ShortHash string   `json:"shortHash"`
+	Subject   string   `json:"subject"`
+	Refs      []string `json:"refs"`
+}
+
+// RewrittenCommits reports the commits between Base and Tip that already exist on a remote,
+// with the remote-tracking branches and remotes that have them
+type RewrittenCommits struct {
+	Base    string            `json:"base"`
+	Tip     string            `json:"tip"`
+	Commits []RewrittenCommit `json:"commits"`
+	Refs    []string          `json:"refs"`
+	Remotes []string          `json:"remotes"`
+}
+
+// ChangelogEntry is a commit listed in a changelog, with its Conventional Commits type
+// prefix removed from Description
+type ChangelogEntry struct {
+	Hash        string `json:"hash"`
+	ShortHash   string `json:"shortHash"`
+	Scope       string `json:"scope"`
 	Description string `json:"description"`
-	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
+	Breaking    bool   `json:"breaking"`
+}
+
+// ChangelogSection holds the entries of one commit type under its heading
+type ChangelogSection struct {
+	Type    string           `json:"type"`
+	Title   string           `json:"title"`
+	Entries []ChangelogEntry `json:"entries"`
+}
+
+// Changelog is the release notes of the commits after From up to To
+// Markdown is rendered from the sections, or written by AI when AIGenerated is set
+type Changelog struct {
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Sections    []ChangelogSection `json:"sections"`
+	Breaking    []ChangelogEntry   `json:"breaking"`
+	Markdown    string             `json:"markdown"`
+	AIGenerated bool               `json:"aiGenerated"`
 }
 
+// PullRequestDescription is a generated title and Markdown description for a pull request
+type PullRequestDescription struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// RepositoryDefaults are per-repository choices used when Push, Pull and comparisons
+// are not given a remote or branch
+// PushBehavior is "" for git's default, "upstream" to push the current branch and set
+// its upstream, or "current" to push the current branch without tracking it
+// ForgeAccount is the ID of the forge account used for the repository, "" to match by host
+type RepositoryDefaults struct {
+	DefaultRemote string `json:"defaultRemote"`
+	DefaultBranch string `json:"defaultBranch"`
+	PushBehavior  string `json:"pushBehavior"`
+	ForgeAccount  string `json:"forgeAccount"`
+}
+
+// Push behaviors of RepositoryDefaults
+const (
+	PushUpstream = "upstream"
+	PushCurrent  = "current"
+)
+
 // RepositoriesConfig holds all managed repositories
 type RepositoriesConfig struct {
 	Repositories []Repository `json:"repositories"`
@@ -0,0 +1,175 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-ai-tools/internal/ai"
+	"git-ai-tools/internal/git"
+	"git-ai-tools/internal/models"
+)
+
+// breakingPattern finds a BREAKING CHANGE footer in a commit body
+var breakingPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+
+// otherType collects commits without a recognized type
+const otherType = "other"
+
+// sectionTitles are the changelog headings of each type, in the order sections appear
+var sectionTitles = []struct{ typ, title string }{
+	{"feat", "✨ 新功能"},
+	{"fix", "🐛 问题修复"},
+	{"perf", "⚡ 性能优化"},
+	{"refactor", "♻️ 重构"},
+	{"revert", "⏪ 回退"},
+	{"docs", "📝 文档"},
+	{"style", "💄 代码格式"},
+	{"test", "✅ 测试"},
+	{"build", "📦 构建"},
+	{"ci", "👷 持续集成"},
+	{"chore", "🔧 杂项"},
+	{otherType, "其他改动"},
+}
+
+// ReleaseService builds changelogs from the commits between two refs of the selected repository
+type ReleaseService struct {
+	git *git.GitService
+	ai  *ai.AIService
+}
+
+// NewReleaseService creates a ReleaseService reading history through gitService and
+// polishing notes with aiService
+func NewReleaseService(gitService *git.GitService, aiService *ai.AIService) *ReleaseService {
+	return &ReleaseService{git: gitService, ai: aiService}
+}
+
+// Changelog groups the commits after fromRef up to toRef by Conventional Commit type and
+// renders them as Markdown; with useAI the model rewrites the notes for readers
+// toRef defaults to HEAD and fromRef to the latest tag before toRef, or the whole history
+func (r *ReleaseService) Changelog(fromRef, toRef string, useAI bool) (*models.Changelog, error) {
+	if strings.HasPrefix(fromRef, "-") || strings.HasPrefix(toRef, "-") {
+		return nil, fmt.Errorf("invalid ref")
+	}
+	if toRef == "" {
+		toRef = "HEAD"
+	}
+	if fromRef == "" {
+		tag, err := r.git.LatestTag(toRef)
+		if err != nil {
+			return nil, err
+		}
+		fromRef = tag
+	}
+
+	commits, err := r.git.CommitsBetween(fromRef, toRef)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits between %s and %s", fromRef, toRef)
+	}
+
+	changelog := &models.Changelog{
+		From:     fromRef,
+		To:       toRef,
+		Sections: groupCommits(commits),
+		Breaking: []models.ChangelogEntry{},
+	}
+	for _, section := range changelog.Sections {
+		for _, entry := range section.Entries {
+			if entry.Breaking {
+				changelog.Breaking = append(changelog.Breaking, entry)
+			}
+		}
+	}
+	changelog.Markdown = renderMarkdown(changelog)
+
+	if useAI {
+		notes, err := r.ai.GenerateReleaseNotes(changelog.Markdown)
+		if err != nil {
+			return nil, err
+		}
+		changelog.Markdown = notes
+		changelog.AIGenerated = true
+	}
+	return changelog, nil
+}
+
+// groupCommits sorts commits into one section per type, keeping their order within each
+func groupCommits(commits []models.BranchCommit) []models.ChangelogSection {
+	entries := map[string][]models.ChangelogEntry{}
+	for _, c := range commits {
+		typ, entry := parseCommit(c)
+		entries[typ] = append(entries[typ], entry)
+	}
+
+	sections := []models.ChangelogSection{}
+	for _, s := range sectionTitles {
+		if len(entries[s.typ]) > 0 {
+			sections = append(sections, models.ChangelogSection{Type: s.typ, Title: s.title, Entries: entries[s.typ]})
+		}
+	}
+	return sections
+}
+
+// parseCommit returns the type of a commit and its changelog entry
+func parseCommit(c models.BranchCommit) (string, models.ChangelogEntry) {
+	entry := models.ChangelogEntry{
+		Hash:        c.Hash,
+		ShortHash:   c.ShortHash,
+		Description: c.Subject,
+		Breaking:    breakingPattern.MatchString(c.Body),
+	}
+	subject, ok := ai.ParseConventional(c.Subject)
+	if !ok {
+		return otherType, entry
+	}
+	entry.Scope = subject.Scope
+	entry.Breaking = entry.Breaking || subject.Breaking
+	entry.Description = subject.Description
+
+	typ := strings.ToLower(subject.Type)
+	for _, s := range sectionTitles {
+		if s.typ == typ {
+			return typ, entry
+		}
+	}
+	return otherType, entry
+}
+
+// renderMarkdown writes the changelog as Markdown, breaking changes first
+func renderMarkdown(c *models.Changelog) string {
+	var b strings.Builder
+	title := c.To
+	if c.To == "HEAD" {
+		title = "未发布"
+	}
+	fmt.Fprintf(&b, "## %s\n", title)
+	if c.From != "" {
+		fmt.Fprintf(&b, "\n自 %s 以来的改动\n", c.From)
+	}
+
+	if len(c.Breaking) > 0 {
+		b.WriteString("\n### ⚠️ 破坏性变更\n\n")
+		for _, e := range c.Breaking {
+			writeEntry(&b, e)
+		}
+	}
+	for _, s := range c.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", s.Title)
+		for _, e := range s.Entries {
+			writeEntry(&b, e)
+		}
+	}
+	return b.String()
+}
+
+// writeEntry writes one changelog line, "- **scope:** description (hash)"
+func writeEntry(b *strings.Builder, e models.ChangelogEntry) {
+	b.WriteString("- ")
+	if e.Scope != "" {
+		fmt.Fprintf(b, "**%s:** ", e.Scope)
+	}
+	fmt.Fprintf(b, "%s (%s)\n", e.Description, e.ShortHash)
+}
@@ -0,0 +1,176 @@
+package semantic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"git-ai-tools/internal/ai"
+	"git-ai-tools/internal/database"
+	"git-ai-tools/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// batchSize is how many commit messages are embedded per request
+	batchSize = 64
+	// maxMessageRunes truncates long messages, which embedding models would reject
+	maxMessageRunes = 2000
+)
+
+// Index keeps embeddings of commit messages in the database so commits can be searched
+// by meaning; each repository is indexed per embedding model
+type Index struct {
+	ai *ai.AIService
+	mu sync.Mutex
+}
+
+// NewIndex creates an Index that computes embeddings with the given AI service
+func NewIndex(aiService *ai.AIService) *Index {
+	return &Index{ai: aiService}
+}
+
+// Refresh embeds the commits that are not indexed yet for the current model and returns
+// how many were added; batches stored before an error are kept
+func (x *Index) Refresh(repoPath string, commits []models.CommitInfo) (int, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	model := x.ai.EmbeddingModel()
+	var hashes []string
+	if err := database.GetDB().Model(&models.CommitEmbeddingDB{}).
+		Where("repo_path = ? AND model = ?", repoPath, model).Pluck("hash", &hashes).Error; err != nil {
+		return 0, fmt.Errorf("failed to read index: %w", err)
+	}
+	known := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		known[h] = true
+	}
+
+	var missing []models.CommitInfo
+	for _, c := range commits {
+		if !known[c.Hash] && c.Message != "" {
+			missing = append(missing, c)
+		}
+	}
+
+	added := 0
+	for start := 0; start < len(missing); start += batchSize {
+		batch := missing[start:min(start+batchSize, len(missing))]
+		texts := make([]string, len(batch))
+		for i, c := range batch {
+			texts[i] = truncate(c.Message)
+		}
+		vectors, err := x.ai.Embed(texts)
+		if err != nil {
+			return added, err
+		}
+
+		rows := make([]models.CommitEmbeddingDB, len(batch))
+		for i, c := range batch {
+			rows[i] = models.CommitEmbeddingDB{
+				ID:       uuid.New().String(),
+				RepoPath: repoPath,
+				Model:    model,
+				Hash:     c.Hash,
+				Message:  c.Message,
+				Author:   c.Author,
+				Date:     c.Date,
+				Vector:   encodeVector(vectors[i]),
+			}
+		}
+		if err := database.GetDB().Create(&rows).Error; err != nil {
+			return added, fmt.Errorf("failed to save embeddings: %w", err)
+		}
+		added += len(rows)
+	}
+	return added, nil
+}
+
+// Search returns the indexed commits most similar in meaning to the query, best first
+func (x *Index) Search(repoPath, query string, limit int) ([]models.SemanticMatch, error) {
+	vectors, err := x.ai.Embed([]string{query})
+	if err != nil {
+		return nil, err
+	}
+	target := vectors[0]
+
+	var rows []models.CommitEmbeddingDB
+	if err := database.GetDB().Where("repo_path = ? AND model = ?", repoPath, x.ai.EmbeddingModel()).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	matches := make([]models.SemanticMatch, 0, len(rows))
+	for _, r := range rows {
+		matches = append(matches, models.SemanticMatch{
+			Hash:      r.Hash,
+			ShortHash: r.Hash[:min(7, len(r.Hash))],
+			Message:   r.Message,
+			Author:    r.Author,
+			Date:      r.Date,
+			Score:     cosine(target, decodeVector(r.Vector)),
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// Clear removes the index of a repository for all models
+func (x *Index) Clear(repoPath string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return database.GetDB().Where("repo_path = ?", repoPath).Delete(&models.CommitEmbeddingDB{}).Error
+}
+
+// truncate shortens a message to maxMessageRunes
+func truncate(message string) string {
+	runes := []rune(message)
+	if len(runes) <= maxMessageRunes {
+		return message
+	}
+	return string(runes[:maxMessageRunes])
+}
+
+// encodeVector packs a vector as little-endian float32 values
+func encodeVector(v []float32) []byte {
+	buf := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(f))
+	}
+	return buf
+}
+
+// decodeVector unpacks a vector stored by encodeVector
+func decodeVector(buf []byte) []float32 {
+	v := make([]float32, len(buf)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return v
+}
+
+// cosine returns the cosine similarity of two vectors, 0 when their sizes differ
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
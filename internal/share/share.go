@@ -0,0 +1,181 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/git"
+	"git-ai-tools/internal/models"
+)
+
+// ShareService serves one repository over git's smart HTTP protocol for LAN clones
+type ShareService struct {
+	mu     sync.Mutex
+	server *http.Server
+	info   models.ShareInfo
+}
+
+// NewShareService creates a new ShareService instance
+func NewShareService() *ShareService {
+	return &ShareService{}
+}
+
+// shareUser is the basic auth user name of push URLs, only the token is checked
+const shareUser = "git"
+
+// Start serves repoPath on port through git http-backend, replacing a running share
+// A port of 0 picks a free one. Read-only shares refuse pushes, writable ones accept them
+// only with the token generated for this share
+func (s *ShareService) Start(repoPath string, port int, readOnly bool) (*models.ShareInfo, error) {
+	if repoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	gitPath, err := exec.LookPath(git.Executable())
+	if err != nil {
+		return nil, fmt.Errorf("git executable not found: %w", err)
+	}
+
+	var token string
+	if !readOnly {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate share token: %w", err)
+		}
+		token = hex.EncodeToString(buf)
+	}
+
+	s.Stop()
+
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	port = listener.Addr().(*net.TCPAddr).Port
+
+	// Requests are matched on the decoded path, the clone URL uses the escaped one
+	name := filepath.Base(repoPath)
+	prefix := "/" + name
+
+	env := []string{
+		"GIT_PROJECT_ROOT=" + filepath.Dir(repoPath),
+		"GIT_HTTP_EXPORT_ALL=1",
+	}
+	if !readOnly {
+		env = append(env, "GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=http.receivepack", "GIT_CONFIG_VALUE_0=true")
+	}
+	backend := &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Dir:  repoPath,
+		Env:  env,
+	}
+
+	// Only the shared repository is reachable, not its siblings under the project root
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != prefix && !strings.HasPrefix(r.URL.Path, prefix+"/") {
+			http.NotFound(w, r)
+			return
+		}
+		if isPush(r) {
+			if readOnly {
+				http.Error(w, "repository is shared read-only", http.StatusForbidden)
+				return
+			}
+			if _, password, ok := r.BasicAuth(); !ok || subtle.ConstantTimeCompare([]byte(password), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+				http.Error(w, "pushing requires the share token", http.StatusUnauthorized)
+				return
+			}
+		}
+		backend.ServeHTTP(w, r)
+	})
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 30 * time.Second}
+	go server.Serve(listener)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.server = server
+	host := net.JoinHostPort(lanAddress(), strconv.Itoa(port))
+	s.info = models.ShareInfo{
+		Active:    true,
+		Repo:      repoPath,
+		Port:      port,
+		ReadOnly:  readOnly,
+		URL:       fmt.Sprintf("http://%s/%s", host, url.PathEscape(name)),
+		StartedAt: time.Now().Format(time.RFC3339),
+	}
+	if token != "" {
+		s.info.Token = token
+		s.info.PushURL = fmt.Sprintf("http://%s:%s@%s/%s", shareUser, token, host, url.PathEscape(name))
+	}
+	info := s.info
+	return &info, nil
+}
+
+// Stop shuts the running share down
+func (s *ShareService) Stop() error {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.info = models.ShareInfo{}
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
+
+// Status returns the running share, Active is false when nothing is shared
+func (s *ShareService) Status() models.ShareInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.info
+}
+
+// isPush reports whether a smart HTTP request belongs to a push
+func isPush(r *http.Request) bool {
+	return r.URL.Query().Get("service") == "git-receive-pack" ||
+		strings.HasSuffix(r.URL.Path, "/git-receive-pack")
+}
+
+// lanAddress returns the first non-loopback IPv4 address of an interface that is up
+func lanAddress() string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return "localhost"
+	}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				if ip := ipNet.IP.To4(); ip != nil {
+					return ip.String()
+				}
+			}
+		}
+	}
+	return "localhost"
+}
@@ -0,0 +1,93 @@
+package shellenv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// captureTimeout bounds how long a slow shell profile may delay startup
+const captureTimeout = 5 * time.Second
+
+// marker separates the environment from anything the shell profile prints
+const marker = "__GIT_AI_TOOLS_ENV__"
+
+var (
+	mu       sync.Mutex
+	basePath = os.Getenv("PATH")
+)
+
+// Load copies the environment of the user's login shell into the process on macOS,
+// where apps started from Finder miss the PATH entries of Homebrew git and ssh
+// Variables already set are kept, except PATH which is taken from the shell
+// It does nothing on other platforms
+func Load() error {
+	if runtime.GOOS != "darwin" {
+		return nil
+	}
+
+	env, err := capture()
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for key, value := range env {
+		if _, ok := os.LookupEnv(key); !ok || key == "PATH" {
+			os.Setenv(key, value)
+		}
+	}
+	basePath = os.Getenv("PATH")
+	return nil
+}
+
+// OverridePath replaces PATH for git and every other child process
+// An empty path restores the PATH from startup
+func OverridePath(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if path == "" {
+		path = basePath
+	}
+	os.Setenv("PATH", path)
+}
+
+// capture runs the login shell interactively, as a terminal would, and reads its environment
+func capture() (map[string]string, error) {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/zsh"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), captureTimeout)
+	defer cancel()
+	script := fmt.Sprintf("printf %s; /usr/bin/env -0; printf %s", marker, marker)
+	cmd := exec.CommandContext(ctx, shell, "-l", "-i", "-c", script)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the login shell environment: %w", err)
+	}
+
+	parts := bytes.Split(out, []byte(marker))
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("failed to read the login shell environment: no output")
+	}
+	env := map[string]string{}
+	for _, entry := range bytes.Split(parts[1], []byte{0}) {
+		if key, value, ok := strings.Cut(string(entry), "="); ok && key != "" {
+			env[key] = value
+		}
+	}
+	// Shell bookkeeping describes the capturing shell, not the app
+	for _, key := range []string{"_", "PWD", "OLDPWD", "SHLVL"} {
+		delete(env, key)
+	}
+	return env, nil
+}
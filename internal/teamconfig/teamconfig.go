@@ -0,0 +1,111 @@
+package teamconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"git-ai-tools/internal/models"
+)
+
+// FileName is the team configuration committed at the repository root
+const FileName = ".gitai.yml"
+
+// Load reads the team configuration of the repository at repoPath
+// It returns nil without an error when the repository has no .gitai.yml
+func Load(repoPath string) (*models.TeamConfig, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	values, err := parseYAML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+	}
+	// The parsed document maps onto the JSON form of the model; a misspelled key is an
+	// error rather than a setting that silently does nothing
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	config := &models.TeamConfig{}
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+	}
+	return config, nil
+}
+
+// ApplyStyle overrides the user's commit style with the settings the team file sets
+// Whether the style is applied at all stays the user's choice
+func ApplyStyle(style models.MessageStyle, team *models.TeamConfig) models.MessageStyle {
+	if team == nil || team.Commit == nil {
+		return style
+	}
+	commit := team.Commit
+	if commit.MaxSubjectLength != nil {
+		style.MaxSubjectLength = *commit.MaxSubjectLength
+	}
+	if commit.BodyWrapWidth != nil {
+		style.BodyWrapWidth = *commit.BodyWrapWidth
+	}
+	if commit.RequireType != nil {
+		style.RequireType = *commit.RequireType
+	}
+	if commit.DefaultType != nil {
+		style.DefaultType = *commit.DefaultType
+	}
+	if commit.Emoji != nil {
+		style.Emoji = *commit.Emoji
+	}
+	return style
+}
+
+// IsProtectedBranch reports whether branch matches one of the team's protected branch
+// patterns, which may use * wildcards such as release/*
+func IsProtectedBranch(team *models.TeamConfig, branch string) bool {
+	if team == nil || branch == "" {
+		return false
+	}
+	for _, pattern := range team.ProtectedBranches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeFor returns the commit scope shared by all paths, using the team's mapping of
+// directories to scopes with the longest matching directory winning
+// It returns "" when a path has no scope or the paths map to different scopes
+func ScopeFor(team *models.TeamConfig, paths []string) string {
+	if team == nil || len(team.Scopes) == 0 || len(paths) == 0 {
+		return ""
+	}
+
+	scope := ""
+	for i, p := range paths {
+		p = filepath.ToSlash(p)
+		best, bestLen := "", -1
+		for dir, s := range team.Scopes {
+			dir = strings.Trim(filepath.ToSlash(dir), "/")
+			if (p == dir || strings.HasPrefix(p, dir+"/")) && len(dir) > bestLen {
+				best, bestLen = s, len(dir)
+			}
+		}
+		if best == "" || (i > 0 && best != scope) {
+			return ""
+		}
+		scope = best
+	}
+	return scope
+}
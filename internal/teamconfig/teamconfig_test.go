@@ -0,0 +1,102 @@
+package teamconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git-ai-tools/internal/models"
+)
+
+// writeTeamFile writes content as the .gitai.yml of a new directory and returns it
+func writeTeamFile(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writeTeamFile(t, `# shared settings
+commit:
+  maxSubjectLength: 72
+  requireType: true
+  defaultType: chore
+protectedBranches: [main, release/*]
+scopes:
+  src/ui: ui
+setup:
+  - npm ci
+prompt: |
+  请用中文
+`)
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Commit == nil || *config.Commit.MaxSubjectLength != 72 || !*config.Commit.RequireType || *config.Commit.DefaultType != "chore" {
+		t.Errorf("commit style = %+v", config.Commit)
+	}
+	if config.Commit.Emoji != nil || config.Commit.BodyWrapWidth != nil {
+		t.Errorf("unset settings should stay nil: %+v", config.Commit)
+	}
+	if !reflect.DeepEqual(config.ProtectedBranches, []string{"main", "release/*"}) {
+		t.Errorf("protectedBranches = %q", config.ProtectedBranches)
+	}
+	if !reflect.DeepEqual(config.Scopes, map[string]string{"src/ui": "ui"}) {
+		t.Errorf("scopes = %v", config.Scopes)
+	}
+	if !reflect.DeepEqual(config.Setup, []string{"npm ci"}) || config.Prompt != "请用中文\n" {
+		t.Errorf("setup = %q, prompt = %q", config.Setup, config.Prompt)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	config, err := Load(t.TempDir())
+	if config != nil || err != nil {
+		t.Errorf("Load without a file = %v, %v; want nil, nil", config, err)
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name, content, want string
+	}{
+		{"unknown key", "prompts: default\n", `unknown field "prompts"`},
+		{"unknown nested key", "commit:\n  maxSubjectLenght: 50\n", `unknown field "maxSubjectLenght"`},
+		{"wrong type", "commit:\n  maxSubjectLength: long\n", "invalid " + FileName},
+		{"syntax error", "commit:\n  - a\n  b: 1\n", "invalid " + FileName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeTeamFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyStyle(t *testing.T) {
+	length, emoji := 50, true
+	team := &models.TeamConfig{Commit: &models.TeamCommitStyle{MaxSubjectLength: &length, Emoji: &emoji}}
+
+	user := models.MessageStyle{Enabled: false, MaxSubjectLength: 100, BodyWrapWidth: 72, DefaultType: "feat"}
+	got := ApplyStyle(user, team)
+	want := models.MessageStyle{Enabled: false, MaxSubjectLength: 50, BodyWrapWidth: 72, DefaultType: "feat", Emoji: true}
+	if got != want {
+		t.Errorf("ApplyStyle = %+v, want %+v", got, want)
+	}
+
+	user.Enabled = true
+	if got := ApplyStyle(user, team); !got.Enabled {
+		t.Error("ApplyStyle turned an enabled style off")
+	}
+	if got := ApplyStyle(user, nil); got != user {
+		t.Errorf("ApplyStyle without a team file = %+v, want %+v", got, user)
+	}
+}
@@ -0,0 +1,291 @@
+package teamconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYAML reads the subset of YAML used by .gitai.yml: nested mappings, lists of
+// scalars, flow lists, quoted strings, literal (|) and folded (>) blocks and comments
+// Anchors, multi-document files and lists of mappings are not supported
+func parseYAML(data string) (map[string]interface{}, error) {
+	p := &yamlParser{lines: strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")}
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return map[string]interface{}{}, nil
+	}
+	if indent, _ := p.current(); indent != 0 {
+		return nil, p.errorf("unexpected indentation")
+	}
+	value, err := p.parseMap(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return value, nil
+}
+
+// yamlParser walks the lines of a document, pos is the next line to read
+type yamlParser struct {
+	lines []string
+	pos   int
+}
+
+// current returns the indentation and trimmed text of the line at pos
+func (p *yamlParser) current() (int, string) {
+	line := p.lines[p.pos]
+	text := strings.TrimLeft(line, " ")
+	return len(line) - len(text), strings.TrimRight(text, " \t")
+}
+
+// skipBlank moves pos past empty and comment-only lines
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) {
+		if _, text := p.current(); text != "" && !strings.HasPrefix(text, "#") {
+			return
+		}
+		p.pos++
+	}
+}
+
+// errorf reports a problem at the current line
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// parseMap reads the "key: value" lines indented by exactly indent
+func (p *yamlParser) parseMap(indent int) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return result, nil
+		}
+		lineIndent, text := p.current()
+		if lineIndent < indent {
+			return result, nil
+		}
+		if lineIndent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if strings.HasPrefix(text, "- ") || text == "-" {
+			return nil, p.errorf("expected a key")
+		}
+
+		key, rest, ok := cutKey(text)
+		if !ok {
+			return nil, p.errorf("expected \"key: value\"")
+		}
+		if _, exists := result[key]; exists {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		p.pos++
+
+		value, err := p.parseValue(indent, stripComment(rest))
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+}
+
+// parseValue reads the value of a key at indent whose inline text is rest
+func (p *yamlParser) parseValue(indent int, rest string) (interface{}, error) {
+	if strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">") {
+		return p.parseBlockScalar(indent, rest), nil
+	}
+	if rest != "" {
+		value, err := parseScalar(rest)
+		if err != nil {
+			// pos has moved past the key's line
+			return nil, fmt.Errorf("line %d: %w", p.pos, err)
+		}
+		return value, nil
+	}
+
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	childIndent, text := p.current()
+	isList := strings.HasPrefix(text, "- ") || text == "-"
+	switch {
+	case childIndent > indent && isList:
+		return p.parseList(childIndent)
+	case childIndent > indent:
+		return p.parseMap(childIndent)
+	case childIndent == indent && isList:
+		// A list may sit at the same indentation as its key
+		return p.parseList(childIndent)
+	}
+	return nil, nil
+}
+
+// parseList reads the "- item" lines indented by exactly indent
+func (p *yamlParser) parseList(indent int) ([]interface{}, error) {
+	result := []interface{}{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return result, nil
+		}
+		lineIndent, text := p.current()
+		if lineIndent != indent || !(strings.HasPrefix(text, "- ") || text == "-") {
+			if lineIndent > indent {
+				return nil, p.errorf("unexpected indentation")
+			}
+			return result, nil
+		}
+
+		item := stripComment(strings.TrimSpace(strings.TrimPrefix(text, "-")))
+		if _, _, isMap := cutKey(item); isMap && !strings.HasPrefix(item, `"`) && !strings.HasPrefix(item, "'") {
+			return nil, p.errorf("lists of mappings are not supported")
+		}
+		value, err := parseScalar(item)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		p.pos++
+		result = append(result, value)
+	}
+}
+
+// parseBlockScalar reads a literal (|) or folded (>) block below a key at indent
+// The "-" chomping indicator drops the final newline, otherwise one is kept
+func (p *yamlParser) parseBlockScalar(indent int, header string) string {
+	var lines []string
+	blockIndent := -1
+	for p.pos < len(p.lines) {
+		line := strings.TrimRight(p.lines[p.pos], " \t")
+		text := strings.TrimLeft(line, " ")
+		lineIndent := len(line) - len(text)
+		if text != "" {
+			if lineIndent <= indent {
+				break
+			}
+			if blockIndent < 0 {
+				blockIndent = lineIndent
+			}
+			if lineIndent < blockIndent {
+				break
+			}
+			line = line[blockIndent:]
+		} else {
+			line = ""
+		}
+		lines = append(lines, line)
+		p.pos++
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var value string
+	if strings.HasPrefix(header, ">") {
+		// Folding joins lines with spaces and keeps blank lines as line breaks
+		var b strings.Builder
+		for i, line := range lines {
+			switch {
+			case line == "":
+				b.WriteString("\n")
+			case i > 0 && lines[i-1] != "":
+				b.WriteString(" " + line)
+			default:
+				b.WriteString(line)
+			}
+		}
+		value = b.String()
+	} else {
+		value = strings.Join(lines, "\n")
+	}
+	if !strings.Contains(header, "-") && value != "" {
+		value += "\n"
+	}
+	return value
+}
+
+// cutKey splits "key: value" or "key:" into its key and the text after the colon
+func cutKey(text string) (string, string, bool) {
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ') {
+			key := strings.TrimSpace(text[:i])
+			if key == "" {
+				return "", "", false
+			}
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// stripComment removes a trailing " # comment" outside of quotes
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return strings.TrimSpace(text[:i])
+		}
+	}
+	return text
+}
+
+// parseScalar converts an inline value to a string, bool, int, nil or flow list
+func parseScalar(text string) (interface{}, error) {
+	switch {
+	case text == "" || text == "~" || text == "null":
+		return nil, nil
+	case text == "true":
+		return true, nil
+	case text == "false":
+		return false, nil
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted string: %s", text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid quoted string: %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("invalid list: %s", text)
+		}
+		items := []interface{}{}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		if inner == "" {
+			return items, nil
+		}
+		for _, part := range strings.Split(inner, ",") {
+			item, err := parseScalar(strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		return n, nil
+	}
+	return text, nil
+}
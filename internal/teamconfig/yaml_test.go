@@ -0,0 +1,94 @@
+package teamconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want map[string]interface{}
+	}{
+		{"empty", "", map[string]interface{}{}},
+		{"only comments", "# team settings\n\n  # nothing yet\n", map[string]interface{}{}},
+		{"scalars", "name: app\ncount: 3\nenabled: true\noff: false\nnone: ~\nalso: null\n",
+			map[string]interface{}{"name": "app", "count": 3, "enabled": true, "off": false, "none": nil, "also": nil}},
+		{"crlf line endings", "a: 1\r\nb: two\r\n", map[string]interface{}{"a": 1, "b": "two"}},
+		{"nested mappings", "commit:\n  maxSubjectLength: 72\n  style:\n    emoji: true\nprompt: default\n",
+			map[string]interface{}{
+				"commit": map[string]interface{}{
+					"maxSubjectLength": 72,
+					"style":            map[string]interface{}{"emoji": true},
+				},
+				"prompt": "default",
+			}},
+		{"indented list", "setup:\n  - npm ci\n  - make build\n",
+			map[string]interface{}{"setup": []interface{}{"npm ci", "make build"}}},
+		{"list at key indentation", "branches:\n- main\n- release/*\nnext: x\n",
+			map[string]interface{}{"branches": []interface{}{"main", "release/*"}, "next": "x"}},
+		{"flow list", "branches: [main, \"release/*\", 3]\nempty: []\n",
+			map[string]interface{}{"branches": []interface{}{"main", "release/*", 3}, "empty": []interface{}{}}},
+		{"comments after values", "a: 1 # one\nb: x#y\nc: \"quoted # kept\" # dropped\nd: 'also # kept'\n",
+			map[string]interface{}{"a": 1, "b": "x#y", "c": "quoted # kept", "d": "also # kept"}},
+		{"comment lines inside blocks", "setup:\n  # install first\n  - npm ci\n\n  - npm test # then test\n",
+			map[string]interface{}{"setup": []interface{}{"npm ci", "npm test"}}},
+		{"double quotes and escapes", `a: "line\nnext"` + "\n" + `b: "say \"hi\""` + "\n" + `c: "true"` + "\n" + `d: "42"` + "\n",
+			map[string]interface{}{"a": "line\nnext", "b": `say "hi"`, "c": "true", "d": "42"}},
+		{"single quotes", "a: 'it''s'\nb: ''\n", map[string]interface{}{"a": "it's", "b": ""}},
+		{"quoted key", "\"src/ui\": ui\n", map[string]interface{}{"src/ui": "ui"}},
+		{"colon inside value", "url: https://example.com:8080/x\n", map[string]interface{}{"url": "https://example.com:8080/x"}},
+		{"quoted list item with colon", "setup:\n  - \"echo a: b\"\n", map[string]interface{}{"setup": []interface{}{"echo a: b"}}},
+		{"literal block", "prompt: |\n  line one\n    indented\n\n  line three\nnext: x\n",
+			map[string]interface{}{"prompt": "line one\n  indented\n\nline three\n", "next": "x"}},
+		{"literal block strip", "prompt: |-\n  one\n  two\n", map[string]interface{}{"prompt": "one\ntwo"}},
+		{"folded block", "prompt: >\n  one\n  two\n\n  three\n", map[string]interface{}{"prompt": "one two\nthree\n"}},
+		{"folded block strip", "prompt: >-\n  one\n  two\n", map[string]interface{}{"prompt": "one two"}},
+		{"chinese text", "prompt: 生成中文提交信息\nscopes:\n  前端: ui\n",
+			map[string]interface{}{"prompt": "生成中文提交信息", "scopes": map[string]interface{}{"前端": "ui"}}},
+		{"key without value", "commit:\nprompt: x\n", map[string]interface{}{"commit": nil, "prompt": "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML(tt.doc)
+			if err != nil {
+				t.Fatalf("parseYAML failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseYAML(%q)\n got %#v\nwant %#v", tt.doc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"indented document", "  a: 1\n", "line 1: unexpected indentation"},
+		{"bad indentation", "a:\n  b: 1\n    c: 2\n", "line 3: unexpected indentation"},
+		{"duplicate key", "a: 1\na: 2\n", "line 2: duplicate key \"a\""},
+		{"not a mapping", "just text\n", "line 1: expected \"key: value\""},
+		{"top-level list", "- a\n", "line 1: expected a key"},
+		{"list of mappings", "items:\n  - name: a\n", "line 2: lists of mappings are not supported"},
+		{"unterminated double quote", "a: \"open\n", "line 1: invalid quoted string"},
+		{"unterminated single quote", "a: 'open\n", "line 1: invalid quoted string"},
+		{"unterminated flow list", "a: [x, y\n", "line 1: invalid list"},
+		{"list item indented further", "a:\n  - x\n    - y\n", "line 3: unexpected indentation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseYAML(tt.doc)
+			if err == nil {
+				t.Fatalf("parseYAML(%q) succeeded, want %q", tt.doc, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseYAML(%q) error = %q, want it to contain %q", tt.doc, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package trace
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git-ai-tools/internal/crash"
+	"git-ai-tools/internal/database"
+	"git-ai-tools/internal/logging"
+	"git-ai-tools/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of traced operations
+const (
+	KindGit = "git"
+	KindAI  = "ai"
+)
+
+const (
+	// retention is how long trace records are kept
+	retention = 30 * 24 * time.Hour
+	// pruneEvery is the number of writes between retention sweeps
+	pruneEvery = 500
+	// slowestLimit is the number of individual operations listed in a report
+	slowestLimit = 20
+	// maxErrorLength is the number of characters of an error kept in the log
+	maxErrorLength = 300
+)
+
+var (
+	enabled   atomic.Bool
+	queue     = make(chan models.OperationTraceDB, 256)
+	startOnce sync.Once
+
+	// active holds the user actions in progress, see Begin
+	activeMu sync.Mutex
+	active   = map[string]bool{}
+
+	scrubber     *crash.Scrubber
+	scrubberOnce sync.Once
+)
+
+type operationKey struct{}
+
+// SetEnabled turns recording on or off
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether operations are being recorded
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Begin starts a user action and returns a context carrying its operation ID
+// Spans started with the context are tagged with the ID; so are spans started without
+// one, such as git commands, while this is the only action in progress. Call end
+// when the action finishes
+func Begin(ctx context.Context, name string) (context.Context, func()) {
+	id := logging.NewID()
+	start := time.Now()
+	activeMu.Lock()
+	active[id] = true
+	activeMu.Unlock()
+	logging.Logger().Debug(name+" started", "op", id)
+
+	end := func() {
+		activeMu.Lock()
+		delete(active, id)
+		activeMu.Unlock()
+		logging.Logger().Debug(name+" finished", "op", id, "ms", time.Since(start).Milliseconds())
+	}
+	return context.WithValue(ctx, operationKey{}, id), end
+}
+
+// OperationID returns the ID of the user action ctx belongs to, or "" outside one
+func OperationID(ctx context.Context) string {
+	if id, ok := ctx.Value(operationKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// currentOperation returns the ID of the only user action in progress
+// With several actions running a span cannot be attributed, so it gets none
+func currentOperation() string {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	if len(active) != 1 {
+		return ""
+	}
+	for id := range active {
+		return id
+	}
+	return ""
+}
+
+// Span is a running operation tagged with a correlation ID and the ID of the user
+// action it belongs to
+type Span struct {
+	ID      string
+	op      string
+	kind    string
+	command string
+	repo    string
+	start   time.Time
+}
+
+// Start begins timing an operation
+func Start(kind, command, repo string) *Span {
+	return StartContext(context.Background(), kind, command, repo)
+}
+
+// StartContext begins timing an operation of the user action ctx belongs to
+func StartContext(ctx context.Context, kind, command, repo string) *Span {
+	op := OperationID(ctx)
+	if op == "" {
+		op = currentOperation()
+	}
+	span := &Span{
+		ID:      logging.NewID(),
+		op:      op,
+		kind:    kind,
+		command: command,
+		repo:    repo,
+		start:   time.Now(),
+	}
+	logging.Logger().Debug(command+" started", "id", span.ID, "op", op, "kind", kind, "repo", repo)
+	return span
+}
+
+// End logs the outcome of the operation and records its duration
+func (s *Span) End(err error) {
+	elapsed := time.Since(s.start)
+	attrs := []any{"id", s.ID, "op", s.op, "kind", s.kind, "repo", s.repo, "ms", elapsed.Milliseconds()}
+	if err != nil {
+		logging.Logger().Error(s.command+" failed", append(attrs, "error", scrubError(err))...)
+	} else {
+		logging.Logger().Info(s.command, attrs...)
+	}
+
+	record(s, elapsed, err)
+}
+
+// scrubError returns the text of err with credentials and paths removed, cut to
+// maxErrorLength characters since errors may carry command output and replies
+func scrubError(err error) string {
+	scrubberOnce.Do(func() { scrubber = crash.NewScrubber(nil) })
+	text := scrubber.Scrub(err.Error())
+	if runes := []rune(text); len(runes) > maxErrorLength {
+		text = string(runes[:maxErrorLength]) + "…"
+	}
+	return text
+}
+
+// record stores the duration and outcome of an operation
+// Writes happen in the background and are dropped when the writer falls behind,
+// so tracing never slows down the operation itself
+func record(s *Span, elapsed time.Duration, err error) {
+	if !enabled.Load() || database.GetDB() == nil {
+		return
+	}
+	startOnce.Do(func() { go writer() })
+
+	entry := models.OperationTraceDB{
+		ID:         uuid.New().String(),
+		Operation:  s.op,
+		Kind:       s.kind,
+		Command:    s.command,
+		Repo:       s.repo,
+		DurationMs: elapsed.Milliseconds(),
+		Success:    err == nil,
+		CreatedAt:  time.Now(),
+	}
+
+	select {
+	case queue <- entry:
+	default:
+	}
+}
+
+// writer persists queued records and periodically drops expired ones
+func writer() {
+	written := 0
+	for entry := range queue {
+		if err := database.GetDB().Create(&entry).Error; err != nil {
+			continue
+		}
+		written++
+		if written%pruneEvery == 1 {
+			database.GetDB().Where("created_at < ?", time.Now().Add(-retention)).Delete(&models.OperationTraceDB{})
+		}
+	}
+}
+
+// Report aggregates the operations recorded in the last days days
+func Report(days int) (*models.PerformanceReport, error) {
+	if days <= 0 {
+		days = 7
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	db := database.GetDB()
+
+	report := &models.PerformanceReport{
+		Enabled:    enabled.Load(),
+		Since:      since.Format(time.RFC3339),
+		Operations: []models.OperationStats{},
+		Slowest:    []models.OperationTrace{},
+	}
+
+	err := db.Model(&models.OperationTraceDB{}).
+		Select("kind, command, COUNT(*) AS count, "+
+			"SUM(CASE WHEN success THEN 0 ELSE 1 END) AS failures, "+
+			"AVG(duration_ms) AS avg_ms, MAX(duration_ms) AS max_ms, SUM(duration_ms) AS total_ms").
+		Where("created_at >= ?", since).
+		Group("kind, command").
+		Order("total_ms DESC").
+		Scan(&report.Operations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var slowest []models.OperationTraceDB
+	if err := db.Where("created_at >= ?", since).Order("duration_ms DESC").Limit(slowestLimit).Find(&slowest).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range slowest {
+		report.Slowest = append(report.Slowest, models.OperationTrace{
+			Operation:  t.Operation,
+			Kind:       t.Kind,
+			Command:    t.Command,
+			Repo:       t.Repo,
+			DurationMs: t.DurationMs,
+			Success:    t.Success,
+			CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return report, nil
+}
+
+// CommandStats aggregates the recorded runs of one command in one repository
+// It returns nil when the command was not recorded in the last days days
+func CommandStats(command, repo string, days int) (*models.OperationStats, error) {
+	if days <= 0 {
+		days = 7
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	var stats []models.OperationStats
+	err := database.GetDB().Model(&models.OperationTraceDB{}).
+		Select("kind, command, COUNT(*) AS count, "+
+			"SUM(CASE WHEN success THEN 0 ELSE 1 END) AS failures, "+
+			"AVG(duration_ms) AS avg_ms, MAX(duration_ms) AS max_ms, SUM(duration_ms) AS total_ms").
+		Where("created_at >= ? AND command = ? AND repo = ?", since, command, repo).
+		Group("kind, command").
+		Scan(&stats).Error
+	if err != nil || len(stats) == 0 {
+		return nil, err
+	}
+	return &stats[0], nil
+}
+
+// Clear deletes all recorded operations
+func Clear() error {
+	return database.GetDB().Where("1 = 1").Delete(&models.OperationTraceDB{}).Error
+}
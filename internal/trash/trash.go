@@ -0,0 +1,211 @@
+package trash
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-ai-tools/internal/fsutil"
+	"git-ai-tools/internal/models"
+)
+
+// Kinds of content moved to the trash
+const (
+	KindDiscard = "discard"
+	KindClean   = "clean"
+)
+
+const (
+	// maxEntries is the number of trash entries kept per repository
+	maxEntries = 50
+	// retention is how long trash entries are kept
+	retention = 30 * 24 * time.Hour
+	// maxFileSize is the largest removed file that is kept, bigger files are listed as skipped
+	maxFileSize = 20 * 1024 * 1024
+
+	metaFile  = "meta.json"
+	patchFile = "changes.patch"
+	filesDir  = "files"
+)
+
+// Store saves content removed from repo, a patch of discarded changes and/or removed files
+// files maps repository-relative paths to the files on disk, which are copied one at a time
+func Store(repo, kind string, paths []string, patch []byte, files map[string]string) (*models.DiscardedEntry, error) {
+	if len(patch) == 0 && len(files) == 0 {
+		return nil, nil
+	}
+
+	id := strconv.FormatInt(time.Now().UnixNano(), 10)
+	dir := filepath.Join(repoDir(repo), id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trash entry: %w", err)
+	}
+
+	entry := &models.DiscardedEntry{
+		ID:        id,
+		Kind:      kind,
+		Repo:      repo,
+		Paths:     paths,
+		Files:     []string{},
+		Skipped:   []string{},
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+
+	if len(patch) > 0 {
+		if err := fsutil.WriteFileAtomic(filepath.Join(dir, patchFile), patch, 0644); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		entry.HasPatch = true
+		entry.Size += int64(len(patch))
+	}
+
+	for name, source := range files {
+		info, err := os.Stat(source)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if info.Size() > maxFileSize {
+			entry.Skipped = append(entry.Skipped, name)
+			continue
+		}
+		target := filepath.Join(dir, filesDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to create trash entry: %w", err)
+		}
+		if err := fsutil.CopyFileAtomic(source, target); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		entry.Files = append(entry.Files, name)
+		entry.Size += info.Size()
+	}
+	sort.Strings(entry.Skipped)
+	sort.Strings(entry.Files)
+
+	meta, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if err := fsutil.WriteFileAtomic(filepath.Join(dir, metaFile), meta, 0644); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	prune(repo)
+	return entry, nil
+}
+
+// List returns the trash entries of repo, newest first
+func List(repo string) ([]models.DiscardedEntry, error) {
+	dirs, err := os.ReadDir(repoDir(repo))
+	if os.IsNotExist(err) {
+		return []models.DiscardedEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	entries := []models.DiscardedEntry{}
+	for _, d := range dirs {
+		entry, err := Get(repo, d.Name())
+		if err != nil {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+// Get returns a trash entry of repo
+func Get(repo, id string) (*models.DiscardedEntry, error) {
+	dir, err := entryDir(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, metaFile))
+	if err != nil {
+		return nil, fmt.Errorf("trash entry not found: %s", id)
+	}
+	var entry models.DiscardedEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("trash entry is corrupt: %w", err)
+	}
+	return &entry, nil
+}
+
+// Patch returns the saved patch of a trash entry, empty when it only holds files
+func Patch(repo, id string) ([]byte, error) {
+	dir, err := entryDir(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, patchFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// File returns the saved content of a removed file
+func File(repo, id, name string) ([]byte, error) {
+	dir, err := entryDir(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(dir, filesDir, filepath.FromSlash(name)))
+}
+
+// Remove deletes a trash entry
+func Remove(repo, id string) error {
+	dir, err := entryDir(repo, id)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// prune drops entries beyond maxEntries or older than retention
+func prune(repo string) {
+	entries, err := List(repo)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-retention).UnixNano()
+	for i, entry := range entries {
+		created, _ := strconv.ParseInt(entry.ID, 10, 64)
+		if i >= maxEntries || created < cutoff {
+			Remove(repo, entry.ID)
+		}
+	}
+}
+
+// entryDir returns the directory of a trash entry, rejecting IDs that are not entry names
+func entryDir(repo, id string) (string, error) {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid trash id: %s", id)
+	}
+	return filepath.Join(repoDir(repo), id), nil
+}
+
+// repoDir returns the trash directory of a repository under the config directory
+func repoDir(repo string) string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+	sum := sha1.Sum([]byte(filepath.Clean(repo)))
+	return filepath.Join(configDir, "git-ai-tools", "trash", hex.EncodeToString(sum[:])[:16])
+}
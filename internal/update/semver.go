@@ -0,0 +1,88 @@
+package update
+
+import (
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed version such as v1.4.0-beta.2; build metadata is ignored
+type semver struct {
+	major, minor, patch int
+	pre                 []string
+}
+
+// parseSemver parses a version with an optional "v" prefix, missing minor and patch count as 0
+func parseSemver(v string) (semver, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	core, pre, hasPre := strings.Cut(v, "-")
+
+	var s semver
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return semver{}, false
+	}
+	nums := []*int{&s.major, &s.minor, &s.patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		*nums[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return semver{}, false
+		}
+		s.pre = strings.Split(pre, ".")
+	}
+	return s, true
+}
+
+// compare returns -1, 0 or 1 following semver precedence, a release ranks above its prereleases
+func (a semver) compare(b semver) int {
+	for _, d := range [][2]int{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if d[0] != d[1] {
+			return cmpInt(d[0], d[1])
+		}
+	}
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		x, xErr := strconv.Atoi(a.pre[i])
+		y, yErr := strconv.Atoi(b.pre[i])
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				return cmpInt(x, y)
+			}
+		case xErr == nil:
+			// Numeric identifiers rank below alphanumeric ones
+			return -1
+		case yErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(a.pre[i], b.pre[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(a.pre), len(b.pre))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
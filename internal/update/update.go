@@ -0,0 +1,412 @@
+package update
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/models"
+)
+
+// Version is the version of this build, set with
+// -ldflags "-X git-ai-tools/internal/update.Version=v1.2.3"
+var Version = "dev"
+
+// releasesURL lists the project's releases, newest first
+const releasesURL = "https://api.github.com/repos/issueye/git_tools/releases"
+
+// checksumAssets are the release assets holding "sha256  name" lines for every artifact
+var checksumAssets = []string{"checksums.txt", "SHA256SUMS", "sha256sums.txt"}
+
+// UpdateService finds new releases, downloads and verifies them, and swaps the
+// executable when the app exits
+type UpdateService struct {
+	client *http.Client
+
+	mu         sync.Mutex
+	latest     *release
+	downloaded string
+	install    bool
+}
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+type release struct {
+	TagName     string         `json:"tag_name"`
+	Body        string         `json:"body"`
+	HTMLURL     string         `json:"html_url"`
+	Draft       bool           `json:"draft"`
+	Prerelease  bool           `json:"prerelease"`
+	PublishedAt string         `json:"published_at"`
+	Assets      []releaseAsset `json:"assets"`
+}
+
+// NewUpdateService creates a new UpdateService instance
+func NewUpdateService() *UpdateService {
+	return &UpdateService{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Check finds the newest release of the channel; the beta channel includes prereleases
+// Development builds never report an update
+func (s *UpdateService) Check(channel models.UpdateChannel) (*models.UpdateInfo, error) {
+	req, err := http.NewRequest("GET", releasesURL+"?per_page=30", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("releases request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var releases []release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	var best *release
+	var bestVersion semver
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && channel != models.UpdateBeta) {
+			continue
+		}
+		v, ok := parseSemver(r.TagName)
+		if !ok {
+			continue
+		}
+		if best == nil || v.compare(bestVersion) > 0 {
+			best, bestVersion = r, v
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if best == nil {
+		s.latest = nil
+		return &models.UpdateInfo{CurrentVersion: Version}, nil
+	}
+	current, ok := parseSemver(Version)
+	available := ok && bestVersion.compare(current) > 0
+	if available {
+		if s.latest == nil || s.latest.TagName != best.TagName {
+			s.downloaded, s.install = "", false
+		}
+		s.latest = best
+	} else {
+		s.latest = nil
+	}
+	return s.info(best, available), nil
+}
+
+// Download fetches the platform artifact of the release found by Check and verifies its
+// SHA-256 against the checksums published with the release
+func (s *UpdateService) Download() (*models.UpdateInfo, error) {
+	s.mu.Lock()
+	latest := s.latest
+	s.mu.Unlock()
+	if latest == nil {
+		return nil, fmt.Errorf("no update available, check for updates first")
+	}
+	asset := platformAsset(latest.Assets)
+	if asset == nil {
+		return nil, fmt.Errorf("release %s has no build for %s", latest.TagName, platformName())
+	}
+	want, err := s.checksum(latest.Assets, asset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := updateDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create update directory: %w", err)
+	}
+	dest := filepath.Join(dir, asset.Name)
+	if err := s.fetchVerified(asset.URL, dest, want); err != nil {
+		return nil, err
+	}
+	if err := checkExecutable(dest); err != nil {
+		os.Remove(dest)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.downloaded = dest
+	return s.info(latest, true), nil
+}
+
+// InstallOnExit marks the downloaded update to replace the executable when the app exits
+func (s *UpdateService) InstallOnExit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.downloaded == "" {
+		return fmt.Errorf("no update downloaded")
+	}
+	s.install = true
+	return nil
+}
+
+// InstallPending replaces the running executable with the downloaded update if one was
+// marked for install. The old executable is renamed aside, which works even while it runs,
+// and removed by Cleanup on the next start
+func (s *UpdateService) InstallPending() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.install || s.downloaded == "" {
+		return nil
+	}
+	s.install = false
+	if err := checkExecutable(s.downloaded); err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return fmt.Errorf("failed to move old executable: %w", err)
+	}
+	if err := copyExecutable(s.downloaded, exe); err != nil {
+		// Put the old executable back so the app still starts
+		os.Rename(old, exe)
+		return err
+	}
+	os.Remove(s.downloaded)
+	return nil
+}
+
+// Cleanup removes the executable left behind by the last install
+func Cleanup() {
+	if exe, err := os.Executable(); err == nil {
+		if exe, err = filepath.EvalSymlinks(exe); err == nil {
+			os.Remove(exe + ".old")
+		}
+	}
+}
+
+// info builds the UpdateInfo of r; callers must hold mu
+func (s *UpdateService) info(r *release, available bool) *models.UpdateInfo {
+	info := &models.UpdateInfo{
+		CurrentVersion: Version,
+		LatestVersion:  r.TagName,
+		Available:      available,
+		Prerelease:     r.Prerelease,
+		Notes:          r.Body,
+		ReleaseURL:     r.HTMLURL,
+		PublishedAt:    r.PublishedAt,
+		Downloaded:     available && s.downloaded != "",
+		InstallPending: available && s.install,
+	}
+	if asset := platformAsset(r.Assets); asset != nil {
+		info.AssetName = asset.Name
+		info.AssetURL = asset.URL
+		info.Size = asset.Size
+	}
+	return info
+}
+
+// checksum returns the published SHA-256 of the named asset, from "<name>.sha256" or a
+// checksums file; a release without one cannot be installed
+func (s *UpdateService) checksum(assets []releaseAsset, name string) (string, error) {
+	for _, a := range assets {
+		if a.Name == name+".sha256" {
+			body, err := s.fetchSmall(a.URL)
+			if err != nil {
+				return "", err
+			}
+			if fields := strings.Fields(body); len(fields) > 0 {
+				return strings.ToLower(fields[0]), nil
+			}
+		}
+	}
+	for _, a := range assets {
+		for _, sums := range checksumAssets {
+			if !strings.EqualFold(a.Name, sums) {
+				continue
+			}
+			body, err := s.fetchSmall(a.URL)
+			if err != nil {
+				return "", err
+			}
+			scanner := bufio.NewScanner(strings.NewReader(body))
+			for scanner.Scan() {
+				fields := strings.Fields(scanner.Text())
+				if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+					return strings.ToLower(fields[0]), nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("release publishes no checksum for %s", name)
+}
+
+// fetchSmall downloads a small text asset such as a checksum file
+func (s *UpdateService) fetchSmall(url string) (string, error) {
+	resp, err := s.client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download of %s failed with status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return string(body), nil
+}
+
+// fetchVerified downloads url to dest, keeping it only when its SHA-256 matches want
+func (s *UpdateService) fetchVerified(url, dest, want string) error {
+	// Artifacts are large, so the client timeout would cut slow downloads short
+	client := &http.Client{Transport: s.client.Transport}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("update download failed with status %d", resp.StatusCode)
+	}
+
+	partial := dest + ".partial"
+	f, err := os.Create(partial)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", partial, err)
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, hash), resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(partial)
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		os.Remove(partial)
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", want, got)
+	}
+	if err := os.Rename(partial, dest); err != nil {
+		os.Remove(partial)
+		return fmt.Errorf("failed to save update: %w", err)
+	}
+	return nil
+}
+
+// platformAsset picks the raw executable built for this OS and architecture, named like
+// git-tools-v1.2.3-windows-x64.exe or git-tools-v1.2.3-linux-x64; archives never match
+func platformAsset(assets []releaseAsset) *releaseAsset {
+	suffix := "-" + platformName()
+	if runtime.GOOS == "windows" {
+		suffix += ".exe"
+	}
+	for i, a := range assets {
+		if strings.HasSuffix(strings.ToLower(a.Name), suffix) {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+// executableMagic are the leading bytes of the executable formats of each OS
+var executableMagic = map[string][][]byte{
+	"windows": {[]byte("MZ")},
+	"linux":   {[]byte("\x7fELF")},
+	"darwin": {
+		{0xcf, 0xfa, 0xed, 0xfe}, // 64-bit Mach-O
+		{0xce, 0xfa, 0xed, 0xfe}, // 32-bit Mach-O
+		{0xca, 0xfe, 0xba, 0xbe}, // universal binary
+	},
+}
+
+// checkExecutable fails unless path starts like an executable of this OS
+func checkExecutable(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open update: %w", err)
+	}
+	defer f.Close()
+
+	head := make([]byte, 4)
+	n, _ := io.ReadFull(f, head)
+	for _, magic := range executableMagic[runtime.GOOS] {
+		if n >= len(magic) && string(head[:len(magic)]) == string(magic) {
+			return nil
+		}
+	}
+	return fmt.Errorf("update %s is not an executable for %s", filepath.Base(path), platformName())
+}
+
+// platformName names this platform the way the build scripts name artifacts
+func platformName() string {
+	arch := runtime.GOARCH
+	if arch == "amd64" {
+		arch = "x64"
+	}
+	return runtime.GOOS + "-" + arch
+}
+
+// updateDir is where downloaded updates wait to be installed
+func updateDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+	return filepath.Join(configDir, "git-ai-tools", "update")
+}
+
+// copyExecutable copies src to dest and makes it executable
+func copyExecutable(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open update: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to write executable: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return fmt.Errorf("failed to write executable: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dest)
+		return fmt.Errorf("failed to write executable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package watch
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"git-ai-tools/internal/git"
+	"git-ai-tools/internal/logging"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// debounce is how long the repository must stay quiet before a change is reported
+	debounce = 300 * time.Millisecond
+	// pollInterval is how often HEAD and the index are checked when polling
+	pollInterval = time.Second
+	// treeEvery is how many polls pass between checks of the working tree, which run git status
+	treeEvery = 3
+	// maxTreeEvery is how far apart working tree checks get while nothing changes
+	maxTreeEvery = 30
+	// idleAfter is how long the repository must stay unchanged before the checks slow down
+	idleAfter = time.Minute
+)
+
+// Watcher notices changes made to a repository outside the app, such as commits from a
+// terminal or files saved in an editor, and reports them once they settle
+// It watches the working tree, HEAD and the index for file system notifications, and polls
+// them instead when watches cannot be set up, such as when the inotify limit is reached
+type Watcher struct {
+	onStatus func(repoPath string)
+	onBranch func(repoPath, branch string)
+	mu       sync.Mutex
+	stop     chan struct{}
+}
+
+// state is what the watcher compares between polls
+type state struct {
+	head  string
+	index string
+	tree  string
+}
+
+// NewWatcher creates a Watcher calling onStatus when the status of the repository changes and
+// onBranch when HEAD moves to another branch; branch is empty when HEAD is detached
+func NewWatcher(onStatus func(repoPath string), onBranch func(repoPath, branch string)) *Watcher {
+	return &Watcher{onStatus: onStatus, onBranch: onBranch}
+}
+
+// Start watches a repository, replacing the one watched before
+func (w *Watcher) Start(repoPath string) {
+	w.Stop()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stop = make(chan struct{})
+	go w.run(repoPath, w.stop)
+}
+
+// Stop ends watching
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+// run watches the repository until stop is closed
+func (w *Watcher) run(repoPath string, stop chan struct{}) {
+	service := git.NewGitService()
+	if err := service.SetPath(repoPath); err != nil {
+		return
+	}
+	gitDir, err := service.GitDir()
+	if err != nil {
+		return
+	}
+
+	ignored := map[string]bool{}
+	if dirs, err := service.IgnoredDirectories(); err == nil {
+		for _, dir := range dirs {
+			ignored[filepath.Join(service.GetCurrentPath(), filepath.FromSlash(dir))] = true
+		}
+	}
+	notifier, err := watchRepository(service.GetCurrentPath(), gitDir, ignored)
+	if err != nil {
+		logging.Logger().Warn("file system notifications unavailable, polling instead", "repo", repoPath, "error", err)
+		w.poll(repoPath, service, gitDir, stop)
+		return
+	}
+	defer notifier.Close()
+	w.listen(repoPath, notifier, gitDir, ignored, stop)
+}
+
+// watchRepository watches the git directory and every directory of the working tree,
+// except .git and ignored ones
+func watchRepository(root, gitDir string, ignored map[string]bool) (*fsnotify.Watcher, error) {
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := notifier.Add(gitDir); err != nil {
+		notifier.Close()
+		return nil, err
+	}
+	if err := addTree(notifier, root, ignored); err != nil {
+		notifier.Close()
+		return nil, err
+	}
+	return notifier, nil
+}
+
+// addTree watches dir and the directories below it, skipping .git and ignored directories
+// Directories that cannot be read are skipped, failing to add a watch is an error
+func addTree(notifier *fsnotify.Watcher, dir string, ignored map[string]bool) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" || ignored[path] {
+			return filepath.SkipDir
+		}
+		return notifier.Add(path)
+	})
+}
+
+// listen reports changes once notifications stop arriving for the debounce period, so a
+// burst of changes, like a checkout or a rebase, produces a single event
+func (w *Watcher) listen(repoPath string, notifier *fsnotify.Watcher, gitDir string, ignored map[string]bool, stop chan struct{}) {
+	reportedHead := readHead(gitDir)
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case err, ok := <-notifier.Errors:
+			if !ok {
+				return
+			}
+			// Events may have been dropped, such as on a queue overflow, so report anyway
+			logging.Logger().Warn("file system notification error", "repo", repoPath, "error", err)
+			timer.Reset(debounce)
+		case event, ok := <-notifier.Events:
+			if !ok {
+				return
+			}
+			if !relevant(event, gitDir, ignored) {
+				continue
+			}
+			if event.Has(fsnotify.Create) && filepath.Dir(event.Name) != gitDir {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addTree(notifier, event.Name, ignored); err != nil {
+						logging.Logger().Warn("failed to watch new directory", "path", event.Name, "error", err)
+					}
+				}
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			if head := readHead(gitDir); head != reportedHead {
+				reportedHead = head
+				w.onBranch(repoPath, branchOf(head))
+			}
+			w.onStatus(repoPath)
+		}
+	}
+}
+
+// relevant reports whether an event can change the status: in the git directory only HEAD
+// and the index count, in the working tree anything but attribute changes and ignored paths
+func relevant(event fsnotify.Event, gitDir string, ignored map[string]bool) bool {
+	if filepath.Dir(event.Name) == gitDir {
+		name := filepath.Base(event.Name)
+		return name == "HEAD" || name == "index"
+	}
+	return event.Op != fsnotify.Chmod && !ignored[event.Name]
+}
+
+// poll checks the repository on a ticker until stop is closed
+// A change is reported on the first poll that finds nothing new, so a burst of changes
+// produces a single event. While nothing changes the working tree is checked less and
+// less often, HEAD and the index, which are only stat'ed, are not
+func (w *Watcher) poll(repoPath string, service *git.GitService, gitDir string, stop chan struct{}) {
+	last := readState(service, gitDir, true)
+	reportedHead := last.head
+	pending := false
+
+	every := treeEvery
+	changedAt := time.Now()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for poll := 1; ; poll++ {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		current := readState(service, gitDir, poll%every == 0)
+		if current.tree == "" {
+			current.tree = last.tree
+		}
+		if current != last {
+			last = current
+			pending = true
+			every, changedAt = treeEvery, time.Now()
+			continue
+		}
+		if time.Since(changedAt) > idleAfter && poll%every == 0 {
+			every = min(every*2, maxTreeEvery)
+		}
+		if !pending {
+			continue
+		}
+
+		pending = false
+		if last.head != reportedHead {
+			reportedHead = last.head
+			w.onBranch(repoPath, branchOf(last.head))
+		}
+		w.onStatus(repoPath)
+	}
+}
+
+// readState reads HEAD and the index, and the working tree status when withTree is set
+func readState(service *git.GitService, gitDir string, withTree bool) state {
+	s := state{head: readHead(gitDir)}
+	if info, err := os.Stat(filepath.Join(gitDir, "index")); err == nil {
+		s.index = fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+	}
+	if withTree {
+		s.tree, _ = service.WorktreeFingerprint()
+	}
+	return s
+}
+
+// readHead returns the content of the HEAD file, "" when it cannot be read
+func readHead(gitDir string) string {
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(head))
+}
+
+// branchOf returns the branch a HEAD file points to, empty when detached
+func branchOf(head string) string {
+	if branch, ok := strings.CutPrefix(head, "ref: refs/heads/"); ok {
+		return branch
+	}
+	return ""
+}
@@ -4,32 +4,51 @@ import (
 	"embed"
 
 	"git-ai-tools/internal/config"
+	"git-ai-tools/internal/logging"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	// Start file logging before anything else can fail
+	if err := logging.Init(); err != nil {
+		println("Error:", err.Error())
+	}
+
 	// Create config service
 	configService := config.NewConfigService()
 
 	// Create an instance of the app structure
 	app := NewApp(configService)
 
+	// Reopen the window at the size it was closed with
+	window := configService.GetWindowConfig()
+
 	// Create application with options
 	err := wails.Run(&options.App{
 		Title:  "Git AI Tools",
-		Width:  1500,
-		Height: 920,
+		Width:  window.Width,
+		Height: window.Height,
 		AssetServer: &assetserver.Options{
 			Assets: assets,
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.startup,
+		OnBeforeClose:    app.beforeClose,
+		OnShutdown:       app.shutdown,
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:               "git-ai-tools-7d4c2f1e",
+			OnSecondInstanceLaunch: app.onSecondInstanceLaunch,
+		},
+		Mac: &mac.Options{
+			OnUrlOpen: app.handleURL,
+		},
 		Bind: []interface{}{
 			app,
 		},
@@ -0,0 +1,132 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"git-ai-tools/internal/database"
+	"git-ai-tools/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// promptLibrary is the curated catalog of prompt templates shipped with the application
+// Bump an entry's version when its template changes so installed copies offer an update
+//
+//go:embed prompts/library.json
+var promptLibrary []byte
+
+// libraryPrompts decodes the embedded prompt catalog
+func libraryPrompts() ([]models.LibraryPrompt, error) {
+	var prompts []models.LibraryPrompt
+	if err := json.Unmarshal(promptLibrary, &prompts); err != nil {
+		return nil, fmt.Errorf("invalid prompt library: %w", err)
+	}
+	return prompts, nil
+}
+
+// libraryPrompt returns the catalog entry with the given ID
+func libraryPrompt(id string) (*models.LibraryPrompt, error) {
+	prompts, err := libraryPrompts()
+	if err != nil {
+		return nil, err
+	}
+	for i := range prompts {
+		if prompts[i].ID == id {
+			return &prompts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("library prompt not found: %s", id)
+}
+
+// GetPromptLibrary returns the built-in prompt catalog, marking the entries already
+// installed and those with a newer version than the installed copy
+func (ts *TemplateService) GetPromptLibrary() ([]models.LibraryPrompt, error) {
+	prompts, err := libraryPrompts()
+	if err != nil {
+		return nil, err
+	}
+
+	var installed []models.PromptDB
+	database.GetDB().Where("library_id != ''").Find(&installed)
+	byLibraryID := make(map[string]models.PromptDB, len(installed))
+	for _, p := range installed {
+		byLibraryID[p.LibraryID] = p
+	}
+
+	for i := range prompts {
+		if p, ok := byLibraryID[prompts[i].ID]; ok {
+			prompts[i].Installed = true
+			prompts[i].PromptID = p.ID
+			prompts[i].InstalledVersion = p.LibraryVersion
+			prompts[i].UpdateAvailable = p.LibraryVersion < prompts[i].Version
+		}
+	}
+	return prompts, nil
+}
+
+// InstallLibraryPrompt adds a prompt from the built-in library to the user's prompts
+func (ts *TemplateService) InstallLibraryPrompt(id string) (*models.Prompt, error) {
+	entry, err := libraryPrompt(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var count int64
+	database.GetDB().Model(&models.PromptDB{}).Where("library_id = ?", id).Count(&count)
+	if count > 0 {
+		return nil, fmt.Errorf("library prompt is already installed: %s", entry.Name)
+	}
+
+	now := time.Now()
+	prompt := models.PromptDB{
+		Name:           entry.Name,
+		Description:    entry.Description,
+		Template:       entry.Template,
+		LibraryID:      entry.ID,
+		LibraryVersion: entry.Version,
+	}
+	prompt.ID = uuid.New().String()
+	prompt.CreatedAt = now
+	prompt.UpdatedAt = now
+	if err := database.GetDB().Create(&prompt).Error; err != nil {
+		return nil, err
+	}
+	return toPrompt(prompt), nil
+}
+
+// UpdateLibraryPrompt replaces an installed library prompt with the latest catalog version
+// The installed template is saved as a revision first, so local edits can be rolled back
+func (ts *TemplateService) UpdateLibraryPrompt(id string) (*models.Prompt, error) {
+	entry, err := libraryPrompt(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var p models.PromptDB
+	if err := database.GetDB().First(&p, "library_id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("library prompt is not installed: %s", entry.Name)
+	}
+	if p.LibraryVersion >= entry.Version {
+		return toPrompt(p), nil
+	}
+
+	ts.saveRevision(revisionKindPrompt, p.ID, p.Name, p.Description, p.Template, "")
+	p.Name = entry.Name
+	p.Description = entry.Description
+	p.Template = entry.Template
+	p.LibraryVersion = entry.Version
+	p.UpdatedAt = time.Now()
+	if err := database.GetDB().Save(&p).Error; err != nil {
+		return nil, err
+	}
+	return toPrompt(p), nil
+}
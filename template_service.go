@@ -1,16 +1,22 @@
 package main
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	"git-ai-tools/internal/database"
 	"git-ai-tools/internal/models"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // TemplateService manages prompts and custom commands
-type TemplateService struct{}
+// mu serializes writes so default flags and revision pruning stay consistent
+type TemplateService struct {
+	mu sync.Mutex
+}
 
 // NewTemplateService creates a new TemplateService instance
 func NewTemplateService() *TemplateService {
@@ -26,15 +32,7 @@ func (ts *TemplateService) GetPrompts() []models.Prompt {
 
 	result := make([]models.Prompt, len(prompts))
 	for i, p := range prompts {
-		result[i] = models.Prompt{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Template:    p.Template,
-			IsDefault:   p.IsDefault,
-			CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
-		}
+		result[i] = *toPrompt(p)
 	}
 	return result
 }
@@ -45,15 +43,7 @@ func (ts *TemplateService) GetPrompt(id string) *models.Prompt {
 	if err := database.GetDB().First(&p, "id = ?", id).Error; err != nil {
 		return nil
 	}
-	return &models.Prompt{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Template:    p.Template,
-		IsDefault:   p.IsDefault,
-		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
-	}
+	return toPrompt(p)
 }
 
 // GetDefaultPrompt returns the default prompt
@@ -67,32 +57,24 @@ func (ts *TemplateService) GetDefaultPrompt() *models.Prompt {
 			p = prompts[0]
 		} else {
 			// Create default prompts if none exist
+			ts.mu.Lock()
 			ts.createDefaultPrompts()
+			ts.mu.Unlock()
 			database.GetDB().Order("created_at DESC").First(&p)
 		}
 	}
 	if p.ID == "" {
 		return nil
 	}
-	return &models.Prompt{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Template:    p.Template,
-		IsDefault:   p.IsDefault,
-		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
-	}
+	return toPrompt(p)
 }
 
 // CreatePrompt creates a new prompt
 func (ts *TemplateService) CreatePrompt(name, description, template string, isDefault bool) (*models.Prompt, error) {
-	now := time.Now()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
 
-	// If this is default, unset other defaults
-	if isDefault {
-		database.GetDB().Model(&models.PromptDB{}).Update("is_default", false)
-	}
+	now := time.Now()
 
 	prompt := models.PromptDB{
 		Name:        name,
@@ -104,31 +86,34 @@ func (ts *TemplateService) CreatePrompt(name, description, template string, isDe
 	prompt.UpdatedAt = now
 	prompt.ID = uuid.New().String()
 
-	if err := database.GetDB().Create(&prompt).Error; err != nil {
+	// Unsetting other defaults and creating the prompt happen together
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if isDefault {
+			if err := tx.Model(&models.PromptDB{}).Where("1 = 1").Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&prompt).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &models.Prompt{
-		ID:          prompt.ID,
-		Name:        prompt.Name,
-		Description: prompt.Description,
-		Template:    prompt.Template,
-		IsDefault:   prompt.IsDefault,
-		CreatedAt:   prompt.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   prompt.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return toPrompt(prompt), nil
 }
 
 // UpdatePrompt updates an existing prompt
 func (ts *TemplateService) UpdatePrompt(id, name, description, template string, isDefault bool) (*models.Prompt, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
 	var p models.PromptDB
 	if err := database.GetDB().First(&p, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 
-	// If setting as default, unset other defaults
-	if isDefault {
-		database.GetDB().Model(&models.PromptDB{}).Where("id != ?", id).Update("is_default", false)
+	if p.Name != name || p.Description != description || p.Template != template {
+		ts.saveRevision(revisionKindPrompt, p.ID, p.Name, p.Description, p.Template, "")
 	}
 
 	p.Name = name
@@ -137,36 +122,58 @@ func (ts *TemplateService) UpdatePrompt(id, name, description, template string,
 	p.IsDefault = isDefault
 	p.UpdatedAt = time.Now()
 
-	if err := database.GetDB().Save(&p).Error; err != nil {
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		// If setting as default, unset other defaults
+		if isDefault {
+			if err := tx.Model(&models.PromptDB{}).Where("id != ?", id).Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(&p).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &models.Prompt{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Template:    p.Template,
-		IsDefault:   p.IsDefault,
-		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return toPrompt(p), nil
 }
 
-// DeletePrompt deletes a prompt
+// DeletePrompt deletes a prompt, a revision is kept so it can be restored
 func (ts *TemplateService) DeletePrompt(id string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var p models.PromptDB
+	if err := database.GetDB().First(&p, "id = ?", id).Error; err == nil {
+		ts.saveRevision(revisionKindPrompt, p.ID, p.Name, p.Description, p.Template, "")
+	}
 	return database.GetDB().Where("id = ?", id).Delete(&models.PromptDB{}).Error
 }
 
 // SetDefaultPrompt sets a prompt as the default
 func (ts *TemplateService) SetDefaultPrompt(id string) error {
-	// Unset all defaults
-	database.GetDB().Model(&models.PromptDB{}).Update("is_default", false)
-	// Set new default
-	return database.GetDB().Model(&models.PromptDB{}).Where("id = ?", id).Update("is_default", true).Error
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		// Unset all defaults
+		if err := tx.Model(&models.PromptDB{}).Where("id != ?", id).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		// Set new default
+		return tx.Model(&models.PromptDB{}).Where("id = ?", id).Update("is_default", true).Error
+	})
 }
 
-// createDefaultPrompts creates default prompt templates
+// createDefaultPrompts creates default prompt templates, callers must hold mu
 func (ts *TemplateService) createDefaultPrompts() {
+	// Another caller may have created them while we waited for the lock
+	var count int64
+	database.GetDB().Model(&models.PromptDB{}).Count(&count)
+	if count > 0 {
+		return
+	}
+
 	now := time.Now()
 
 	defaultPrompts := []models.PromptDB{
@@ -193,6 +200,21 @@ func (ts *TemplateService) createDefaultPrompts() {
 	database.GetDB().Create(&defaultPrompts)
 }
 
+// toPrompt converts a stored prompt to its API form
+func toPrompt(p models.PromptDB) *models.Prompt {
+	return &models.Prompt{
+		ID:             p.ID,
+		Name:           p.Name,
+		Description:    p.Description,
+		Template:       p.Template,
+		IsDefault:      p.IsDefault,
+		LibraryID:      p.LibraryID,
+		LibraryVersion: p.LibraryVersion,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
 // ============= Command Operations =============
 
 // GetCommands returns all commands
@@ -261,6 +283,9 @@ func (ts *TemplateService) GetCategories() []string {
 
 // CreateCommand creates a new command
 func (ts *TemplateService) CreateCommand(name, description, command, category string) (*models.Command, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
 	now := time.Now()
 
 	if category == "" {
@@ -294,6 +319,9 @@ func (ts *TemplateService) CreateCommand(name, description, command, category st
 
 // UpdateCommand updates an existing command
 func (ts *TemplateService) UpdateCommand(id, name, description, command, category string) (*models.Command, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
 	var c models.CommandDB
 	if err := database.GetDB().First(&c, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -303,6 +331,10 @@ func (ts *TemplateService) UpdateCommand(id, name, description, command, categor
 		category = "自定义"
 	}
 
+	if c.Name != name || c.Description != description || c.Command != command || c.Category != category {
+		ts.saveRevision(revisionKindCommand, c.ID, c.Name, c.Description, c.Command, c.Category)
+	}
+
 	c.Name = name
 	c.Description = description
 	c.Command = command
@@ -324,7 +356,162 @@ func (ts *TemplateService) UpdateCommand(id, name, description, command, categor
 	}, nil
 }
 
-// DeleteCommand deletes a command
+// DeleteCommand deletes a command, a revision is kept so it can be restored
 func (ts *TemplateService) DeleteCommand(id string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var c models.CommandDB
+	if err := database.GetDB().First(&c, "id = ?", id).Error; err == nil {
+		ts.saveRevision(revisionKindCommand, c.ID, c.Name, c.Description, c.Command, c.Category)
+	}
 	return database.GetDB().Where("id = ?", id).Delete(&models.CommandDB{}).Error
 }
+
+// ============= Revision History =============
+
+const (
+	revisionKindPrompt  = "prompt"
+	revisionKindCommand = "command"
+
+	// maxRevisions is the number of revisions kept per prompt or command
+	maxRevisions = 20
+)
+
+// GetPromptHistory returns the saved revisions of a prompt, newest first
+func (ts *TemplateService) GetPromptHistory(id string) []models.TemplateRevision {
+	return ts.getRevisions(revisionKindPrompt, id)
+}
+
+// RollbackPrompt restores a prompt to a saved revision
+// The current version is saved first so the rollback itself can be undone
+func (ts *TemplateService) RollbackPrompt(id, versionID string) (*models.Prompt, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	rev, err := ts.getRevision(revisionKindPrompt, id, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var p models.PromptDB
+	if err := database.GetDB().Unscoped().First(&p, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	if !p.DeletedAt.Valid {
+		ts.saveRevision(revisionKindPrompt, p.ID, p.Name, p.Description, p.Template, "")
+	}
+
+	p.Name = rev.Name
+	p.Description = rev.Description
+	p.Template = rev.Content
+	p.DeletedAt = gorm.DeletedAt{}
+	p.UpdatedAt = time.Now()
+
+	if err := database.GetDB().Unscoped().Save(&p).Error; err != nil {
+		return nil, err
+	}
+
+	return ts.GetPrompt(id), nil
+}
+
+// GetCommandHistory returns the saved revisions of a command, newest first
+func (ts *TemplateService) GetCommandHistory(id string) []models.TemplateRevision {
+	return ts.getRevisions(revisionKindCommand, id)
+}
+
+// RollbackCommand restores a command to a saved revision
+// The current version is saved first so the rollback itself can be undone
+func (ts *TemplateService) RollbackCommand(id, versionID string) (*models.Command, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	rev, err := ts.getRevision(revisionKindCommand, id, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var c models.CommandDB
+	if err := database.GetDB().Unscoped().First(&c, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	if !c.DeletedAt.Valid {
+		ts.saveRevision(revisionKindCommand, c.ID, c.Name, c.Description, c.Command, c.Category)
+	}
+
+	c.Name = rev.Name
+	c.Description = rev.Description
+	c.Command = rev.Content
+	c.Category = rev.Category
+	c.DeletedAt = gorm.DeletedAt{}
+	c.UpdatedAt = time.Now()
+
+	if err := database.GetDB().Unscoped().Save(&c).Error; err != nil {
+		return nil, err
+	}
+
+	return ts.GetCommand(id), nil
+}
+
+// saveRevision records a version of an item and drops revisions beyond maxRevisions
+// Callers must hold mu
+func (ts *TemplateService) saveRevision(kind, itemID, name, description, content, category string) {
+	now := time.Now()
+	rev := models.TemplateRevisionDB{
+		Kind:        kind,
+		ItemID:      itemID,
+		Name:        name,
+		Description: description,
+		Content:     content,
+		Category:    category,
+	}
+	rev.ID = uuid.New().String()
+	rev.CreatedAt = now
+	rev.UpdatedAt = now
+
+	if err := database.GetDB().Create(&rev).Error; err != nil {
+		return
+	}
+
+	var stale []string
+	database.GetDB().Model(&models.TemplateRevisionDB{}).
+		Where("kind = ? AND item_id = ?", kind, itemID).
+		Order("created_at DESC").
+		Offset(maxRevisions).
+		Pluck("id", &stale)
+	if len(stale) > 0 {
+		database.GetDB().Unscoped().Where("id IN ?", stale).Delete(&models.TemplateRevisionDB{})
+	}
+}
+
+// getRevisions returns the revisions of an item, newest first
+func (ts *TemplateService) getRevisions(kind, itemID string) []models.TemplateRevision {
+	var revisions []models.TemplateRevisionDB
+	database.GetDB().Where("kind = ? AND item_id = ?", kind, itemID).Order("created_at DESC").Find(&revisions)
+
+	result := make([]models.TemplateRevision, len(revisions))
+	for i, r := range revisions {
+		result[i] = models.TemplateRevision{
+			ID:          r.ID,
+			ItemID:      r.ItemID,
+			Kind:        r.Kind,
+			Name:        r.Name,
+			Description: r.Description,
+			Content:     r.Content,
+			Category:    r.Category,
+			CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return result
+}
+
+// getRevision returns a single revision belonging to an item
+func (ts *TemplateService) getRevision(kind, itemID, versionID string) (*models.TemplateRevisionDB, error) {
+	var rev models.TemplateRevisionDB
+	if err := database.GetDB().First(&rev, "id = ? AND kind = ? AND item_id = ?", versionID, kind, itemID).Error; err != nil {
+		return nil, fmt.Errorf("revision not found: %w", err)
+	}
+	return &rev, nil
+}
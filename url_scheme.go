@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"git-ai-tools/internal/forge"
+	"git-ai-tools/internal/models"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// URLScheme is the custom protocol registered for "Open in Git AI Tools" links
+const URLScheme = "gitai"
+
+// parseCloneLink turns a gitai://clone?url=...&branch=... link into clone options
+func parseCloneLink(link string) (*models.CloneOptions, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link: %w", err)
+	}
+	if u.Scheme != URLScheme {
+		return nil, fmt.Errorf("unsupported link scheme: %s", u.Scheme)
+	}
+
+	// gitai://clone?... puts the action in the host, gitai:/clone?... in the path
+	action := u.Host
+	if action == "" {
+		action = strings.Trim(u.Path, "/")
+	}
+	if action != "clone" {
+		return nil, fmt.Errorf("unsupported link action: %s", action)
+	}
+
+	repoURL := strings.TrimSpace(u.Query().Get("url"))
+	if repoURL == "" {
+		return nil, fmt.Errorf("link is missing the repository url")
+	}
+	if !isCloneURL(repoURL) {
+		return nil, fmt.Errorf("unsupported repository url: %s", repoURL)
+	}
+
+	return &models.CloneOptions{
+		URL:    repoURL,
+		Branch: u.Query().Get("branch"),
+	}, nil
+}
+
+// isCloneURL accepts the remote URL forms git can clone from over the network
+// Options and "transport::address" remote helpers such as ext:: are refused
+func isCloneURL(repoURL string) bool {
+	if strings.HasPrefix(repoURL, "-") || strings.Contains(repoURL, "::") || strings.ContainsAny(repoURL, " \t\r\n") {
+		return false
+	}
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git://"} {
+		if strings.HasPrefix(repoURL, prefix) {
+			return true
+		}
+	}
+	// scp-like syntax such as git@github.com:owner/repo.git
+	return strings.Contains(repoURL, "@") && strings.Contains(repoURL, ":") && !strings.Contains(repoURL, "://")
+}
+
+// handleURL processes a link opened through the custom protocol
+// The clone options are kept until the frontend asks for them and are also pushed as an event
+func (a *App) handleURL(link string) {
+	opts, err := parseCloneLink(link)
+	if err != nil {
+		if a.ctx != nil {
+			runtime.LogWarningf(a.ctx, "ignoring link %s: %v", link, err)
+		}
+		return
+	}
+
+	// Pre-fill the destination of the clone dialog
+	settings := a.configService.GetCloneSettings()
+	opts.Path, _ = forge.SuggestClonePath(settings.Root, settings.Grouping, opts.URL)
+
+	a.pendingClone.Store(opts)
+	if a.ctx != nil {
+		runtime.WindowUnminimise(a.ctx)
+		runtime.WindowShow(a.ctx)
+		runtime.EventsEmit(a.ctx, "url:clone", opts)
+	}
+}
+
+// handleArgs looks for protocol links among command line arguments
+// Windows and Linux pass the link as an argument to a new process
+func (a *App) handleArgs(args []string) {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, URLScheme+":") {
+			a.handleURL(arg)
+		}
+	}
+}
+
+// onSecondInstanceLaunch forwards links from a second launch to the running instance
+func (a *App) onSecondInstanceLaunch(data options.SecondInstanceData) {
+	a.handleArgs(data.Args)
+}
+
+// TakePendingCloneLink returns the clone options from the last opened link and clears them
+// The frontend calls it on mount for links that arrived before it was listening
+func (a *App) TakePendingCloneLink() *models.CloneOptions {
+	return a.pendingClone.Swap(nil)
+}